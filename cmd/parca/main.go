@@ -17,9 +17,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/alecthomas/kong"
 	"github.com/common-nighthawk/go-figure"
+	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -31,12 +33,31 @@ var (
 	commit  = "dev"
 )
 
+type cli struct {
+	Run    parca.Flags       `cmd:"" default:"withargs" help:"Run Parca."`
+	Import parca.ImportFlags `cmd:"" help:"Import a directory of pprof files into a Parca server."`
+	Replay parca.ReplayFlags `cmd:"" help:"Replay a debug value log to a Parca server."`
+}
+
 func main() {
 	ctx := context.Background()
-	flags := &parca.Flags{}
+	c := &cli{}
 
-	kong.Parse(flags)
+	kctx := kong.Parse(c)
+	switch strings.Fields(kctx.Command())[0] {
+	case "import":
+		runTool(c.Import.LogLevel, "import", func(logger log.Logger) error {
+			return parca.Import(ctx, logger, prometheus.NewRegistry(), &c.Import)
+		})
+		return
+	case "replay":
+		runTool(c.Replay.LogLevel, "replay", func(logger log.Logger) error {
+			return parca.Replay(ctx, logger, prometheus.NewRegistry(), &c.Replay)
+		})
+		return
+	}
 
+	flags := &c.Run
 	if flags.Version {
 		fmt.Printf("parca, version %s (commit: %s)\n", version, commit)
 		return
@@ -62,3 +83,14 @@ func main() {
 
 	level.Info(logger).Log("msg", "exited")
 }
+
+// runTool runs a command other than the server, exiting with an error code
+// if it fails.
+func runTool(logLevel, name string, run func(log.Logger) error) {
+	logger := parca.NewLogger(logLevel, parca.LogFormatLogfmt, "parca-"+name)
+
+	if err := run(logger); err != nil {
+		level.Error(logger).Log("msg", "Command failed", "command", name, "err", err)
+		os.Exit(1)
+	}
+}
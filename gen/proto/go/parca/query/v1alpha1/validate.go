@@ -16,8 +16,10 @@ package queryv1alpha1
 
 import (
 	"fmt"
+	"regexp"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -27,6 +29,7 @@ func (r *QueryRangeRequest) Validate() error {
 		validation.Field(&r.Start, validation.Required),
 		validation.Field(&r.End, validation.Required, isAfter(r.Start)),
 		validation.Field(&r.Query, validation.Required),
+		validation.Field(&r.Step, isNonNegative()),
 	)
 }
 
@@ -67,6 +70,21 @@ func (r *QueryRequest) Validate() error {
 		if err != nil {
 			return err
 		}
+	case QueryRequest_MODE_LABEL_DIFF:
+		err := validateLabelDiff(r.GetLabelDiff())
+		if err != nil {
+			return err
+		}
+	case QueryRequest_MODE_RATIO:
+		err := validateRatio(r.GetRatio())
+		if err != nil {
+			return err
+		}
+	case QueryRequest_MODE_TRACE:
+		err := validateTrace(r.GetTrace())
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("invalid mode")
 	}
@@ -97,6 +115,47 @@ func validateMerge(merge *MergeProfile) error {
 	)
 }
 
+func validateLabelDiff(diff *LabelDiffProfile) error {
+	if diff == nil {
+		return fmt.Errorf("label diff must not be unset")
+	}
+
+	return validation.ValidateStruct(diff,
+		validation.Field(&diff.Start, validation.Required),
+		validation.Field(&diff.End, validation.Required, isAfter(diff.Start)),
+		validation.Field(&diff.Query, validation.Required),
+		validation.Field(&diff.LabelName, validation.Required),
+		validation.Field(&diff.B, validation.NotIn(diff.A).Error("must differ from a")),
+	)
+}
+
+func validateRatio(ratio *RatioProfile) error {
+	if ratio == nil {
+		return fmt.Errorf("ratio must not be unset")
+	}
+
+	return validation.ValidateStruct(ratio,
+		validation.Field(&ratio.Start, validation.Required),
+		validation.Field(&ratio.End, validation.Required, isAfter(ratio.Start)),
+		validation.Field(&ratio.Numerator, validation.Required, isProfileType()),
+		validation.Field(&ratio.Denominator, validation.Required, isProfileType()),
+		validation.Field(&ratio.Scale, validation.Min(float64(0))),
+	)
+}
+
+func validateTrace(trace *TraceProfile) error {
+	if trace == nil {
+		return fmt.Errorf("trace must not be unset")
+	}
+
+	return validation.ValidateStruct(trace,
+		validation.Field(&trace.Start, validation.Required),
+		validation.Field(&trace.End, validation.Required, isAfter(trace.Start)),
+		validation.Field(&trace.Query, validation.Required),
+		validation.Field(&trace.TraceId, validation.Required.When(trace.SpanId == "").Error("trace_id or span_id is required")),
+	)
+}
+
 func validateDiff(diff *DiffProfile) error {
 	if diff == nil {
 		return fmt.Errorf("diff must not be unset")
@@ -225,11 +284,34 @@ func (o ProfileOptionMatchesRule) Validate(v interface{}) error {
 			return fmt.Errorf("invalid option for mode")
 		}
 		return nil
+	case QueryRequest_MODE_LABEL_DIFF:
+		if _, ok := option.(*QueryRequest_LabelDiff); !ok {
+			return fmt.Errorf("invalid option for mode")
+		}
+		return nil
+	case QueryRequest_MODE_RATIO:
+		if _, ok := option.(*QueryRequest_Ratio); !ok {
+			return fmt.Errorf("invalid option for mode")
+		}
+		return nil
+	case QueryRequest_MODE_TRACE:
+		if _, ok := option.(*QueryRequest_Trace); !ok {
+			return fmt.Errorf("invalid option for mode")
+		}
+		return nil
 	default:
 		return fmt.Errorf("invalid query request mode")
 	}
 }
 
+// profileTypeRegexp matches profile types of the form
+// <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>(:delta).
+var profileTypeRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(:[a-zA-Z0-9_]+){4}(:delta)?$`)
+
+func isProfileType() validation.Rule {
+	return validation.Match(profileTypeRegexp).Error("must be of the form <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>(:delta)")
+}
+
 type QueryModeRule struct{}
 
 func isQueryMode() QueryModeRule { return QueryModeRule{} }
@@ -254,6 +336,24 @@ func isAfter(t *timestamppb.Timestamp) AfterRule {
 	}
 }
 
+// NonNegativeRule validates that the duration is not negative.
+type NonNegativeRule struct{}
+
+func isNonNegative() NonNegativeRule { return NonNegativeRule{} }
+
+// Validate runs the validation function for the NonNegativeRule.
+func (r NonNegativeRule) Validate(v interface{}) error {
+	d, ok := v.(*durationpb.Duration)
+	if !ok {
+		return fmt.Errorf("value is not a duration")
+	}
+	if d != nil && d.AsDuration() < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+
+	return nil
+}
+
 type DiffSelectionModeRule struct{}
 
 func isDiffSelectionMode() DiffSelectionModeRule { return DiffSelectionModeRule{} }
@@ -28,7 +28,9 @@ import (
 	"github.com/prometheus/prometheus/discovery"
 	_ "github.com/prometheus/prometheus/discovery/install" // Imported for registration side-effect
 	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/thanos-io/objstore/client"
 	"gopkg.in/yaml.v2"
 )
@@ -43,8 +45,25 @@ const (
 
 // Config holds all the configuration information for Parca.
 type Config struct {
-	ObjectStorage *ObjectStorage  `yaml:"object_storage,omitempty"`
-	ScrapeConfigs []*ScrapeConfig `yaml:"scrape_configs,omitempty"`
+	ObjectStorage     *ObjectStorage      `yaml:"object_storage,omitempty"`
+	ScrapeConfigs     []*ScrapeConfig     `yaml:"scrape_configs,omitempty"`
+	StackFoldingRules []*StackFoldingRule `yaml:"stack_folding_rules,omitempty"`
+	// WriteRelabelConfigs are applied to the labels of every profile written
+	// to the profile store, regardless of whether it was scraped or pushed.
+	WriteRelabelConfigs []*relabel.Config `yaml:"write_relabel_configs,omitempty"`
+	// Limits are enforced on the profiles written by every tenant.
+	Limits *Limits `yaml:"limits,omitempty"`
+	// TenantLimits override the limits of individual tenants.
+	TenantLimits map[string]*Limits `yaml:"tenant_limits,omitempty"`
+	// SampleLabels configures which pprof labels of samples are stored.
+	SampleLabels *SampleLabels `yaml:"sample_labels,omitempty"`
+	// HATracker configures the deduplication of profiles written by
+	// replicated scrapers.
+	HATracker *HATracker `yaml:"ha_tracker,omitempty"`
+	// Retention configures the deletion of expired profiles.
+	Retention *Retention `yaml:"retention,omitempty"`
+	// Rollups configures the downsampling of old profiles.
+	Rollups *Rollups `yaml:"rollups,omitempty"`
 }
 
 type ObjectStorage struct {
@@ -291,3 +310,352 @@ func CheckTargetAddress(address model.LabelValue) error {
 	}
 	return nil
 }
+
+// StackFoldingAction is the action a stack folding rule applies to matching frames.
+type StackFoldingAction string
+
+const (
+	// StackFoldingDrop removes matching frames from the stack.
+	StackFoldingDrop StackFoldingAction = "drop"
+	// StackFoldingCollapse replaces consecutive matching frames with a single
+	// synthetic frame.
+	StackFoldingCollapse StackFoldingAction = "collapse"
+)
+
+// StackFoldingRule configures how frames of queried profiles are folded.
+type StackFoldingRule struct {
+	// Regular expression matched against the function name of each frame.
+	// The expression is anchored on both ends.
+	Match relabel.Regexp `yaml:"match"`
+	// Action to perform on matching frames, defaults to collapse.
+	Action StackFoldingAction `yaml:"action,omitempty"`
+	// Name of the synthetic frame that collapsed frames are replaced with.
+	Replacement string `yaml:"replacement,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *StackFoldingRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain StackFoldingRule
+	unmarshalled := plain{
+		Action: StackFoldingCollapse,
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+
+	if unmarshalled.Match.Regexp == nil {
+		return errors.New("stack folding rule is missing a match expression")
+	}
+
+	switch unmarshalled.Action {
+	case StackFoldingDrop:
+	case StackFoldingCollapse:
+		if unmarshalled.Replacement == "" {
+			return fmt.Errorf("stack folding rule %q: collapse requires a replacement", unmarshalled.Match.String())
+		}
+	default:
+		return fmt.Errorf("stack folding rule %q: unknown action %q", unmarshalled.Match.String(), unmarshalled.Action)
+	}
+
+	*r = StackFoldingRule(unmarshalled)
+	return nil
+}
+
+// Limits configures the limits enforced on written profiles. A zero value
+// disables a limit, or in case of the limits of a tenant, falls back to the
+// default limit.
+type Limits struct {
+	// Maximum size of a profile as it is received, possibly compressed.
+	MaxProfileSizeBytes int64 `yaml:"max_profile_size_bytes,omitempty"`
+	// Maximum number of samples of a profile.
+	MaxSamplesPerProfile int `yaml:"max_samples_per_profile,omitempty"`
+	// Maximum number of locations of a stack.
+	MaxStackDepth int `yaml:"max_stack_depth,omitempty"`
+	// Maximum number of series that have been written to in the last hour.
+	MaxActiveSeries int `yaml:"max_active_series,omitempty"`
+	// Number of bytes of profiles per second that may be written.
+	IngestionRateBytes int64 `yaml:"ingestion_rate_bytes,omitempty"`
+	// Number of bytes that may be written at once, defaults to the
+	// ingestion rate. Profiles larger than the burst are always rejected.
+	IngestionBurstBytes int64 `yaml:"ingestion_burst_bytes,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (l *Limits) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Limits
+	unmarshalled := plain{}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+
+	if unmarshalled.MaxProfileSizeBytes < 0 ||
+		unmarshalled.MaxSamplesPerProfile < 0 ||
+		unmarshalled.MaxStackDepth < 0 ||
+		unmarshalled.MaxActiveSeries < 0 ||
+		unmarshalled.IngestionRateBytes < 0 ||
+		unmarshalled.IngestionBurstBytes < 0 {
+		return errors.New("limits must not be negative")
+	}
+
+	*l = Limits(unmarshalled)
+	return nil
+}
+
+// Merge returns the limits of l with all unset limits taken from defaults.
+func (l *Limits) Merge(defaults *Limits) Limits {
+	res := Limits{}
+	if defaults != nil {
+		res = *defaults
+	}
+	if l == nil {
+		return res
+	}
+
+	if l.MaxProfileSizeBytes != 0 {
+		res.MaxProfileSizeBytes = l.MaxProfileSizeBytes
+	}
+	if l.MaxSamplesPerProfile != 0 {
+		res.MaxSamplesPerProfile = l.MaxSamplesPerProfile
+	}
+	if l.MaxStackDepth != 0 {
+		res.MaxStackDepth = l.MaxStackDepth
+	}
+	if l.MaxActiveSeries != 0 {
+		res.MaxActiveSeries = l.MaxActiveSeries
+	}
+	if l.IngestionRateBytes != 0 {
+		res.IngestionRateBytes = l.IngestionRateBytes
+	}
+	if l.IngestionBurstBytes != 0 {
+		res.IngestionBurstBytes = l.IngestionBurstBytes
+	}
+	return res
+}
+
+// DefaultSampleLabelOverflowValue is the value that pprof label values are
+// replaced with once a key has reached its maximum number of values.
+const DefaultSampleLabelOverflowValue = "__overflow__"
+
+// DefaultSampleLabelValuesWindow is the default time after which the distinct
+// values counted towards the maximum of their key are forgotten.
+const DefaultSampleLabelValuesWindow = model.Duration(24 * time.Hour)
+
+// SampleLabels configures which pprof labels of samples are stored. Every
+// distinct key becomes a column, and every distinct value grows the
+// dictionary of its column.
+type SampleLabels struct {
+	// Keys of the labels that are stored, all other labels are dropped.
+	AllowKeys []string `yaml:"allow_keys,omitempty"`
+	// Keys of the labels that are dropped.
+	DenyKeys []string `yaml:"deny_keys,omitempty"`
+	// Maximum number of distinct values stored per key, further values are
+	// replaced with the overflow value. Zero disables the limit.
+	MaxValuesPerKey int `yaml:"max_values_per_key,omitempty"`
+	// Value that values exceeding the maximum are replaced with.
+	OverflowValue string `yaml:"overflow_value,omitempty"`
+	// Time after which the values counted towards the maximum are
+	// forgotten, so that new values are stored again. The values are only
+	// counted in memory, a restart forgets them as well.
+	ValuesWindow model.Duration `yaml:"values_window,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *SampleLabels) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain SampleLabels
+	unmarshalled := plain{
+		OverflowValue: DefaultSampleLabelOverflowValue,
+		ValuesWindow:  DefaultSampleLabelValuesWindow,
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+
+	if len(unmarshalled.AllowKeys) > 0 && len(unmarshalled.DenyKeys) > 0 {
+		return errors.New("sample labels: only one of allow_keys and deny_keys may be set")
+	}
+	if unmarshalled.MaxValuesPerKey < 0 {
+		return errors.New("sample labels: max_values_per_key must not be negative")
+	}
+	if unmarshalled.OverflowValue == "" {
+		return errors.New("sample labels: overflow_value must not be empty")
+	}
+	if unmarshalled.ValuesWindow <= 0 {
+		return errors.New("sample labels: values_window must be positive")
+	}
+
+	*c = SampleLabels(unmarshalled)
+	return nil
+}
+
+// The defaults of the HA tracker.
+const (
+	DefaultHAClusterLabel    = "cluster"
+	DefaultHAReplicaLabel    = "__replica__"
+	DefaultHAFailoverTimeout = model.Duration(30 * time.Second)
+)
+
+// HATracker configures the deduplication of profiles written by the replicas
+// of highly available scrapers. Of every cluster only the profiles of one
+// elected replica are stored, until it hasn't written for the failover
+// timeout.
+type HATracker struct {
+	// Label whose value identifies the cluster of a replica.
+	ClusterLabel string `yaml:"cluster_label,omitempty"`
+	// Label whose value identifies a replica, it is removed before profiles
+	// are stored.
+	ReplicaLabel string `yaml:"replica_label,omitempty"`
+	// Time after the last write of the elected replica that another replica
+	// is elected.
+	FailoverTimeout model.Duration `yaml:"failover_timeout,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *HATracker) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain HATracker
+	unmarshalled := plain{
+		ClusterLabel:    DefaultHAClusterLabel,
+		ReplicaLabel:    DefaultHAReplicaLabel,
+		FailoverTimeout: DefaultHAFailoverTimeout,
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+
+	if !model.LabelName(unmarshalled.ClusterLabel).IsValid() {
+		return fmt.Errorf("ha tracker: invalid cluster_label %q", unmarshalled.ClusterLabel)
+	}
+	if !model.LabelName(unmarshalled.ReplicaLabel).IsValid() {
+		return fmt.Errorf("ha tracker: invalid replica_label %q", unmarshalled.ReplicaLabel)
+	}
+	if unmarshalled.ClusterLabel == unmarshalled.ReplicaLabel {
+		return errors.New("ha tracker: cluster_label and replica_label must differ")
+	}
+	if unmarshalled.FailoverTimeout <= 0 {
+		return errors.New("ha tracker: failover_timeout must be positive")
+	}
+
+	*c = HATracker(unmarshalled)
+	return nil
+}
+
+// DefaultRetentionInterval is the default interval at which expired profiles
+// are deleted.
+const DefaultRetentionInterval = model.Duration(time.Hour)
+
+// Retention configures the deletion of profiles older than their maximum age.
+// The maximum age of a profile is the one of the first rule whose selector
+// matches its labels, or the default maximum age if none does. A maximum age
+// of zero keeps profiles forever. Profiles are only deleted from persisted
+// blocks, so deleting any requires persistence to be enabled. Rollups are
+// deleted by the same rules.
+type Retention struct {
+	// Default maximum age of profiles.
+	MaxAge model.Duration `yaml:"max_age,omitempty"`
+	// Interval at which expired profiles are deleted.
+	Interval model.Duration `yaml:"interval,omitempty"`
+	// Rules overriding the maximum age of the profiles they select.
+	Rules []*RetentionRule `yaml:"rules,omitempty"`
+}
+
+// RetentionRule overrides the maximum age of the profiles whose labels match
+// its selector.
+type RetentionRule struct {
+	// Label selector, for example {namespace="dev"}.
+	Selector string `yaml:"selector"`
+	// Maximum age of the selected profiles.
+	MaxAge model.Duration `yaml:"max_age,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *Retention) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Retention
+	unmarshalled := plain{
+		Interval: DefaultRetentionInterval,
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+
+	if unmarshalled.MaxAge < 0 {
+		return errors.New("retention: max_age must not be negative")
+	}
+	if unmarshalled.Interval <= 0 {
+		return errors.New("retention: interval must be positive")
+	}
+	for _, r := range unmarshalled.Rules {
+		if r == nil {
+			return errors.New("retention: empty rule")
+		}
+		if _, err := r.Matchers(); err != nil {
+			return fmt.Errorf("retention: %w", err)
+		}
+		if r.MaxAge < 0 {
+			return fmt.Errorf("retention: max_age of selector %q must not be negative", r.Selector)
+		}
+	}
+
+	*c = Retention(unmarshalled)
+	return nil
+}
+
+// Matchers returns the label matchers of the selector of the rule. Profile
+// types can't be selected, as they are not labels.
+func (r *RetentionRule) Matchers() ([]*labels.Matcher, error) {
+	matchers, err := parser.ParseMetricSelector(r.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("parse selector %q: %w", r.Selector, err)
+	}
+	for _, m := range matchers {
+		if m.Name == labels.MetricName {
+			return nil, fmt.Errorf("selector %q must only match labels", r.Selector)
+		}
+	}
+	return matchers, nil
+}
+
+// Defaults of the rollup configuration.
+const (
+	DefaultRollupResolution = model.Duration(time.Hour)
+	DefaultRollupInterval   = model.Duration(10 * time.Minute)
+)
+
+// Rollups configures the downsampling of profiles older than a minimum age.
+// Their values are summed per series, stacktrace, pprof labels and profile
+// type in time buckets of the resolution, which queries read instead of the
+// individual profiles when the requested range and step allow it. Rollups
+// are a query cache, they don't replace the profiles, which are kept until
+// retention deletes them.
+type Rollups struct {
+	// Minimum age of the profiles that are rolled up.
+	After model.Duration `yaml:"after"`
+	// Width of the time buckets. It can't be changed once profiles were
+	// rolled up.
+	Resolution model.Duration `yaml:"resolution,omitempty"`
+	// Interval at which new rollups are computed.
+	Interval model.Duration `yaml:"interval,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *Rollups) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Rollups
+	unmarshalled := plain{
+		Resolution: DefaultRollupResolution,
+		Interval:   DefaultRollupInterval,
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+
+	if unmarshalled.Resolution <= 0 {
+		return errors.New("rollups: resolution must be positive")
+	}
+	if unmarshalled.After < unmarshalled.Resolution {
+		return errors.New("rollups: after must not be less than the resolution")
+	}
+	if unmarshalled.Interval <= 0 {
+		return errors.New("rollups: interval must be positive")
+	}
+
+	*c = Rollups(unmarshalled)
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/discovery"
+	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/stretchr/testify/require"
 	"github.com/thanos-io/objstore/client"
 )
@@ -194,6 +195,200 @@ scrape_configs:
 	require.Equal(t, expected, c)
 }
 
+func TestLoadStackFoldingRules(t *testing.T) {
+	t.Parallel()
+
+	c, err := Load(`
+stack_folding_rules:
+  - match: 'google\.golang\.org/grpc\..*'
+    replacement: '[grpc]'
+  - match: 'runtime\.goexit'
+    action: drop
+`)
+	require.NoError(t, err)
+	require.Len(t, c.StackFoldingRules, 2)
+	require.Equal(t, StackFoldingCollapse, c.StackFoldingRules[0].Action)
+	require.Equal(t, "[grpc]", c.StackFoldingRules[0].Replacement)
+	require.True(t, c.StackFoldingRules[0].Match.MatchString("google.golang.org/grpc.(*Server).serveStreams"))
+	require.False(t, c.StackFoldingRules[0].Match.MatchString("main.google.golang.org/grpc.x"))
+	require.Equal(t, StackFoldingDrop, c.StackFoldingRules[1].Action)
+
+	invalid := map[string]string{
+		"missingMatch": `
+stack_folding_rules:
+  - action: drop
+`,
+		"missingReplacement": `
+stack_folding_rules:
+  - match: 'runtime\..*'
+`,
+		"unknownAction": `
+stack_folding_rules:
+  - match: 'runtime\..*'
+    action: hide
+`,
+	}
+	for name, yaml := range invalid {
+		yaml := yaml
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Load(yaml)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWriteRelabelConfigs(t *testing.T) {
+	t.Parallel()
+
+	c, err := Load(`
+write_relabel_configs:
+  - action: labeldrop
+    regex: 'pod_template_hash'
+  - source_labels: [__name__]
+    regex: 'goroutine'
+    action: drop
+`)
+	require.NoError(t, err)
+	require.Len(t, c.WriteRelabelConfigs, 2)
+	require.Equal(t, relabel.LabelDrop, c.WriteRelabelConfigs[0].Action)
+	require.Equal(t, relabel.Drop, c.WriteRelabelConfigs[1].Action)
+
+	_, err = Load(`
+write_relabel_configs:
+  - action: replace
+    target_label: '0invalid'
+`)
+	require.Error(t, err)
+}
+
+func TestLoadLimits(t *testing.T) {
+	t.Parallel()
+
+	c, err := Load(`
+limits:
+  max_profile_size_bytes: 1024
+  max_active_series: 10
+tenant_limits:
+  team-a:
+    max_active_series: 100
+`)
+	require.NoError(t, err)
+	require.Equal(t, Limits{
+		MaxProfileSizeBytes: 1024,
+		MaxActiveSeries:     100,
+	}, c.TenantLimits["team-a"].Merge(c.Limits))
+	require.Equal(t, *c.Limits, c.TenantLimits["team-b"].Merge(c.Limits))
+
+	_, err = Load(`
+limits:
+  max_stack_depth: -1
+`)
+	require.Error(t, err)
+}
+
+func TestLoadSampleLabels(t *testing.T) {
+	t.Parallel()
+
+	c, err := Load(`
+sample_labels:
+  deny_keys: [request_id]
+  max_values_per_key: 100
+`)
+	require.NoError(t, err)
+	require.Equal(t, &SampleLabels{
+		DenyKeys:        []string{"request_id"},
+		MaxValuesPerKey: 100,
+		OverflowValue:   DefaultSampleLabelOverflowValue,
+		ValuesWindow:    DefaultSampleLabelValuesWindow,
+	}, c.SampleLabels)
+
+	_, err = Load(`
+sample_labels:
+  allow_keys: [handler]
+  deny_keys: [request_id]
+`)
+	require.Error(t, err)
+
+	_, err = Load(`
+sample_labels:
+  values_window: 0s
+`)
+	require.Error(t, err)
+}
+
+func TestLoadHATracker(t *testing.T) {
+	t.Parallel()
+
+	c, err := Load(`
+ha_tracker:
+  failover_timeout: 1m
+`)
+	require.NoError(t, err)
+	require.Equal(t, &HATracker{
+		ClusterLabel:    DefaultHAClusterLabel,
+		ReplicaLabel:    DefaultHAReplicaLabel,
+		FailoverTimeout: model.Duration(time.Minute),
+	}, c.HATracker)
+
+	_, err = Load(`
+ha_tracker:
+  replica_label: cluster
+`)
+	require.Error(t, err)
+}
+
+func TestLoadRetention(t *testing.T) {
+	t.Parallel()
+
+	c, err := Load(`
+retention:
+  max_age: 30d
+  rules:
+  - selector: '{namespace="dev"}'
+    max_age: 3d
+`)
+	require.NoError(t, err)
+	require.Equal(t, &Retention{
+		MaxAge:   model.Duration(30 * 24 * time.Hour),
+		Interval: DefaultRetentionInterval,
+		Rules: []*RetentionRule{{
+			Selector: `{namespace="dev"}`,
+			MaxAge:   model.Duration(3 * 24 * time.Hour),
+		}},
+	}, c.Retention)
+
+	_, err = Load(`
+retention:
+  rules:
+  - selector: 'process_cpu{namespace="dev"}'
+    max_age: 3d
+`)
+	require.Error(t, err)
+}
+
+func TestLoadRollups(t *testing.T) {
+	t.Parallel()
+
+	c, err := Load(`
+rollups:
+  after: 7d
+`)
+	require.NoError(t, err)
+	require.Equal(t, &Rollups{
+		After:      model.Duration(7 * 24 * time.Hour),
+		Resolution: DefaultRollupResolution,
+		Interval:   DefaultRollupInterval,
+	}, c.Rollups)
+
+	_, err = Load(`
+rollups:
+  after: 1m
+  resolution: 5m
+`)
+	require.Error(t, err)
+}
+
 func Test_Config_Validation(t *testing.T) {
 	t.Parallel()
 
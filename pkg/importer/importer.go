@@ -0,0 +1,395 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package importer imports directories of pprof files into a profile store.
+package importer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profilestore"
+)
+
+// SidecarSuffix is the suffix of the path of the JSON file holding the
+// labels of the profile at the path without it.
+const SidecarSuffix = ".json"
+
+// Config configures an import.
+type Config struct {
+	// Dir is the directory whose profiles are imported.
+	Dir string
+	// PathTemplate extracts labels from the paths of the profiles. Profiles
+	// whose path doesn't match it are skipped. If it is nil, all files but
+	// sidecar files are imported.
+	PathTemplate *PathTemplate
+	// Labels are added to all profiles. Labels of the path template take
+	// precedence over them and labels of sidecar files over both.
+	Labels map[string]string
+	// Concurrency is the number of profiles written at the same time.
+	Concurrency int
+	// StateFile records the absolute paths of imported profiles, which are
+	// skipped when the import is run again, so the import can be resumed from
+	// another working directory or with another path to the directory. Empty
+	// disables resuming.
+	StateFile string
+	// ProgressInterval is the interval at which progress is logged.
+	ProgressInterval time.Duration
+}
+
+// Failure is a profile that failed to be imported.
+type Failure struct {
+	Path string
+	Err  error
+}
+
+// Summary is the outcome of an import.
+type Summary struct {
+	// Imported is the number of profiles imported.
+	Imported int
+	// Resumed is the number of profiles skipped as they were imported by a
+	// previous run.
+	Resumed int
+	// Skipped is the number of files skipped as they don't match the path
+	// template.
+	Skipped int
+	// Failures are the profiles that failed to be imported, sorted by path.
+	Failures []Failure
+}
+
+// Importer writes the profiles of a directory to a profile store.
+type Importer struct {
+	logger log.Logger
+	client profilestorepb.ProfileStoreServiceClient
+	cfg    Config
+}
+
+// New returns an Importer writing profiles to the client.
+func New(logger log.Logger, client profilestorepb.ProfileStoreServiceClient, cfg Config) *Importer {
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	return &Importer{
+		logger: logger,
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+// Run imports all profiles of the directory that have not been imported by a
+// previous run. Failing profiles don't stop the import, they are reported in
+// the summary. An error is only returned if the import could not be run to
+// completion.
+func (i *Importer) Run(ctx context.Context) (*Summary, error) {
+	imported, err := readState(i.cfg.StateFile)
+	if err != nil {
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+
+	dir, err := filepath.Abs(i.cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve directory: %w", err)
+	}
+	// stateKey returns the path recorded in the state file for the path
+	// relative to the directory.
+	stateKey := func(path string) string {
+		return filepath.ToSlash(filepath.Join(dir, filepath.FromSlash(path)))
+	}
+
+	paths, err := i.walk()
+	if err != nil {
+		return nil, fmt.Errorf("walk directory: %w", err)
+	}
+
+	summary := &Summary{}
+	todo := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if _, ok := imported[stateKey(path)]; ok {
+			summary.Resumed++
+			continue
+		}
+		if i.cfg.PathTemplate != nil {
+			if _, ok := i.cfg.PathTemplate.Labels(path); !ok {
+				summary.Skipped++
+				continue
+			}
+		}
+		todo = append(todo, path)
+	}
+
+	var state *os.File
+	if i.cfg.StateFile != "" {
+		state, err = os.OpenFile(i.cfg.StateFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open state file: %w", err)
+		}
+		defer state.Close()
+	}
+
+	level.Info(i.logger).Log(
+		"msg", "importing profiles",
+		"dir", i.cfg.Dir,
+		"profiles", len(todo),
+		"resumed", summary.Resumed,
+		"skipped", summary.Skipped,
+	)
+
+	var (
+		done     int64
+		failed   int64
+		mtx      sync.Mutex
+		failures []Failure
+		stateErr error
+	)
+
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for w := 0; w < i.cfg.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range queue {
+				if err := i.importProfile(ctx, path); err != nil {
+					atomic.AddInt64(&failed, 1)
+					mtx.Lock()
+					failures = append(failures, Failure{Path: path, Err: err})
+					mtx.Unlock()
+					level.Debug(i.logger).Log("msg", "failed to import profile", "path", path, "err", err)
+					continue
+				}
+				atomic.AddInt64(&done, 1)
+
+				if state == nil {
+					continue
+				}
+				mtx.Lock()
+				if stateErr == nil {
+					stateErr = recordState(state, stateKey(path))
+				}
+				mtx.Unlock()
+			}
+		}()
+	}
+
+	progressDone := make(chan struct{})
+	if i.cfg.ProgressInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(i.cfg.ProgressInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-progressDone:
+					return
+				case <-ticker.C:
+					level.Info(i.logger).Log(
+						"msg", "import progress",
+						"imported", atomic.LoadInt64(&done),
+						"failed", atomic.LoadInt64(&failed),
+						"total", len(todo),
+					)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, path := range todo {
+		select {
+		case <-ctx.Done():
+			break feed
+		case queue <- path:
+		}
+	}
+	close(queue)
+	wg.Wait()
+	close(progressDone)
+
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Path < failures[j].Path })
+	summary.Imported = int(done)
+	summary.Failures = failures
+
+	if stateErr != nil {
+		return summary, fmt.Errorf("record imported profile: %w", stateErr)
+	}
+	return summary, ctx.Err()
+}
+
+// walk returns the sorted slash separated paths of all files of the directory
+// relative to it, except for sidecar files and the state file.
+func (i *Importer) walk() ([]string, error) {
+	var state string
+	if i.cfg.StateFile != "" {
+		var err error
+		state, err = filepath.Abs(i.cfg.StateFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var paths []string
+	err := filepath.WalkDir(i.cfg.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || strings.HasSuffix(path, SidecarSuffix) {
+			return nil
+		}
+		if abs, err := filepath.Abs(path); err == nil && abs == state {
+			return nil
+		}
+
+		rel, err := filepath.Rel(i.cfg.Dir, path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// importProfile writes the profile at the path relative to the directory.
+func (i *Importer) importProfile(ctx context.Context, path string) error {
+	file := filepath.Join(i.cfg.Dir, filepath.FromSlash(path))
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	content, _, err := profilestore.Decompress(raw, 0)
+	if err != nil {
+		return err
+	}
+	p := &pprofpb.Profile{}
+	if err := p.UnmarshalVT(content); err != nil {
+		return fmt.Errorf("parse profile: %w", err)
+	}
+	if p.TimeNanos == 0 {
+		return errors.New("profile has no timestamp")
+	}
+
+	ls, err := i.labels(path, file)
+	if err != nil {
+		return err
+	}
+
+	_, err = i.client.WriteRaw(ctx, &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels: &profilestorepb.LabelSet{Labels: ls},
+			Samples: []*profilestorepb.RawSample{{
+				RawProfile: raw,
+			}},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("write profile: %w", err)
+	}
+	return nil
+}
+
+// labels returns the sorted labels of the profile at the path relative to the
+// directory.
+func (i *Importer) labels(path, file string) ([]*profilestorepb.Label, error) {
+	m := make(map[string]string, len(i.cfg.Labels))
+	for name, value := range i.cfg.Labels {
+		m[name] = value
+	}
+
+	if i.cfg.PathTemplate != nil {
+		ls, _ := i.cfg.PathTemplate.Labels(path)
+		for name, value := range ls {
+			m[name] = value
+		}
+	}
+
+	sidecar, err := os.ReadFile(file + SidecarSuffix)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read sidecar file: %w", err)
+	}
+	if err == nil {
+		ls := map[string]string{}
+		if err := json.Unmarshal(sidecar, &ls); err != nil {
+			return nil, fmt.Errorf("parse sidecar file: %w", err)
+		}
+		for name, value := range ls {
+			m[name] = value
+		}
+	}
+
+	if m["__name__"] == "" {
+		return nil, errors.New("missing __name__ label")
+	}
+
+	ls := make([]*profilestorepb.Label, 0, len(m))
+	for name, value := range m {
+		ls = append(ls, &profilestorepb.Label{Name: name, Value: value})
+	}
+	sort.Slice(ls, func(i, j int) bool { return ls[i].Name < ls[j].Name })
+	return ls, nil
+}
+
+// readState returns the paths recorded in the state file, which may not
+// exist yet.
+func readState(path string) (map[string]struct{}, error) {
+	imported := map[string]struct{}{}
+	if path == "" {
+		return imported, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return imported, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := s.Text(); line != "" {
+			imported[line] = struct{}{}
+		}
+	}
+	return imported, s.Err()
+}
+
+// recordState appends the path of an imported profile to the state file. The
+// file is synced, so profiles are not imported twice after a crash.
+func recordState(f *os.File, path string) error {
+	if _, err := f.WriteString(path + "\n"); err != nil {
+		return err
+	}
+	return f.Sync()
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+)
+
+// recordingClient records the label-sets of the written profiles.
+type recordingClient struct {
+	profilestorepb.ProfileStoreServiceClient
+
+	mtx    sync.Mutex
+	series []string
+}
+
+func (c *recordingClient) WriteRaw(ctx context.Context, in *profilestorepb.WriteRawRequest, opts ...grpc.CallOption) (*profilestorepb.WriteRawResponse, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, s := range in.Series {
+		ls := ""
+		for _, l := range s.Labels.Labels {
+			ls += l.Name + "=" + l.Value + ";"
+		}
+		c.series = append(c.series, ls)
+	}
+	return &profilestorepb.WriteRawResponse{}, nil
+}
+
+func TestParsePathTemplate(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := ParsePathTemplate("{job}/{instance}/*.pb.gz")
+	require.NoError(t, err)
+
+	ls, ok := tmpl.Labels("api/host-1/heap.pb.gz")
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"job": "api", "instance": "host-1"}, ls)
+
+	_, ok = tmpl.Labels("api/host-1/nested/heap.pb.gz")
+	require.False(t, ok)
+	_, ok = tmpl.Labels("api/host-1/heap.pb")
+	require.False(t, ok)
+
+	for _, invalid := range []string{"{job", "job}", "{job}/{job}", "{1job}"} {
+		_, err := ParsePathTemplate(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestImporter(t *testing.T) {
+	t.Parallel()
+
+	profile, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	noTimestamp, err := (&pprofpb.Profile{}).MarshalVT()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"api/host-1/heap.pb.gz":      profile,
+		"api/host-2/heap.pb.gz":      profile,
+		"api/host-2/heap.pb.gz.json": []byte(`{"__name__": "allocs", "instance": "host-b"}`),
+		"api/host-3/heap.pb.gz":      []byte("not a profile"),
+		"api/host-4/heap.pb.gz":      noTimestamp,
+		"api/README.txt":             []byte("not matching the template"),
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, content, 0o644))
+	}
+
+	tmpl, err := ParsePathTemplate("{job}/{instance}/*.pb.gz")
+	require.NoError(t, err)
+	cfg := Config{
+		Dir:          dir,
+		PathTemplate: tmpl,
+		Labels:       map[string]string{"__name__": "memory", "env": "archive"},
+		Concurrency:  2,
+		StateFile:    filepath.Join(t.TempDir(), "state"),
+	}
+
+	client := &recordingClient{}
+	summary, err := New(log.NewNopLogger(), client, cfg).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.Imported)
+	require.Equal(t, 1, summary.Skipped)
+	require.Equal(t, 0, summary.Resumed)
+	require.Len(t, summary.Failures, 2)
+	require.Equal(t, "api/host-3/heap.pb.gz", summary.Failures[0].Path)
+	require.Equal(t, "api/host-4/heap.pb.gz", summary.Failures[1].Path)
+	require.EqualError(t, summary.Failures[1].Err, "profile has no timestamp")
+
+	sort.Strings(client.series)
+	require.Equal(t, []string{
+		"__name__=allocs;env=archive;instance=host-b;job=api;",
+		"__name__=memory;env=archive;instance=host-1;job=api;",
+	}, client.series)
+
+	// Running the import again only retries the failed profiles.
+	client = &recordingClient{}
+	summary, err = New(log.NewNopLogger(), client, cfg).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0, summary.Imported)
+	require.Equal(t, 2, summary.Resumed)
+	require.Len(t, summary.Failures, 2)
+	require.Empty(t, client.series)
+
+	// The state records absolute paths, so the import resumes with another
+	// path to the same directory, but not for another directory.
+	cfg.Dir = filepath.Join(dir, "api", "..")
+	summary, err = New(log.NewNopLogger(), client, cfg).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.Resumed)
+
+	other := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(other, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, content, 0o644))
+	}
+	cfg.Dir = other
+	summary, err = New(log.NewNopLogger(), client, cfg).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.Imported)
+	require.Equal(t, 0, summary.Resumed)
+}
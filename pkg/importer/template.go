@@ -0,0 +1,90 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/common/model"
+)
+
+// PathTemplate extracts labels from the slash separated paths of profiles
+// relative to the imported directory, for example
+// {job}/{instance}/*.pb.gz. A placeholder like {job} matches the value of
+// the label within a path segment, * matches anything within a path segment
+// and all other characters match themselves.
+type PathTemplate struct {
+	re    *regexp.Regexp
+	names []string
+}
+
+// ParsePathTemplate parses a path template.
+func ParsePathTemplate(s string) (*PathTemplate, error) {
+	var (
+		b     strings.Builder
+		names []string
+		seen  = map[string]struct{}{}
+	)
+	b.WriteString("^")
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed placeholder at offset %d", i)
+			}
+			name := s[i+1 : i+end]
+			if !model.LabelName(name).IsValid() {
+				return nil, fmt.Errorf("invalid label name %q", name)
+			}
+			if _, ok := seen[name]; ok {
+				return nil, fmt.Errorf("duplicate placeholder %q", name)
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+			b.WriteString("([^/]+)")
+			i += end
+		case '}':
+			return nil, fmt.Errorf("unopened placeholder at offset %d", i)
+		case '*':
+			b.WriteString("[^/]*")
+		default:
+			b.WriteString(regexp.QuoteMeta(s[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, err
+	}
+	return &PathTemplate{re: re, names: names}, nil
+}
+
+// Labels returns the labels of the placeholders of the template in the path,
+// or false if the path doesn't match the template.
+func (t *PathTemplate) Labels(path string) (map[string]string, bool) {
+	m := t.re.FindStringSubmatch(path)
+	if m == nil {
+		return nil, false
+	}
+
+	ls := make(map[string]string, len(t.names))
+	for i, name := range t.names {
+		ls[name] = m[i+1]
+	}
+	return ls, true
+}
@@ -0,0 +1,251 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jfr converts Java Flight Recorder recordings, as produced by the JDK
+// and async-profiler, to pprof profiles.
+package jfr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pyroscope-io/jfr-parser/parser"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+)
+
+const (
+	KindCPU   = "cpu"
+	KindAlloc = "alloc"
+	KindLock  = "lock"
+)
+
+// Profile is the pprof profile built from a single kind of JFR event.
+type Profile struct {
+	// Kind is the kind of events the profile was built from, one of KindCPU,
+	// KindAlloc or KindLock.
+	Kind    string
+	Profile *pprofpb.Profile
+}
+
+// Parse parses a JFR recording and returns one profile per kind of event
+// found in it. Execution samples become a cpu profile, allocations in and
+// outside of TLABs an alloc profile and monitor enter and thread park events
+// a lock profile. Kinds without any events are omitted.
+func Parse(r io.Reader) ([]Profile, error) {
+	chunks, err := parser.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse jfr: %w", err)
+	}
+
+	cpu := newBuilder(
+		valueType{"cpu", "nanoseconds"},
+		valueType{"samples", "count"},
+	)
+	alloc := newBuilder(
+		valueType{"space", "bytes"},
+		valueType{"alloc_objects", "count"},
+		valueType{"alloc_space", "bytes"},
+	)
+	lock := newBuilder(
+		valueType{"contentions", "count"},
+		valueType{"contentions", "count"},
+		valueType{"delay", "nanoseconds"},
+	)
+
+	var start, end int64
+	for _, c := range chunks {
+		if start == 0 || c.Header.StartTimeNanos < start {
+			start = c.Header.StartTimeNanos
+		}
+		if chunkEnd := c.Header.StartTimeNanos + c.Header.DurationNanos; chunkEnd > end {
+			end = chunkEnd
+		}
+
+		ticksToNanos := func(ticks int64) int64 {
+			if c.Header.TicksPerSecond == 0 {
+				return ticks
+			}
+			return int64(float64(ticks) * 1e9 / float64(c.Header.TicksPerSecond))
+		}
+
+		for _, e := range c.Events {
+			switch e := e.(type) {
+			case *parser.ExecutionSample:
+				cpu.add(e.StackTrace, 1)
+			case *parser.ObjectAllocationInNewTLAB:
+				alloc.add(e.StackTrace, 1, e.TLABSize)
+			case *parser.ObjectAllocationOutsideTLAB:
+				alloc.add(e.StackTrace, 1, e.AllocationSize)
+			case *parser.JavaMonitorEnter:
+				lock.add(e.StackTrace, 1, ticksToNanos(e.Duration))
+			case *parser.ThreadPark:
+				lock.add(e.StackTrace, 1, ticksToNanos(e.Duration))
+			}
+		}
+	}
+
+	res := make([]Profile, 0, 3)
+	for _, b := range []struct {
+		kind    string
+		builder *builder
+	}{
+		{KindCPU, cpu},
+		{KindAlloc, alloc},
+		{KindLock, lock},
+	} {
+		if len(b.builder.p.Sample) == 0 {
+			continue
+		}
+
+		p := b.builder.p
+		p.TimeNanos = start
+		p.DurationNanos = end - start
+		res = append(res, Profile{Kind: b.kind, Profile: p})
+	}
+
+	return res, nil
+}
+
+type valueType struct {
+	typ  string
+	unit string
+}
+
+type locationKey struct {
+	function uint64
+	line     int64
+}
+
+type builder struct {
+	p *pprofpb.Profile
+
+	strings   map[string]int64
+	functions map[string]uint64
+	locations map[locationKey]uint64
+	// samples are keyed by the stack trace they were recorded with, stack
+	// traces are shared through the constant pool of a chunk.
+	samples map[*parser.StackTrace]*pprofpb.Sample
+}
+
+func newBuilder(periodType valueType, sampleTypes ...valueType) *builder {
+	b := &builder{
+		p: &pprofpb.Profile{
+			StringTable: []string{""},
+		},
+		strings:   map[string]int64{"": 0},
+		functions: map[string]uint64{},
+		locations: map[locationKey]uint64{},
+		samples:   map[*parser.StackTrace]*pprofpb.Sample{},
+	}
+	b.p.PeriodType = &pprofpb.ValueType{
+		Type: b.stringIndex(periodType.typ),
+		Unit: b.stringIndex(periodType.unit),
+	}
+	for _, t := range sampleTypes {
+		b.p.SampleType = append(b.p.SampleType, &pprofpb.ValueType{
+			Type: b.stringIndex(t.typ),
+			Unit: b.stringIndex(t.unit),
+		})
+	}
+	return b
+}
+
+// add records an event with one value per sample type.
+func (b *builder) add(st *parser.StackTrace, values ...int64) {
+	if st == nil || len(st.Frames) == 0 {
+		return
+	}
+
+	if s, ok := b.samples[st]; ok {
+		for i, v := range values {
+			s.Value[i] += v
+		}
+		return
+	}
+
+	s := &pprofpb.Sample{
+		LocationId: make([]uint64, 0, len(st.Frames)),
+		Value:      append([]int64(nil), values...),
+	}
+	// Frames are ordered from the top of the stack, the same way pprof
+	// locations are.
+	for _, f := range st.Frames {
+		s.LocationId = append(s.LocationId, b.location(f))
+	}
+
+	b.samples[st] = s
+	b.p.Sample = append(b.p.Sample, s)
+}
+
+func (b *builder) location(f *parser.StackFrame) uint64 {
+	key := locationKey{
+		function: b.function(frameName(f)),
+		line:     int64(f.LineNumber),
+	}
+	if id, ok := b.locations[key]; ok {
+		return id
+	}
+
+	id := uint64(len(b.p.Location) + 1)
+	b.p.Location = append(b.p.Location, &pprofpb.Location{
+		Id: id,
+		Line: []*pprofpb.Line{{
+			FunctionId: key.function,
+			Line:       key.line,
+		}},
+	})
+	b.locations[key] = id
+	return id
+}
+
+func (b *builder) function(name string) uint64 {
+	if id, ok := b.functions[name]; ok {
+		return id
+	}
+
+	id := uint64(len(b.p.Function) + 1)
+	b.p.Function = append(b.p.Function, &pprofpb.Function{
+		Id:         id,
+		Name:       b.stringIndex(name),
+		SystemName: b.stringIndex(name),
+	})
+	b.functions[name] = id
+	return id
+}
+
+func (b *builder) stringIndex(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+
+	i := int64(len(b.p.StringTable))
+	b.p.StringTable = append(b.p.StringTable, s)
+	b.strings[s] = i
+	return i
+}
+
+// frameName returns the fully qualified method name of a frame, for example
+// java.lang.Thread.run.
+func frameName(f *parser.StackFrame) string {
+	if f.Method == nil || f.Method.Name == nil {
+		return "unknown"
+	}
+
+	if f.Method.Type == nil || f.Method.Type.Name == nil {
+		return f.Method.Name.String
+	}
+
+	return strings.ReplaceAll(f.Method.Type.Name.String, "/", ".") + "." + f.Method.Name.String
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jfr
+
+import (
+	"compress/gzip"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	f, err := os.Open("testdata/example.jfr.gz")
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	profiles, err := Parse(r)
+	require.NoError(t, err)
+	require.Len(t, profiles, 3)
+
+	kinds := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		kinds = append(kinds, p.Kind)
+
+		require.NotZero(t, p.Profile.TimeNanos)
+		require.NotZero(t, p.Profile.DurationNanos)
+		require.NotEmpty(t, p.Profile.Sample)
+		for _, s := range p.Profile.Sample {
+			require.Len(t, s.Value, len(p.Profile.SampleType))
+			require.NotEmpty(t, s.LocationId)
+			for _, id := range s.LocationId {
+				require.Equal(t, id, p.Profile.Location[id-1].Id)
+			}
+		}
+	}
+	require.Equal(t, []string{KindCPU, KindAlloc, KindLock}, kinds)
+
+	// The recording contains 1031 execution samples, 290 allocations and 13
+	// monitor enter events.
+	total := func(p Profile, index int) int64 {
+		var sum int64
+		for _, s := range p.Profile.Sample {
+			sum += s.Value[index]
+		}
+		return sum
+	}
+	require.Equal(t, int64(1031), total(profiles[0], 0))
+	require.Equal(t, int64(290), total(profiles[1], 0))
+	require.Equal(t, int64(13), total(profiles[2], 0))
+	require.NotZero(t, total(profiles[1], 1))
+	require.NotZero(t, total(profiles[2], 1))
+}
@@ -16,6 +16,7 @@ package metastore
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/go-kit/log"
@@ -24,15 +25,18 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 // BadgerMetastore is an implementation of the metastore using the badger KV
-// store.
+// store. The keys of every tenant are stored under their own prefix, the
+// tenant is taken from the context of each request.
 type BadgerMetastore struct {
 	tracer trace.Tracer
 	logger log.Logger
 
 	db *badger.DB
+	gc gcBarrier
 
 	pb.UnimplementedMetastoreServiceServer
 }
@@ -79,9 +83,10 @@ func (m *BadgerMetastore) Mappings(ctx context.Context, r *pb.MappingsRequest) (
 		Mappings: make([]*pb.Mapping, 0, len(r.MappingIds)),
 	}
 
+	prefix := keyPrefix(ctx)
 	mappingKeys := make([][]byte, 0, len(r.MappingIds))
 	for _, id := range r.MappingIds {
-		mappingKeys = append(mappingKeys, []byte(MakeMappingKeyWithID(id)))
+		mappingKeys = append(mappingKeys, []byte(prefix+MakeMappingKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
@@ -117,14 +122,18 @@ func (m *BadgerMetastore) GetOrCreateMappings(ctx context.Context, r *pb.GetOrCr
 		Mappings: make([]*pb.Mapping, 0, len(r.Mappings)),
 	}
 
+	prefix := keyPrefix(ctx)
 	mappingKeys := make([]string, 0, len(r.Mappings))
 	for _, id := range r.Mappings {
 		mappingKeys = append(mappingKeys, MakeMappingKey(id))
 	}
+	defer m.gc.markLive(func() []string {
+		return prefixed(prefix, mappingKeys)
+	})()
 
 	err := m.db.Update(func(txn *badger.Txn) error {
 		for i, mappingKey := range mappingKeys {
-			item, err := txn.Get([]byte(mappingKey))
+			item, err := txn.Get([]byte(prefix + mappingKey))
 			if err != nil && err != badger.ErrKeyNotFound {
 				return err
 			}
@@ -136,7 +145,7 @@ func (m *BadgerMetastore) GetOrCreateMappings(ctx context.Context, r *pb.GetOrCr
 				if err != nil {
 					return err
 				}
-				if err := txn.Set([]byte(mappingKey), b); err != nil {
+				if err := txn.Set([]byte(prefix+mappingKey), b); err != nil {
 					return err
 				}
 				res.Mappings = append(res.Mappings, mapping)
@@ -169,9 +178,10 @@ func (m *BadgerMetastore) Functions(ctx context.Context, r *pb.FunctionsRequest)
 		Functions: make([]*pb.Function, 0, len(r.FunctionIds)),
 	}
 
+	prefix := keyPrefix(ctx)
 	functionKeys := make([][]byte, 0, len(r.FunctionIds))
 	for _, id := range r.FunctionIds {
-		functionKeys = append(functionKeys, []byte(MakeFunctionKeyWithID(id)))
+		functionKeys = append(functionKeys, []byte(prefix+MakeFunctionKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
@@ -207,14 +217,18 @@ func (m *BadgerMetastore) GetOrCreateFunctions(ctx context.Context, r *pb.GetOrC
 		Functions: make([]*pb.Function, 0, len(r.Functions)),
 	}
 
+	prefix := keyPrefix(ctx)
 	functionKeys := make([]string, 0, len(r.Functions))
 	for _, function := range r.Functions {
 		functionKeys = append(functionKeys, MakeFunctionKey(function))
 	}
+	defer m.gc.markLive(func() []string {
+		return prefixed(prefix, functionKeys)
+	})()
 
 	err := m.db.Update(func(txn *badger.Txn) error {
 		for i, functionKey := range functionKeys {
-			item, err := txn.Get([]byte(functionKey))
+			item, err := txn.Get([]byte(prefix + functionKey))
 			if err != nil && err != badger.ErrKeyNotFound {
 				return err
 			}
@@ -226,7 +240,7 @@ func (m *BadgerMetastore) GetOrCreateFunctions(ctx context.Context, r *pb.GetOrC
 				if err != nil {
 					return err
 				}
-				if err := txn.Set([]byte(functionKey), b); err != nil {
+				if err := txn.Set([]byte(prefix+functionKey), b); err != nil {
 					return err
 				}
 				res.Functions = append(res.Functions, function)
@@ -259,9 +273,10 @@ func (m *BadgerMetastore) Locations(ctx context.Context, r *pb.LocationsRequest)
 		Locations: make([]*pb.Location, 0, len(r.LocationIds)),
 	}
 
+	prefix := keyPrefix(ctx)
 	locationKeys := make([][]byte, 0, len(r.LocationIds))
 	for _, id := range r.LocationIds {
-		locationKeys = append(locationKeys, []byte(MakeLocationKeyWithID(id)))
+		locationKeys = append(locationKeys, []byte(prefix+MakeLocationKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
@@ -303,14 +318,18 @@ func (m *BadgerMetastore) GetOrCreateLocations(ctx context.Context, r *pb.GetOrC
 		Locations: make([]*pb.Location, 0, len(r.Locations)),
 	}
 
+	prefix := keyPrefix(ctx)
 	locationKeys := make([]string, 0, len(r.Locations))
 	for _, location := range r.Locations {
 		locationKeys = append(locationKeys, MakeLocationKey(location))
 	}
+	defer m.gc.markLive(func() []string {
+		return append(prefixed(prefix, locationKeys), referencedKeys(prefix, r.Locations)...)
+	})()
 
 	err := m.db.Update(func(txn *badger.Txn) error {
 		for i, locationKey := range locationKeys {
-			item, err := txn.Get([]byte(locationKey))
+			item, err := txn.Get([]byte(prefix + locationKey))
 			if err != nil && err != badger.ErrKeyNotFound {
 				return err
 			}
@@ -322,13 +341,13 @@ func (m *BadgerMetastore) GetOrCreateLocations(ctx context.Context, r *pb.GetOrC
 				if err != nil {
 					return err
 				}
-				if err := txn.Set([]byte(locationKey), b); err != nil {
+				if err := txn.Set([]byte(prefix+locationKey), b); err != nil {
 					return err
 				}
 				res.Locations = append(res.Locations, location)
 
 				if location.MappingId != "" && location.Address != 0 && len(location.Lines) == 0 {
-					unsymbolizableKey := MakeUnsymbolizedLocationKeyWithID(location.Id)
+					unsymbolizableKey := prefix + MakeUnsymbolizedLocationKeyWithID(location.Id)
 					if err := txn.Set([]byte(unsymbolizableKey), []byte{}); err != nil {
 						return err
 					}
@@ -362,6 +381,7 @@ func (m *BadgerMetastore) GetOrCreateLocations(ctx context.Context, r *pb.GetOrC
 func (m *BadgerMetastore) UnsymbolizedLocations(ctx context.Context, r *pb.UnsymbolizedLocationsRequest) (*pb.UnsymbolizedLocationsResponse, error) {
 	var locations []*pb.Location
 
+	tenantPrefix := keyPrefix(ctx)
 	maxKey := ""
 	err := m.db.View(func(txn *badger.Txn) error {
 		opts := badger.DefaultIteratorOptions
@@ -370,7 +390,7 @@ func (m *BadgerMetastore) UnsymbolizedLocations(ctx context.Context, r *pb.Unsym
 		defer it.Close()
 
 		locationKeys := [][]byte{}
-		prefix := []byte(UnsymbolizedLocationLinesKeyPrefix)
+		prefix := []byte(tenantPrefix + UnsymbolizedLocationLinesKeyPrefix)
 		if len(r.MinKey) > 0 {
 			it.Seek([]byte(r.MinKey))
 			if !it.ValidForPrefix(prefix) {
@@ -385,7 +405,7 @@ func (m *BadgerMetastore) UnsymbolizedLocations(ctx context.Context, r *pb.Unsym
 		}
 		for it.ValidForPrefix(prefix) {
 			maxKey = string(it.Item().Key())
-			key := MakeLocationKeyWithID(LocationIDFromUnsymbolizedKey(maxKey))
+			key := tenantPrefix + MakeLocationKeyWithID(LocationIDFromUnsymbolizedKey(strings.TrimPrefix(maxKey, tenantPrefix)))
 			locationKeys = append(locationKeys, []byte(key))
 			if uint32(len(locationKeys)) == r.Limit {
 				break
@@ -410,17 +430,25 @@ func (m *BadgerMetastore) UnsymbolizedLocations(ctx context.Context, r *pb.Unsym
 }
 
 func (m *BadgerMetastore) CreateLocationLines(ctx context.Context, r *pb.CreateLocationLinesRequest) (*pb.CreateLocationLinesResponse, error) {
+	prefix := keyPrefix(ctx)
+	defer m.gc.markLive(func() []string {
+		keys := referencedKeys(prefix, r.Locations)
+		for _, location := range r.Locations {
+			keys = append(keys, prefix+MakeLocationKeyWithID(location.Id))
+		}
+		return keys
+	})()
 	err := m.db.Update(func(txn *badger.Txn) error {
 		for _, location := range r.Locations {
 			b, err := location.MarshalVT()
 			if err != nil {
 				return err
 			}
-			if err := txn.Set([]byte(MakeLocationKeyWithID(location.Id)), b); err != nil {
+			if err := txn.Set([]byte(prefix+MakeLocationKeyWithID(location.Id)), b); err != nil {
 				return err
 			}
 
-			if err := txn.Delete([]byte(MakeUnsymbolizedLocationKeyWithID(location.Id))); err != nil {
+			if err := txn.Delete([]byte(prefix + MakeUnsymbolizedLocationKeyWithID(location.Id))); err != nil {
 				return err
 			}
 		}
@@ -442,6 +470,16 @@ func (m *BadgerMetastore) GetOrCreateStacktraces(ctx context.Context, r *pb.GetO
 	for _, stacktrace := range r.Stacktraces {
 		stacktraceKeys = append(stacktraceKeys, MakeStacktraceKey(stacktrace))
 	}
+	prefix := keyPrefix(ctx)
+	defer m.gc.markLive(func() []string {
+		keys := prefixed(prefix, stacktraceKeys)
+		for _, stacktrace := range r.Stacktraces {
+			for _, id := range stacktrace.LocationIds {
+				keys = append(keys, prefix+MakeLocationKeyWithID(id))
+			}
+		}
+		return keys
+	})()
 
 	const maxRetries = 2
 	var result retryableGetOrCreateStacktraces
@@ -449,7 +487,7 @@ func (m *BadgerMetastore) GetOrCreateStacktraces(ctx context.Context, r *pb.GetO
 
 	level.Debug(m.logger).Log("msg", "GetOrCreateStacktraces", "stacktrace_keys_len", len(r.Stacktraces))
 	for i := 0; i < maxRetries; i++ {
-		result, err = m.retryableGetOrCreateStacktraces(r, stacktraceKeys)
+		result, err = m.retryableGetOrCreateStacktraces(r, prefix, stacktraceKeys)
 		if err != nil {
 			return res, err
 		}
@@ -474,11 +512,11 @@ type retryableGetOrCreateStacktraces struct {
 	retryWith   []string
 }
 
-func (m *BadgerMetastore) retryableGetOrCreateStacktraces(r *pb.GetOrCreateStacktracesRequest, stacktraceKeys []string) (retryableGetOrCreateStacktraces, error) {
+func (m *BadgerMetastore) retryableGetOrCreateStacktraces(r *pb.GetOrCreateStacktracesRequest, prefix string, stacktraceKeys []string) (retryableGetOrCreateStacktraces, error) {
 	result := retryableGetOrCreateStacktraces{}
 	err := m.db.Update(func(txn *badger.Txn) error {
 		for i, stacktraceKey := range stacktraceKeys {
-			item, err := txn.Get([]byte(stacktraceKey))
+			item, err := txn.Get([]byte(prefix + stacktraceKey))
 			if err != nil && err != badger.ErrKeyNotFound {
 				return err
 			}
@@ -490,7 +528,7 @@ func (m *BadgerMetastore) retryableGetOrCreateStacktraces(r *pb.GetOrCreateStack
 				if err != nil {
 					return err
 				}
-				err = txn.Set([]byte(stacktraceKey), b)
+				err = txn.Set([]byte(prefix+stacktraceKey), b)
 				if err != nil && err != badger.ErrTxnTooBig {
 					return err
 				}
@@ -532,9 +570,10 @@ func (m *BadgerMetastore) Stacktraces(ctx context.Context, r *pb.StacktracesRequ
 		Stacktraces: make([]*pb.Stacktrace, 0, len(r.StacktraceIds)),
 	}
 
+	prefix := keyPrefix(ctx)
 	stacktraceKeys := make([][]byte, 0, len(r.StacktraceIds))
 	for _, id := range r.StacktraceIds {
-		stacktraceKeys = append(stacktraceKeys, []byte(MakeStacktraceKeyWithID(id)))
+		stacktraceKeys = append(stacktraceKeys, []byte(prefix+MakeStacktraceKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
@@ -564,3 +603,31 @@ func (m *BadgerMetastore) Stacktraces(ctx context.Context, r *pb.StacktracesRequ
 
 	return res, err
 }
+
+// keyPrefix returns the prefix of the keys of the tenant of the context.
+func keyPrefix(ctx context.Context) string {
+	return tenant.KeyPrefix(tenant.FromContext(ctx))
+}
+
+func prefixed(prefix string, keys []string) []string {
+	res := make([]string, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, prefix+k)
+	}
+	return res
+}
+
+// referencedKeys returns the keys of the mappings and functions the locations
+// reference.
+func referencedKeys(prefix string, locations []*pb.Location) []string {
+	var keys []string
+	for _, location := range locations {
+		if location.MappingId != "" {
+			keys = append(keys, prefix+MakeMappingKeyWithID(location.MappingId))
+		}
+		for _, line := range location.Lines {
+			keys = append(keys, prefix+MakeFunctionKeyWithID(line.FunctionId))
+		}
+	}
+	return keys
+}
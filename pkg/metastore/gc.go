@@ -0,0 +1,470 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// The kinds of keys garbage collection deletes.
+const (
+	GCKindStacktraces           = "stacktraces"
+	GCKindLocations             = "locations"
+	GCKindUnsymbolizedLocations = "unsymbolized_locations"
+	GCKindFunctions             = "functions"
+	GCKindMappings              = "mappings"
+)
+
+// gcDeleteBatchSize is the number of keys deleted per transaction.
+const gcDeleteBatchSize = 1000
+
+// tenantsKeyPrefix is the prefix of the keys of all tenants but the default
+// one.
+const tenantsKeyPrefix = "tenants/"
+
+type gcKind struct {
+	name   string
+	prefix string
+	// liveKey returns the key whose liveness decides whether the key is
+	// live.
+	liveKey func(key string) string
+}
+
+var gcKinds = []gcKind{
+	{name: GCKindStacktraces, prefix: stacktraceKeyPrefix, liveKey: identity},
+	{name: GCKindLocations, prefix: locationsKeyPrefix, liveKey: identity},
+	{name: GCKindUnsymbolizedLocations, prefix: UnsymbolizedLocationLinesKeyPrefix, liveKey: func(key string) string {
+		return MakeLocationKeyWithID(LocationIDFromUnsymbolizedKey(key))
+	}},
+	{name: GCKindFunctions, prefix: functionKeyPrefix, liveKey: identity},
+	{name: GCKindMappings, prefix: mappingKeyPrefix, liveKey: identity},
+}
+
+func identity(key string) string {
+	return key
+}
+
+// StacktraceReferencer lists the stacktraces the stored profiles reference.
+type StacktraceReferencer interface {
+	// StacktraceIDs calls fn with the ID of every stacktrace referenced by
+	// the profiles of the tenant of the context.
+	StacktraceIDs(ctx context.Context, fn func(id string)) error
+}
+
+// gcBarrier tracks the keys written while a garbage collection is running,
+// so that the keys of profiles that are being written when the references
+// are listed are not deleted. Writes hold the barrier for reading, which
+// excludes them from running while keys are being deleted.
+type gcBarrier struct {
+	sync.RWMutex
+	active bool
+
+	keysMtx sync.Mutex
+	keys    map[string]struct{}
+}
+
+// markLive marks the keys returned by keys as live if a garbage collection
+// is running. It returns the function releasing the barrier, which must be
+// called once the keys have been written.
+func (b *gcBarrier) markLive(keys func() []string) func() {
+	b.RLock()
+	if !b.active {
+		return b.RUnlock
+	}
+
+	ks := keys()
+	b.keysMtx.Lock()
+	for _, k := range ks {
+		b.keys[k] = struct{}{}
+	}
+	b.keysMtx.Unlock()
+	return b.RUnlock
+}
+
+func (b *gcBarrier) start() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.active = true
+	b.keys = map[string]struct{}{}
+}
+
+func (b *gcBarrier) stop() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.active = false
+	b.keys = nil
+}
+
+// Cache is a cache of metastore entries outside of the metastore, whose
+// entries can no longer be relied upon to exist once a garbage collection
+// started.
+type Cache interface {
+	Purge()
+}
+
+// GCStats holds the number of keys of every kind a garbage collection found
+// to be live and unreferenced.
+type GCStats struct {
+	Live         map[string]int
+	Unreferenced map[string]int
+}
+
+// GarbageCollector deletes the stacktraces no stored profile references any
+// longer, as well as the locations, functions and mappings no remaining
+// stacktrace references. It is a mark-and-sweep collector: it marks the keys
+// reachable from the stacktraces the references lists, and then sweeps all
+// keys that were neither marked nor written while it was running.
+type GarbageCollector struct {
+	logger     log.Logger
+	metastore  *BadgerMetastore
+	references StacktraceReferencer
+	dryRun     bool
+	// gracePeriod is the time waited after starting to track writes before
+	// the references are listed, so that profiles whose keys were written
+	// before are stored by then.
+	gracePeriod time.Duration
+	// caches are purged at the start of every garbage collection, as their
+	// entries are used without being written and therefore aren't marked as
+	// live while it is running.
+	caches []Cache
+
+	liveKeys         *prometheus.GaugeVec
+	unreferencedKeys *prometheus.GaugeVec
+	deleted          *prometheus.CounterVec
+	failures         prometheus.Counter
+	duration         prometheus.Gauge
+	lastSuccess      prometheus.Gauge
+}
+
+// NewGarbageCollector returns a GarbageCollector for the metastore. In dry
+// run mode unreferenced keys are only counted, not deleted.
+func NewGarbageCollector(
+	logger log.Logger,
+	reg prometheus.Registerer,
+	metastore *BadgerMetastore,
+	references StacktraceReferencer,
+	dryRun bool,
+	gracePeriod time.Duration,
+	caches ...Cache,
+) *GarbageCollector {
+	return &GarbageCollector{
+		logger:      logger,
+		metastore:   metastore,
+		references:  references,
+		dryRun:      dryRun,
+		gracePeriod: gracePeriod,
+		caches:      caches,
+		liveKeys: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "metastore_gc_live_keys",
+				Help: "Number of metastore keys the last garbage collection found to be referenced, partitioned by kind.",
+			},
+			[]string{"kind"},
+		),
+		unreferencedKeys: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "metastore_gc_unreferenced_keys",
+				Help: "Number of metastore keys the last garbage collection found to be unreferenced, partitioned by kind.",
+			},
+			[]string{"kind"},
+		),
+		deleted: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "metastore_gc_deleted_keys_total",
+				Help: "Total number of unreferenced metastore keys deleted by garbage collection, partitioned by kind.",
+			},
+			[]string{"kind"},
+		),
+		failures: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Name: "metastore_gc_failures_total",
+				Help: "Total number of failed metastore garbage collections.",
+			},
+		),
+		duration: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Name: "metastore_gc_duration_seconds",
+				Help: "Duration of the last metastore garbage collection.",
+			},
+		),
+		lastSuccess: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Name: "metastore_gc_last_success_timestamp_seconds",
+				Help: "Time the last successful metastore garbage collection finished.",
+			},
+		),
+	}
+}
+
+// Run collects garbage at the interval until the context is canceled.
+func (gc *GarbageCollector) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := gc.Collect(ctx); err != nil {
+				gc.failures.Inc()
+				level.Warn(gc.logger).Log("msg", "metastore garbage collection failed", "err", err)
+			}
+		}
+	}
+}
+
+// Collect runs a garbage collection of the keys of all tenants.
+func (gc *GarbageCollector) Collect(ctx context.Context) (*GCStats, error) {
+	start := time.Now()
+	barrier := &gc.metastore.gc
+	barrier.start()
+	defer barrier.stop()
+
+	// Entries used from a cache from now on must have been written while the
+	// writes are tracked.
+	for _, c := range gc.caches {
+		c.Purge()
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(gc.gracePeriod):
+	}
+
+	tenants, err := gc.metastore.tenants()
+	if err != nil {
+		return nil, fmt.Errorf("list tenants: %w", err)
+	}
+
+	stats := &GCStats{
+		Live:         map[string]int{},
+		Unreferenced: map[string]int{},
+	}
+	for _, id := range tenants {
+		live, err := gc.mark(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("mark keys of tenant %q: %w", id, err)
+		}
+		if err := gc.sweep(ctx, tenant.KeyPrefix(id), live, stats); err != nil {
+			return nil, fmt.Errorf("sweep keys of tenant %q: %w", id, err)
+		}
+	}
+
+	for _, kind := range gcKinds {
+		gc.liveKeys.WithLabelValues(kind.name).Set(float64(stats.Live[kind.name]))
+		gc.unreferencedKeys.WithLabelValues(kind.name).Set(float64(stats.Unreferenced[kind.name]))
+	}
+	gc.duration.Set(time.Since(start).Seconds())
+	gc.lastSuccess.SetToCurrentTime()
+	level.Debug(gc.logger).Log("msg", "metastore garbage collection finished", "dry_run", gc.dryRun, "duration", time.Since(start))
+	return stats, nil
+}
+
+// mark returns the keys, including the tenant prefix, reachable from the
+// stacktraces referenced by the profiles of the tenant.
+func (gc *GarbageCollector) mark(ctx context.Context, id string) (map[string]struct{}, error) {
+	prefix := tenant.KeyPrefix(id)
+	live := map[string]struct{}{}
+
+	var stacktraceKeys []string
+	if err := gc.references.StacktraceIDs(tenant.NewContext(ctx, id), func(stacktraceID string) {
+		key := prefix + MakeStacktraceKeyWithID(stacktraceID)
+		if _, ok := live[key]; !ok {
+			live[key] = struct{}{}
+			stacktraceKeys = append(stacktraceKeys, key)
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("list referenced stacktraces: %w", err)
+	}
+
+	err := gc.metastore.db.View(func(txn *badger.Txn) error {
+		var locationKeys []string
+		for _, key := range stacktraceKeys {
+			stacktrace := &pb.Stacktrace{}
+			found, err := getValue(txn, key, stacktrace)
+			if err != nil {
+				return err
+			}
+			if !found {
+				continue
+			}
+			for _, locationID := range stacktrace.LocationIds {
+				key := prefix + MakeLocationKeyWithID(locationID)
+				if _, ok := live[key]; !ok {
+					live[key] = struct{}{}
+					locationKeys = append(locationKeys, key)
+				}
+			}
+		}
+
+		for _, key := range locationKeys {
+			location := &pb.Location{}
+			found, err := getValue(txn, key, location)
+			if err != nil {
+				return err
+			}
+			if !found {
+				continue
+			}
+			if location.MappingId != "" {
+				live[prefix+MakeMappingKeyWithID(location.MappingId)] = struct{}{}
+			}
+			for _, line := range location.Lines {
+				live[prefix+MakeFunctionKeyWithID(line.FunctionId)] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+type vtUnmarshaler interface {
+	UnmarshalVT([]byte) error
+}
+
+func getValue(txn *badger.Txn, key string, v vtUnmarshaler) (bool, error) {
+	item, err := txn.Get([]byte(key))
+	if err == badger.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, item.Value(v.UnmarshalVT)
+}
+
+// sweep deletes the keys of the tenant with the prefix that are neither live
+// nor were written since the garbage collection started.
+func (gc *GarbageCollector) sweep(ctx context.Context, prefix string, live map[string]struct{}, stats *GCStats) error {
+	for _, kind := range gcKinds {
+		var dead []string
+		err := gc.metastore.db.View(func(txn *badger.Txn) error {
+			opts := badger.DefaultIteratorOptions
+			opts.PrefetchValues = false
+			opts.Prefix = []byte(prefix + kind.prefix)
+			it := txn.NewIterator(opts)
+			defer it.Close()
+
+			for it.Rewind(); it.Valid(); it.Next() {
+				key := string(it.Item().Key())
+				if _, ok := live[prefix+kind.liveKey(strings.TrimPrefix(key, prefix))]; ok {
+					stats.Live[kind.name]++
+					continue
+				}
+				dead = append(dead, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		stats.Unreferenced[kind.name] += len(dead)
+		if gc.dryRun {
+			continue
+		}
+
+		for len(dead) > 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			n := gcDeleteBatchSize
+			if n > len(dead) {
+				n = len(dead)
+			}
+			deleted, err := gc.delete(kind, prefix, dead[:n])
+			if err != nil {
+				return err
+			}
+			gc.deleted.WithLabelValues(kind.name).Add(float64(deleted))
+			dead = dead[n:]
+		}
+	}
+	return nil
+}
+
+// delete deletes the keys that were not written since the garbage collection
+// started and returns the number of keys deleted. Writes are blocked while
+// the keys are deleted.
+func (gc *GarbageCollector) delete(kind gcKind, prefix string, keys []string) (int, error) {
+	barrier := &gc.metastore.gc
+	barrier.Lock()
+	defer barrier.Unlock()
+
+	deleted := 0
+	err := gc.metastore.db.Update(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			if _, ok := barrier.keys[prefix+kind.liveKey(strings.TrimPrefix(key, prefix))]; ok {
+				continue
+			}
+			if err := txn.Delete([]byte(key)); err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// tenants returns the IDs of the tenants that have keys, the default tenant
+// is always included.
+func (m *BadgerMetastore) tenants() ([]string, error) {
+	ids := []string{tenant.Default}
+	err := m.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Prefix = []byte(tenantsKeyPrefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); {
+			key := strings.TrimPrefix(string(it.Item().Key()), tenantsKeyPrefix)
+			i := strings.Index(key, "/")
+			if i == -1 {
+				it.Next()
+				continue
+			}
+			ids = append(ids, key[:i])
+			// Skip all other keys of the tenant, '0' follows '/'.
+			it.Seek([]byte(tenantsKeyPrefix + key[:i] + "0"))
+		}
+		return nil
+	})
+	return ids, err
+}
@@ -0,0 +1,146 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastoretest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+type fakeReferencer struct {
+	ids map[string][]string
+	// onList is called every time the references are listed.
+	onList func(ctx context.Context)
+}
+
+func (r *fakeReferencer) StacktraceIDs(ctx context.Context, fn func(id string)) error {
+	if r.onList != nil {
+		r.onList(ctx)
+	}
+	for _, id := range r.ids[tenant.FromContext(ctx)] {
+		fn(id)
+	}
+	return nil
+}
+
+type fakeCache struct {
+	purges int
+}
+
+func (c *fakeCache) Purge() {
+	c.purges++
+}
+
+// createStacktrace creates a stacktrace of a symbolized and an unsymbolized
+// location, each with its own mapping.
+func createStacktrace(ctx context.Context, t *testing.T, m pb.MetastoreServiceServer, name string) *pb.Stacktrace {
+	mres, err := m.GetOrCreateMappings(ctx, &pb.GetOrCreateMappingsRequest{
+		Mappings: []*pb.Mapping{{BuildId: name + "-1"}, {BuildId: name + "-2"}},
+	})
+	require.NoError(t, err)
+
+	fres, err := m.GetOrCreateFunctions(ctx, &pb.GetOrCreateFunctionsRequest{
+		Functions: []*pb.Function{{Name: name}},
+	})
+	require.NoError(t, err)
+
+	lres, err := m.GetOrCreateLocations(ctx, &pb.GetOrCreateLocationsRequest{
+		Locations: []*pb.Location{{
+			MappingId: mres.Mappings[0].Id,
+			Address:   0x1,
+		}, {
+			MappingId: mres.Mappings[1].Id,
+			Lines:     []*pb.Line{{FunctionId: fres.Functions[0].Id, Line: 1}},
+		}},
+	})
+	require.NoError(t, err)
+
+	sres, err := m.GetOrCreateStacktraces(ctx, &pb.GetOrCreateStacktracesRequest{
+		Stacktraces: []*pb.Stacktrace{{
+			LocationIds: []string{lres.Locations[0].Id, lres.Locations[1].Id},
+		}},
+	})
+	require.NoError(t, err)
+	return sres.Stacktraces[0]
+}
+
+func stacktraceExists(ctx context.Context, t *testing.T, m pb.MetastoreServiceServer, s *pb.Stacktrace) bool {
+	_, err := m.Stacktraces(ctx, &pb.StacktracesRequest{StacktraceIds: []string{s.Id}})
+	if err != nil {
+		return false
+	}
+	_, err = m.Locations(ctx, &pb.LocationsRequest{LocationIds: s.LocationIds})
+	require.NoError(t, err)
+	return true
+}
+
+func TestGarbageCollector(t *testing.T) {
+	logger := log.NewNopLogger()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	ctx := context.Background()
+	tenantCtx := tenant.NewContext(ctx, "team-a")
+
+	m := NewTestMetastore(t, logger, prometheus.NewRegistry(), tracer)
+	live := createStacktrace(ctx, t, m, "live")
+	dead := createStacktrace(ctx, t, m, "dead")
+	tenantDead := createStacktrace(tenantCtx, t, m, "dead")
+
+	refs := &fakeReferencer{ids: map[string][]string{tenant.Default: {live.Id}}}
+
+	stats, err := metastore.NewGarbageCollector(logger, prometheus.NewRegistry(), m.(*metastore.BadgerMetastore), refs, true, 0).Collect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		metastore.GCKindStacktraces:           2,
+		metastore.GCKindLocations:             4,
+		metastore.GCKindUnsymbolizedLocations: 2,
+		metastore.GCKindFunctions:             2,
+		metastore.GCKindMappings:              4,
+	}, stats.Unreferenced)
+	require.True(t, stacktraceExists(ctx, t, m, dead))
+	require.True(t, stacktraceExists(tenantCtx, t, m, tenantDead))
+
+	// A stacktrace written while the references are listed is kept, even
+	// though it is not referenced yet.
+	var written *pb.Stacktrace
+	refs.onList = func(ctx context.Context) {
+		if written == nil {
+			written = createStacktrace(ctx, t, m, "written")
+		}
+	}
+
+	cache := &fakeCache{}
+	stats, err = metastore.NewGarbageCollector(logger, prometheus.NewRegistry(), m.(*metastore.BadgerMetastore), refs, false, 0, cache).Collect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, cache.purges)
+	require.Equal(t, 3, stats.Unreferenced[metastore.GCKindStacktraces])
+	require.Equal(t, 1, stats.Live[metastore.GCKindStacktraces])
+
+	require.True(t, stacktraceExists(ctx, t, m, live))
+	require.True(t, stacktraceExists(ctx, t, m, written))
+	require.False(t, stacktraceExists(ctx, t, m, dead))
+	require.False(t, stacktraceExists(tenantCtx, t, m, tenantDead))
+
+	ures, err := m.UnsymbolizedLocations(ctx, &pb.UnsymbolizedLocationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, ures.Locations, 2)
+}
@@ -0,0 +1,341 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlp converts OpenTelemetry (OTLP) profiles to pprof profiles and
+// Parca labels.
+package otlp
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/util/strutil"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	otelpb "github.com/parca-dev/parca/gen/proto/go/opentelemetry/proto/profiles/v1experimental"
+)
+
+// The pprof labels the links of samples to spans are converted to, which the
+// normalizer stores as the trace and span IDs of samples.
+const (
+	traceIDLabel = "trace_id"
+	spanIDLabel  = "span_id"
+)
+
+// LabelsFromAttributes converts OTLP attributes to a label-set. Attribute
+// keys are sanitized to valid label names, for example service.name becomes
+// service_name. Attributes without a scalar value are skipped.
+func LabelsFromAttributes(attrs []*commonpb.KeyValue) labels.Labels {
+	ls := make(labels.Labels, 0, len(attrs))
+	seen := make(map[string]struct{}, len(attrs))
+	for _, kv := range attrs {
+		value, ok := stringValue(kv.GetValue())
+		if !ok || value == "" {
+			continue
+		}
+
+		name := labelName(kv.GetKey())
+		if _, ok := seen[name]; ok || name == labels.MetricName {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		ls = append(ls, labels.Label{Name: name, Value: value})
+	}
+
+	sort.Sort(ls)
+	return ls
+}
+
+// ToPprof converts an OTLP profile to a pprof profile. Sample attributes
+// become pprof labels, which are stored as the sample's pprof_labels. The
+// start and end time of the containing ProfileContainer are used if the
+// profile itself does not carry a time or duration.
+func ToPprof(p *otelpb.Profile, startTimeUnixNano, endTimeUnixNano uint64) (*pprofpb.Profile, error) {
+	if p == nil {
+		return nil, errors.New("missing profile")
+	}
+	if len(p.StringTable) == 0 || p.StringTable[0] != "" {
+		return nil, errors.New("first item in string table is expected to be empty string")
+	}
+
+	c := &converter{
+		in: p,
+		out: &pprofpb.Profile{
+			StringTable:       append([]string(nil), p.StringTable...),
+			DropFrames:        p.DropFrames,
+			KeepFrames:        p.KeepFrames,
+			TimeNanos:         p.TimeNanos,
+			DurationNanos:     p.DurationNanos,
+			Period:            p.Period,
+			Comment:           p.Comment,
+			DefaultSampleType: p.DefaultSampleType,
+		},
+		strings: map[string]int64{},
+	}
+	for i, s := range c.out.StringTable {
+		if _, ok := c.strings[s]; !ok {
+			c.strings[s] = int64(i)
+		}
+	}
+
+	if c.out.TimeNanos == 0 {
+		c.out.TimeNanos = int64(startTimeUnixNano)
+	}
+	if c.out.DurationNanos == 0 && endTimeUnixNano > startTimeUnixNano {
+		c.out.DurationNanos = int64(endTimeUnixNano - startTimeUnixNano)
+	}
+
+	if err := c.convert(); err != nil {
+		return nil, err
+	}
+
+	return c.out, nil
+}
+
+type converter struct {
+	in  *otelpb.Profile
+	out *pprofpb.Profile
+
+	strings map[string]int64
+}
+
+func (c *converter) convert() error {
+	if c.in.PeriodType != nil {
+		c.out.PeriodType = &pprofpb.ValueType{
+			Type: c.in.PeriodType.Type,
+			Unit: c.in.PeriodType.Unit,
+		}
+	}
+
+	for _, t := range c.in.SampleType {
+		c.out.SampleType = append(c.out.SampleType, &pprofpb.ValueType{
+			Type: t.Type,
+			Unit: t.Unit,
+		})
+	}
+
+	for i, m := range c.in.Mapping {
+		c.out.Mapping = append(c.out.Mapping, &pprofpb.Mapping{
+			Id:              uint64(i + 1),
+			MemoryStart:     m.MemoryStart,
+			MemoryLimit:     m.MemoryLimit,
+			FileOffset:      m.FileOffset,
+			Filename:        m.Filename,
+			BuildId:         m.BuildId,
+			HasFunctions:    m.HasFunctions,
+			HasFilenames:    m.HasFilenames,
+			HasLineNumbers:  m.HasLineNumbers,
+			HasInlineFrames: m.HasInlineFrames,
+		})
+	}
+
+	for i, f := range c.in.Function {
+		c.out.Function = append(c.out.Function, &pprofpb.Function{
+			Id:         uint64(i + 1),
+			Name:       f.Name,
+			SystemName: f.SystemName,
+			Filename:   f.Filename,
+			StartLine:  f.StartLine,
+		})
+	}
+
+	for i, l := range c.in.Location {
+		loc := &pprofpb.Location{
+			Id:       uint64(i + 1),
+			Address:  l.Address,
+			IsFolded: l.IsFolded,
+		}
+		// A mapping index of 0 is ambiguous, it either references the
+		// first mapping or no mapping at all if the profile has none.
+		if len(c.in.Mapping) > 0 {
+			if l.MappingIndex >= uint64(len(c.in.Mapping)) {
+				return fmt.Errorf("location %d references unknown mapping %d", i, l.MappingIndex)
+			}
+			loc.MappingId = l.MappingIndex + 1
+		}
+		for _, line := range l.Line {
+			if line.FunctionIndex >= uint64(len(c.in.Function)) {
+				return fmt.Errorf("location %d references unknown function %d", i, line.FunctionIndex)
+			}
+			loc.Line = append(loc.Line, &pprofpb.Line{
+				FunctionId: line.FunctionIndex + 1,
+				Line:       line.Line,
+			})
+		}
+		c.out.Location = append(c.out.Location, loc)
+	}
+
+	for i, s := range c.in.Sample {
+		if len(s.Value) != len(c.in.SampleType) {
+			return fmt.Errorf("sample %d has %d values, expected %d", i, len(s.Value), len(c.in.SampleType))
+		}
+
+		locationIds, err := c.sampleLocations(s)
+		if err != nil {
+			return fmt.Errorf("sample %d: %w", i, err)
+		}
+
+		labels, err := c.sampleLabels(s)
+		if err != nil {
+			return fmt.Errorf("sample %d: %w", i, err)
+		}
+
+		c.out.Sample = append(c.out.Sample, &pprofpb.Sample{
+			LocationId: locationIds,
+			Value:      s.Value,
+			Label:      labels,
+		})
+	}
+
+	return nil
+}
+
+// sampleLocations returns the pprof location IDs of a sample, ordered from
+// the leaf to the root.
+func (c *converter) sampleLocations(s *otelpb.Sample) ([]uint64, error) {
+	var indices []uint64
+	switch {
+	case s.LocationsLength > 0:
+		end := s.LocationsStartIndex + s.LocationsLength
+		if len(c.in.LocationIndices) > 0 {
+			if end > uint64(len(c.in.LocationIndices)) {
+				return nil, fmt.Errorf("locations [%d, %d) out of range", s.LocationsStartIndex, end)
+			}
+			indices = make([]uint64, 0, s.LocationsLength)
+			for _, index := range c.in.LocationIndices[s.LocationsStartIndex:end] {
+				indices = append(indices, uint64(index))
+			}
+		} else {
+			indices = make([]uint64, 0, s.LocationsLength)
+			for index := s.LocationsStartIndex; index < end; index++ {
+				indices = append(indices, index)
+			}
+		}
+	default:
+		indices = s.LocationIndex
+	}
+
+	ids := make([]uint64, 0, len(indices))
+	for _, index := range indices {
+		if index >= uint64(len(c.in.Location)) {
+			return nil, fmt.Errorf("unknown location %d", index)
+		}
+		ids = append(ids, index+1)
+	}
+
+	return ids, nil
+}
+
+func (c *converter) sampleLabels(s *otelpb.Sample) ([]*pprofpb.Label, error) {
+	res := make([]*pprofpb.Label, 0, len(s.Label)+len(s.Attributes))
+	for _, l := range s.Label {
+		res = append(res, &pprofpb.Label{
+			Key:     l.Key,
+			Str:     l.Str,
+			Num:     l.Num,
+			NumUnit: l.NumUnit,
+		})
+	}
+
+	for _, index := range s.Attributes {
+		if index >= uint64(len(c.in.AttributeTable)) {
+			return nil, fmt.Errorf("unknown attribute %d", index)
+		}
+
+		kv := c.in.AttributeTable[index]
+		l := &pprofpb.Label{
+			Key: c.stringIndex(labelName(kv.GetKey())),
+		}
+		if v, ok := kv.GetValue().GetValue().(*commonpb.AnyValue_IntValue); ok {
+			l.Num = v.IntValue
+			l.NumUnit = c.attributeUnit(kv.GetKey())
+		} else {
+			value, ok := stringValue(kv.GetValue())
+			if !ok {
+				continue
+			}
+			l.Str = c.stringIndex(value)
+		}
+		res = append(res, l)
+	}
+
+	// The span a sample was recorded in is converted to the pprof labels
+	// otel-profiling-go sets. Links without a trace ID, like the empty link
+	// usually at index 0, are ignored.
+	if s.Link < uint64(len(c.in.LinkTable)) {
+		link := c.in.LinkTable[s.Link]
+		if len(link.GetTraceId()) > 0 {
+			res = append(res, &pprofpb.Label{
+				Key: c.stringIndex(traceIDLabel),
+				Str: c.stringIndex(hex.EncodeToString(link.GetTraceId())),
+			})
+			if len(link.GetSpanId()) > 0 {
+				res = append(res, &pprofpb.Label{
+					Key: c.stringIndex(spanIDLabel),
+					Str: c.stringIndex(hex.EncodeToString(link.GetSpanId())),
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (c *converter) attributeUnit(key string) int64 {
+	for _, u := range c.in.AttributeUnits {
+		if u.AttributeKey >= 0 && u.AttributeKey < int64(len(c.in.StringTable)) && c.in.StringTable[u.AttributeKey] == key {
+			return u.Unit
+		}
+	}
+	return 0
+}
+
+func (c *converter) stringIndex(s string) int64 {
+	if i, ok := c.strings[s]; ok {
+		return i
+	}
+
+	i := int64(len(c.out.StringTable))
+	c.out.StringTable = append(c.out.StringTable, s)
+	c.strings[s] = i
+	return i
+}
+
+func labelName(key string) string {
+	name := strutil.SanitizeLabelName(key)
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+func stringValue(v *commonpb.AnyValue) (string, bool) {
+	switch v := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue, true
+	case *commonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue), true
+	case *commonpb.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10), true
+	case *commonpb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'g', -1, 64), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	otelpb "github.com/parca-dev/parca/gen/proto/go/opentelemetry/proto/profiles/v1experimental"
+)
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func intAttribute(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}},
+	}
+}
+
+// testProfile returns an OTLP profile with two samples sharing the main
+// function, for use in tests.
+func testProfile() *otelpb.Profile {
+	return &otelpb.Profile{
+		StringTable: []string{"", "samples", "count", "cpu", "nanoseconds", "main", "work", "/bin/app", "thread.id"},
+		SampleType: []*otelpb.ValueType{{
+			Type: 1,
+			Unit: 2,
+		}},
+		PeriodType: &otelpb.ValueType{Type: 3, Unit: 4},
+		Period:     10000000,
+		Mapping: []*otelpb.Mapping{{
+			MemoryStart:  0x1000,
+			MemoryLimit:  0x2000,
+			Filename:     7,
+			HasFunctions: true,
+		}},
+		Function: []*otelpb.Function{{
+			Name: 5,
+		}, {
+			Name: 6,
+		}},
+		Location: []*otelpb.Location{{
+			Address: 0x1100,
+			Line:    []*otelpb.Line{{FunctionIndex: 0, Line: 10}},
+		}, {
+			Address: 0x1200,
+			Line:    []*otelpb.Line{{FunctionIndex: 1, Line: 20}},
+		}},
+		LocationIndices: []int64{1, 0, 0},
+		AttributeTable: []*commonpb.KeyValue{
+			stringAttribute("thread.name", "worker"),
+			intAttribute("thread.id", 7),
+		},
+		AttributeUnits: []*otelpb.AttributeUnit{{
+			AttributeKey: 8,
+			Unit:         2,
+		}},
+		LinkTable: []*otelpb.Link{{}, {
+			TraceId: []byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c},
+			SpanId:  []byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+		}},
+		Sample: []*otelpb.Sample{{
+			LocationsStartIndex: 0,
+			LocationsLength:     2,
+			Value:               []int64{3},
+			Attributes:          []uint64{0, 1},
+		}, {
+			LocationsStartIndex: 2,
+			LocationsLength:     1,
+			Value:               []int64{1},
+			Link:                1,
+		}},
+	}
+}
+
+func TestToPprof(t *testing.T) {
+	p, err := ToPprof(testProfile(), 1000, 3000)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1000), p.TimeNanos)
+	require.Equal(t, int64(2000), p.DurationNanos)
+	require.Equal(t, "cpu", p.StringTable[p.PeriodType.Type])
+	require.Len(t, p.Mapping, 1)
+	require.Equal(t, uint64(1), p.Location[0].MappingId)
+	require.Equal(t, uint64(2), p.Location[1].Line[0].FunctionId)
+
+	require.Len(t, p.Sample, 2)
+	require.Equal(t, []uint64{2, 1}, p.Sample[0].LocationId)
+	require.Equal(t, []uint64{1}, p.Sample[1].LocationId)
+
+	sampleLabels := map[string]*pprofpb.Label{}
+	for _, l := range p.Sample[0].Label {
+		sampleLabels[p.StringTable[l.Key]] = l
+	}
+	require.Len(t, sampleLabels, 2)
+	require.Equal(t, "worker", p.StringTable[sampleLabels["thread_name"].Str])
+	require.Equal(t, int64(7), sampleLabels["thread_id"].Num)
+	require.Equal(t, "count", p.StringTable[sampleLabels["thread_id"].NumUnit])
+
+	sampleLabels = map[string]*pprofpb.Label{}
+	for _, l := range p.Sample[1].Label {
+		sampleLabels[p.StringTable[l.Key]] = l
+	}
+	require.Len(t, sampleLabels, 2)
+	require.Equal(t, "0af7651916cd43dd8448eb211c80319c", p.StringTable[sampleLabels["trace_id"].Str])
+	require.Equal(t, "b7ad6b7169203331", p.StringTable[sampleLabels["span_id"].Str])
+}
+
+func TestToPprofInvalid(t *testing.T) {
+	p := testProfile()
+	p.Sample[0].LocationsLength = 4
+	_, err := ToPprof(p, 0, 0)
+	require.Error(t, err)
+
+	p = testProfile()
+	p.Location[0].Line[0].FunctionIndex = 5
+	_, err = ToPprof(p, 0, 0)
+	require.Error(t, err)
+
+	p = testProfile()
+	p.Sample[1].Value = nil
+	_, err = ToPprof(p, 0, 0)
+	require.Error(t, err)
+}
+
+func TestLabelsFromAttributes(t *testing.T) {
+	ls := LabelsFromAttributes([]*commonpb.KeyValue{
+		stringAttribute("service.name", "checkout"),
+		stringAttribute("__name__", "ignored"),
+		intAttribute("process.pid", 42),
+		stringAttribute("empty", ""),
+		{Key: "array", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{}}},
+	})
+
+	require.Equal(t, labels.FromStrings(
+		"process_pid", "42",
+		"service_name", "checkout",
+	), ls)
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parca
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/importer"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// ImportFlags are the flags of the import command.
+type ImportFlags struct {
+	Dir      string `arg:"" type:"existingdir" help:"Directory to import pprof files from. The labels of a profile are also read from a JSON object of label names and values in the file of its path with a .json suffix, if it exists."`
+	LogLevel string `default:"info" enum:"error,warn,info,debug" help:"log level."`
+
+	PathTemplate     string            `help:"Template of the paths of the profiles relative to the directory, for example {job}/{instance}/*.pb.gz. Placeholders in braces match label values within a path segment, * matches anything within a path segment. Files not matching it are skipped."`
+	Label            map[string]string `help:"Label(s) to attach to all imported profiles. Labels of the path template and JSON files take precedence."`
+	Tenant           string            `help:"Tenant to import the profiles for."`
+	Concurrency      int               `default:"4" help:"Number of profiles to write concurrently."`
+	StateFile        string            `default:"parca-import.state" help:"File recording the absolute paths of the imported profiles, so running the import again resumes where it stopped. Empty disables resuming."`
+	ProgressInterval time.Duration     `default:"10s" help:"Interval at which the progress is logged."`
+
+	StoreFlags `embed:""`
+}
+
+// Import imports a directory of pprof files into a remote store.
+func Import(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags *ImportFlags) error {
+	if flags.StoreAddress == "" {
+		return fmt.Errorf("parca import needs to have a --store-address")
+	}
+
+	cfg := importer.Config{
+		Dir:              flags.Dir,
+		Labels:           flags.Label,
+		Concurrency:      flags.Concurrency,
+		StateFile:        flags.StateFile,
+		ProgressInterval: flags.ProgressInterval,
+	}
+	if flags.PathTemplate != "" {
+		t, err := importer.ParsePathTemplate(flags.PathTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid path template: %w", err)
+		}
+		cfg.PathTemplate = t
+	}
+	if flags.Tenant != "" {
+		if err := tenant.Validate(flags.Tenant); err != nil {
+			return err
+		}
+		ctx = tenant.NewOutgoingContext(tenant.NewContext(ctx, flags.Tenant))
+	}
+
+	conn, err := dialStore(reg, flags.StoreFlags)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	summary, err := importer.New(logger, profilestorepb.NewProfileStoreServiceClient(conn), cfg).Run(ctx)
+	if summary != nil {
+		for _, f := range summary.Failures {
+			level.Warn(logger).Log("msg", "failed to import profile", "path", f.Path, "err", f.Err)
+		}
+		level.Info(logger).Log(
+			"msg", "import finished",
+			"imported", summary.Imported,
+			"failed", len(summary.Failures),
+			"resumed", summary.Resumed,
+			"skipped", summary.Skipped,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	if len(summary.Failures) > 0 {
+		return fmt.Errorf("failed to import %d profiles", len(summary.Failures))
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	goruntime "runtime"
@@ -51,6 +52,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"gopkg.in/yaml.v2"
 
+	otelcollectorpb "github.com/parca-dev/parca/gen/proto/go/opentelemetry/proto/collector/profiles/v1experimental"
 	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
 	metastorepb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
@@ -67,12 +69,23 @@ import (
 	"github.com/parca-dev/parca/pkg/server"
 	"github.com/parca-dev/parca/pkg/symbol"
 	"github.com/parca-dev/parca/pkg/symbolizer"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 const (
 	symbolizationInterval = 10 * time.Second
 	flagModeScraperOnly   = "scraper-only"
 	metaStoreBadger       = "badger"
+	// metastoreGCGracePeriod is the time metastore garbage collection waits
+	// for profiles being written to be stored before it lists the
+	// stacktraces they reference.
+	metastoreGCGracePeriod = time.Minute
+	// storageBlocksPrefix is the prefix of the bucket the database persists
+	// its blocks under.
+	storageBlocksPrefix = "blocks"
+	// storageDatabase is the name of the database, frostdb prefixes the
+	// blocks of every database with its name.
+	storageDatabase = "parca"
 )
 
 type Flags struct {
@@ -90,16 +103,21 @@ type Flags struct {
 
 	EnablePersistence bool `default:"false" help:"Turn on persistent storage for the metastore and profile storage."`
 
-	StorageDebugValueLog bool   `default:"false" help:"Log every value written to the database into a separate file. This is only for debugging purposes to produce data to replay situations in tests."`
+	StorageDebugValueLog bool   `default:"false" help:"Log every value written to the database into a separate file. This is only for debugging purposes to produce data to replay situations in tests, see parca replay."`
 	StorageGranuleSize   int64  `default:"26265625" help:"Granule size in bytes for storage."`
 	StorageActiveMemory  int64  `default:"536870912" help:"Amount of memory to use for active storage. Defaults to 512MB."`
 	StoragePath          string `default:"data" help:"Path to storage directory."`
 	StorageEnableWAL     bool   `default:"false" help:"Enables write ahead log for profile storage."`
 
+	ProfileMaxDecompressedSize int64 `default:"268435456" help:"Maximum size in bytes a written profile may decompress to. 0 disables the limit. Defaults to 256MB."`
+
 	SymbolizerDemangleMode  string `default:"simple" help:"Mode to demangle C++ symbols. Default mode is simplified: no parameters, no templates, no return type" enum:"simple,full,none,templates"`
 	SymbolizerNumberOfTries int    `default:"3" help:"Number of tries to attempt to symbolize an unsybolized location"`
 
-	Metastore string `default:"badger" help:"Which metastore implementation to use" enum:"badger"`
+	Metastore           string        `default:"badger" help:"Which metastore implementation to use" enum:"badger"`
+	MetastoreGCInterval time.Duration `default:"0" help:"Interval at which metastore entries no longer referenced by any stored profile are deleted. 0 disables garbage collection."`
+	MetastoreGCDryRun   bool          `default:"false" help:"Only count the metastore entries garbage collection would delete, without deleting them."`
+	MetastoreCacheSize  int           `default:"100000" help:"Maximum number of mappings, functions, locations and stacktraces each that ingestion caches as known to exist in the metastore. 0 disables the cache."`
 
 	ProfileShareServer string `default:"api.pprof.me:443" help:"gRPC address to send share profile requests to."`
 
@@ -107,12 +125,17 @@ type Flags struct {
 	DebugInfodHTTPRequestTimeout time.Duration `default:"5m" help:"Timeout duration for HTTP request to upstream debuginfod server. Defaults to 5m"`
 	DebuginfoCacheDir            string        `default:"/tmp" help:"Path to directory where debuginfo is cached."`
 
-	StoreAddress       string            `kong:"help='gRPC address to send profiles and symbols to.'"`
-	BearerToken        string            `kong:"help='Bearer token to authenticate with store.'"`
-	BearerTokenFile    string            `kong:"help='File to read bearer token from to authenticate with store.'"`
-	Insecure           bool              `kong:"help='Send gRPC requests via plaintext instead of TLS.'"`
-	InsecureSkipVerify bool              `kong:"help='Skip TLS certificate verification.'"`
-	ExternalLabel      map[string]string `kong:"help='Label(s) to attach to all profiles in scraper-only mode.'"`
+	StoreFlags    `embed:""`
+	ExternalLabel map[string]string `kong:"help='Label(s) to attach to all profiles in scraper-only mode. Replicas of highly available scrapers are told apart by the replica label of the ha_tracker config of the store, __replica__ by default.'"`
+}
+
+// StoreFlags are the flags of the connection to a remote store.
+type StoreFlags struct {
+	StoreAddress       string `kong:"help='gRPC address to send profiles and symbols to.'"`
+	BearerToken        string `kong:"help='Bearer token to authenticate with store.'"`
+	BearerTokenFile    string `kong:"help='File to read bearer token from to authenticate with store.'"`
+	Insecure           bool   `kong:"help='Send gRPC requests via plaintext instead of TLS.'"`
+	InsecureSkipVerify bool   `kong:"help='Skip TLS certificate verification.'"`
 }
 
 // Run the parca server.
@@ -163,7 +186,10 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
-	var mStr metastorepb.MetastoreServiceServer
+	var (
+		mStr        metastorepb.MetastoreServiceServer
+		badgerStore *metastore.BadgerMetastore
+	)
 	switch flags.Metastore {
 	case metaStoreBadger:
 		var badgerOptions badger.Options
@@ -181,19 +207,29 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 			return err
 		}
 
-		mStr = metastore.NewBadgerMetastore(
+		badgerStore = metastore.NewBadgerMetastore(
 			logger,
 			reg,
 			tracerProvider.Tracer(metaStoreBadger),
 			db,
 		)
+		mStr = badgerStore
 	default:
 		err := fmt.Errorf("unknown metastore implementation: %s", flags.Metastore)
 		level.Error(logger).Log("msg", "failed to initialize metastore", "err", err)
 		return err
 	}
 
-	metastore := metastore.NewInProcessClient(mStr)
+	metastoreClient := metastore.NewInProcessClient(mStr)
+
+	var normalizerCache *parcacol.NormalizerCache
+	if flags.MetastoreCacheSize > 0 {
+		normalizerCache, err = parcacol.NewNormalizerCache(reg, flags.MetastoreCacheSize)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to create normalizer cache", "err", err)
+			return err
+		}
+	}
 
 	frostdbOptions := []frostdb.Option{
 		frostdb.WithActiveMemorySize(flags.StorageActiveMemory),
@@ -202,8 +238,13 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		frostdb.WithTracer(tracerProvider.Tracer("frostdb")),
 	}
 
+	// blocksBucket holds the blocks persisted by the database, it is nil if
+	// persistence is disabled.
+	var blocksBucket objstore.Bucket
 	if flags.EnablePersistence {
-		frostdbOptions = append(frostdbOptions, frostdb.WithBucketStorage(objstore.NewPrefixedBucket(bucket, "blocks")))
+		storageBucket := objstore.NewPrefixedBucket(bucket, storageBlocksPrefix)
+		frostdbOptions = append(frostdbOptions, frostdb.WithBucketStorage(parcacol.NewBlocksBucket(storageBucket)))
+		blocksBucket = objstore.NewPrefixedBucket(storageBucket, storageDatabase)
 	}
 
 	if flags.StorageEnableWAL {
@@ -221,7 +262,7 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
-	colDB, err := col.DB(ctx, "parca")
+	colDB, err := col.DB(ctx, storageDatabase)
 	if err != nil {
 		level.Error(logger).Log("msg", "failed to load database", "err", err)
 		return err
@@ -233,39 +274,90 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
-	table, err := colDB.Table("stacktraces", frostdb.NewTableConfig(schema))
-	if err != nil {
+	tables := parcacol.NewTables(colDB, schema, "stacktraces", blocksBucket)
+	// The table of the default tenant is created eagerly, the tables of
+	// all other tenants the first time they write profiles.
+	if _, err := tables.Table(tenant.Default); err != nil {
 		level.Error(logger).Log("msg", "create table", "err", err)
 		return err
 	}
 
+	rollupTables := parcacol.NewTables(colDB, schema, "rollups", blocksBucket)
+
+	retention := parcacol.NewRetention(logger, reg, blocksBucket, tables, rollupTables)
+	if err := retention.ApplyConfig(cfg.Retention); err != nil {
+		level.Error(logger).Log("msg", "failed to apply retention config", "err", err)
+		return err
+	}
+
+	engine := query.NewEngine(
+		memory.DefaultAllocator,
+		parcacol.NewStatsTableProvider(parcacol.MultiTableProvider{tables, rollupTables}),
+		query.WithTracer(tracerProvider.Tracer("query-engine")),
+	)
+
+	rollups := parcacol.NewRollups(logger, reg, engine, tables, rollupTables)
+	if err := rollups.ApplyConfig(ctx, cfg.Rollups); err != nil {
+		level.Error(logger).Log("msg", "failed to apply rollups config", "err", err)
+		return err
+	}
+
+	sampleLabels := parcacol.NewSampleLabelFilter(reg)
+	if err := sampleLabels.ApplyConfig(cfg.SampleLabels); err != nil {
+		level.Error(logger).Log("msg", "failed to apply sample labels config", "err", err)
+		return err
+	}
+
 	s := profilestore.NewProfileColumnStore(
 		logger,
+		reg,
 		tracerProvider.Tracer("profilestore"),
-		metastore,
-		table,
+		metastoreClient,
+		tables,
 		schema,
-		flags.StorageDebugValueLog,
+		profilestore.WithNormalizerCache(normalizerCache),
+		profilestore.WithSampleLabelFilter(sampleLabels),
+		profilestore.WithDebugValueLog(flags.StorageDebugValueLog),
+		profilestore.WithMaxDecompressedSize(flags.ProfileMaxDecompressedSize),
+		profilestore.WithRollups(rollups),
+	)
+	if err := s.ApplyWriteRelabelConfigs(cfg.WriteRelabelConfigs); err != nil {
+		level.Error(logger).Log("msg", "failed to apply write relabel configs", "err", err)
+		return err
+	}
+	if err := s.ApplyLimits(cfg.Limits, cfg.TenantLimits); err != nil {
+		level.Error(logger).Log("msg", "failed to apply limits", "err", err)
+		return err
+	}
+	if err := s.ApplyHATrackerConfig(cfg.HATracker); err != nil {
+		level.Error(logger).Log("msg", "failed to apply ha tracker config", "err", err)
+		return err
+	}
+	otlpProfiles := profilestore.NewOTLPProfilesService(
+		logger,
+		tracerProvider.Tracer("otlp"),
+		s,
 	)
 	conn, err := grpc.Dial(flags.ProfileShareServer, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
 	if err != nil {
 		return fmt.Errorf("failed to create gRPC connection to ProfileShareServer: %s, %w", flags.ProfileShareServer, err)
 	}
+	querier := parcacol.NewQuerier(
+		tracerProvider.Tracer("querier"),
+		engine,
+		"stacktraces",
+		metastoreClient,
+	).WithRollups(rollups)
 	q := queryservice.NewColumnQueryAPI(
 		logger,
 		tracerProvider.Tracer("query-service"),
 		sharepb.NewShareClient(conn),
-		parcacol.NewQuerier(
-			tracerProvider.Tracer("querier"),
-			query.NewEngine(
-				memory.DefaultAllocator,
-				colDB.TableProvider(),
-				query.WithTracer(tracerProvider.Tracer("query-engine")),
-			),
-			"stacktraces",
-			metastore,
-		),
+		querier,
 	)
+	if err := q.ApplyStackFoldingRules(cfg.StackFoldingRules); err != nil {
+		level.Error(logger).Log("msg", "failed to apply stack folding rules", "err", err)
+		return err
+	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -337,6 +429,48 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 				return m.ApplyConfig(cfg.ScrapeConfigs)
 			},
 		},
+		{
+			Name: "stack_folding",
+			Reloader: func(cfg *config.Config) error {
+				return q.ApplyStackFoldingRules(cfg.StackFoldingRules)
+			},
+		},
+		{
+			Name: "write_relabel",
+			Reloader: func(cfg *config.Config) error {
+				return s.ApplyWriteRelabelConfigs(cfg.WriteRelabelConfigs)
+			},
+		},
+		{
+			Name: "limits",
+			Reloader: func(cfg *config.Config) error {
+				return s.ApplyLimits(cfg.Limits, cfg.TenantLimits)
+			},
+		},
+		{
+			Name: "ha_tracker",
+			Reloader: func(cfg *config.Config) error {
+				return s.ApplyHATrackerConfig(cfg.HATracker)
+			},
+		},
+		{
+			Name: "retention",
+			Reloader: func(cfg *config.Config) error {
+				return retention.ApplyConfig(cfg.Retention)
+			},
+		},
+		{
+			Name: "rollups",
+			Reloader: func(cfg *config.Config) error {
+				return rollups.ApplyConfig(ctx, cfg.Rollups)
+			},
+		},
+		{
+			Name: "sample_labels",
+			Reloader: func(cfg *config.Config) error {
+				return sampleLabels.ApplyConfig(cfg.SampleLabels)
+			},
+		},
 	}
 
 	cfgReloader, err := config.NewConfigReloader(logger, reg, flags.ConfigPath, reloaders)
@@ -351,12 +485,13 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		s := symbolizer.New(
 			logger,
 			reg,
-			metastore,
+			metastoreClient,
 			dbgInfo,
 			sym,
 			flags.DebuginfoCacheDir,
 			flags.DebuginfoCacheDir,
 			0,
+			tables.Tenants,
 		)
 		ctx, cancel := context.WithCancel(ctx)
 		gr.Add(
@@ -369,6 +504,52 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 				sym.Close()
 			})
 	}
+	if flags.MetastoreGCInterval > 0 && badgerStore != nil {
+		var caches []metastore.Cache
+		if normalizerCache != nil {
+			caches = append(caches, normalizerCache)
+		}
+		gc := metastore.NewGarbageCollector(
+			logger,
+			reg,
+			badgerStore,
+			querier,
+			flags.MetastoreGCDryRun,
+			metastoreGCGracePeriod,
+			caches...,
+		)
+		ctx, cancel := context.WithCancel(ctx)
+		gr.Add(
+			func() error {
+				return gc.Run(ctx, flags.MetastoreGCInterval)
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "metastore garbage collector exiting")
+				cancel()
+			})
+	}
+	{
+		ctx, cancel := context.WithCancel(ctx)
+		gr.Add(
+			func() error {
+				return retention.Run(ctx)
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "retention exiting")
+				cancel()
+			})
+	}
+	{
+		ctx, cancel := context.WithCancel(ctx)
+		gr.Add(
+			func() error {
+				return rollups.Run(ctx)
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "rollups exiting")
+				cancel()
+			})
+	}
 	gr.Add(
 		func() error {
 			return discoveryManager.Run()
@@ -408,6 +589,7 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 				server.RegisterableFunc(func(ctx context.Context, srv *grpc.Server, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
 					debuginfopb.RegisterDebugInfoServiceServer(srv, dbgInfo)
 					profilestorepb.RegisterProfileStoreServiceServer(srv, s)
+					otelcollectorpb.RegisterProfilesServiceServer(srv, otlpProfiles)
 					querypb.RegisterQueryServiceServer(srv, q)
 					scrapepb.RegisterScrapeServiceServer(srv, m)
 
@@ -419,6 +601,24 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 						return err
 					}
 
+					if err := mux.HandlePath(http.MethodPost, profilestore.OTLPHTTPPath, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+						otlpProfiles.ServeHTTP(w, r)
+					}); err != nil {
+						return err
+					}
+
+					if err := mux.HandlePath(http.MethodPost, profilestore.PushPath, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+						s.ServePush(w, r)
+					}); err != nil {
+						return err
+					}
+
+					if err := mux.HandlePath(http.MethodPost, profilestore.PyroscopeIngestPath, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+						s.ServePyroscopeIngest(w, r)
+					}); err != nil {
+						return err
+					}
+
 					if err := querypb.RegisterQueryServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
 						return err
 					}
@@ -470,44 +670,9 @@ func runScraper(
 		return fmt.Errorf("parca scraper mode needs to have a --store-address")
 	}
 
-	metrics := grpc_prometheus.NewClientMetrics()
-	metrics.EnableClientHandlingTimeHistogram()
-	reg.MustRegister(metrics)
-
-	opts := []grpc.DialOption{
-		grpc.WithUnaryInterceptor(
-			metrics.UnaryClientInterceptor(),
-		),
-	}
-	if flags.Insecure {
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
-			InsecureSkipVerify: flags.InsecureSkipVerify,
-		})))
-	}
-
-	if flags.BearerToken != "" {
-		opts = append(opts, grpc.WithPerRPCCredentials(&perRequestBearerToken{
-			token:    flags.BearerToken,
-			insecure: flags.Insecure,
-		}))
-	}
-
-	if flags.BearerTokenFile != "" {
-		b, err := os.ReadFile(flags.BearerTokenFile)
-		if err != nil {
-			return fmt.Errorf("failed to read bearer token from file: %w", err)
-		}
-		opts = append(opts, grpc.WithPerRPCCredentials(&perRequestBearerToken{
-			token:    strings.TrimSpace(string(b)),
-			insecure: flags.Insecure,
-		}))
-	}
-
-	conn, err := grpc.Dial(flags.StoreAddress, opts...)
+	conn, err := dialStore(reg, flags.StoreFlags)
 	if err != nil {
-		return fmt.Errorf("failed to create gRPC connection: %w", err)
+		return err
 	}
 
 	store := profilestore.NewGRPCForwarder(conn, logger)
@@ -622,6 +787,50 @@ func runScraper(
 	return nil
 }
 
+// dialStore connects to the remote store, recording client metrics.
+func dialStore(reg prometheus.Registerer, flags StoreFlags) (*grpc.ClientConn, error) {
+	metrics := grpc_prometheus.NewClientMetrics()
+	metrics.EnableClientHandlingTimeHistogram()
+	reg.MustRegister(metrics)
+
+	opts := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(
+			metrics.UnaryClientInterceptor(),
+		),
+	}
+	if flags.Insecure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: flags.InsecureSkipVerify,
+		})))
+	}
+
+	if flags.BearerToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(&perRequestBearerToken{
+			token:    flags.BearerToken,
+			insecure: flags.Insecure,
+		}))
+	}
+
+	if flags.BearerTokenFile != "" {
+		b, err := os.ReadFile(flags.BearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bearer token from file: %w", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(&perRequestBearerToken{
+			token:    strings.TrimSpace(string(b)),
+			insecure: flags.Insecure,
+		}))
+	}
+
+	conn, err := grpc.Dial(flags.StoreAddress, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	return conn, nil
+}
+
 type perRequestBearerToken struct {
 	token    string
 	insecure bool
@@ -275,7 +275,7 @@ func replayDebugLog(ctx context.Context, t Testing) (querypb.QueryServiceServer,
 
 			return parcacol.NewIngester(
 				logger,
-				parcacol.NewNormalizer(metastore),
+				parcacol.NewNormalizer(metastore, nil),
 				table,
 				schema,
 			).Ingest(ctx, sample.Labels, p, false)
@@ -382,7 +382,7 @@ func TestConsistency(t *testing.T) {
 	p := &pprofpb.Profile{}
 	require.NoError(t, p.UnmarshalVT(MustReadAllGzip(t, "../query/testdata/alloc_objects.pb.gz")))
 
-	ingester := parcacol.NewIngester(logger, parcacol.NewNormalizer(metastore), table, schema)
+	ingester := parcacol.NewIngester(logger, parcacol.NewNormalizer(metastore, nil), table, schema)
 	require.NoError(t, ingester.Ingest(ctx, labels.Labels{{Name: "__name__", Value: "memory"}}, p, false))
 
 	table.Sync()
@@ -0,0 +1,75 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parca
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
+	"github.com/parca-dev/parca/pkg/valuelog"
+)
+
+// ReplayFlags are the flags of the replay command.
+type ReplayFlags struct {
+	Dir      string `arg:"" optional:"" type:"existingdir" default:"tmp" help:"Directory of the debug value log written with --storage-debug-value-log, tmp by default."`
+	LogLevel string `default:"info" enum:"error,warn,info,debug" help:"log level."`
+
+	Speed  float64           `default:"1" help:"Factor by which the replay is faster than the original writes. 0 replays all profiles without waiting."`
+	Label  map[string]string `help:"Label(s) to set on all replayed profiles, replacing labels of the same name. Labels with an empty value are removed."`
+	Tenant string            `help:"Tenant to replay all profiles for, instead of the tenant they were written for."`
+
+	StoreFlags `embed:""`
+}
+
+// Replay replays the profiles of a debug value log to a remote store in the
+// order they were written.
+func Replay(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags *ReplayFlags) error {
+	if flags.StoreAddress == "" {
+		return fmt.Errorf("parca replay needs to have a --store-address")
+	}
+	if flags.Speed < 0 {
+		return fmt.Errorf("speed must not be negative")
+	}
+	if flags.Tenant != "" {
+		if err := tenant.Validate(flags.Tenant); err != nil {
+			return err
+		}
+	}
+
+	entries, err := valuelog.Read(flags.Dir)
+	if err != nil {
+		return fmt.Errorf("read debug value log: %w", err)
+	}
+
+	conn, err := dialStore(reg, flags.StoreFlags)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	level.Info(logger).Log("msg", "replaying profiles", "dir", flags.Dir, "profiles", len(entries), "speed", flags.Speed)
+	written, err := valuelog.Replay(ctx, profilestorepb.NewProfileStoreServiceClient(conn), entries, valuelog.ReplayOptions{
+		Speed:  flags.Speed,
+		Labels: flags.Label,
+		Tenant: flags.Tenant,
+	})
+	level.Info(logger).Log("msg", "replay finished", "replayed", written, "total", len(entries))
+	return err
+}
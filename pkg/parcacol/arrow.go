@@ -16,6 +16,8 @@ package parcacol
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
@@ -82,12 +84,16 @@ func (c *ArrowToProfileConverter) Convert(
 		return nil, fmt.Errorf("read stacktrace metadata: %w", err)
 	}
 
+	pprofLabels := sampleLabelColumnsFromRecord(ar)
+
 	samples := make([]*profile.SymbolizedSample, 0, rows)
 	for i := 0; i < rows; i++ {
-		samples = append(samples, &profile.SymbolizedSample{
+		s := &profile.SymbolizedSample{
 			Value:     valueColumn.Value(i),
 			Locations: stacktraceLocations[i],
-		})
+		}
+		s.Label, s.NumLabel, s.NumUnit = pprofLabels.row(i)
+		samples = append(samples, s)
 	}
 
 	return &profile.Profile{
@@ -96,6 +102,87 @@ func (c *ArrowToProfileConverter) Convert(
 	}, nil
 }
 
+// sampleLabelColumns holds the pprof label columns of a record, keyed by
+// label name.
+type sampleLabelColumns struct {
+	labels    map[string]arrow.Array
+	numLabels map[string]*array.Int64
+	numUnits  map[string]arrow.Array
+}
+
+func sampleLabelColumnsFromRecord(ar arrow.Record) sampleLabelColumns {
+	cols := sampleLabelColumns{
+		labels:    map[string]arrow.Array{},
+		numLabels: map[string]*array.Int64{},
+		numUnits:  map[string]arrow.Array{},
+	}
+	for i, field := range ar.Schema().Fields() {
+		switch {
+		case strings.HasPrefix(field.Name, ColumnPprofLabels+"."):
+			cols.labels[strings.TrimPrefix(field.Name, ColumnPprofLabels+".")] = ar.Column(i)
+		case strings.HasPrefix(field.Name, ColumnPprofNumLabels+"."):
+			if c, ok := ar.Column(i).(*array.Int64); ok {
+				cols.numLabels[strings.TrimPrefix(field.Name, ColumnPprofNumLabels+".")] = c
+			}
+		case strings.HasPrefix(field.Name, ColumnPprofNumUnits+"."):
+			cols.numUnits[strings.TrimPrefix(field.Name, ColumnPprofNumUnits+".")] = ar.Column(i)
+		}
+	}
+	return cols
+}
+
+// stringValue returns the value of a string column at row i and whether it
+// is set.
+func stringValue(c arrow.Array, i int) (string, bool) {
+	if c.IsNull(i) {
+		return "", false
+	}
+	switch c := c.(type) {
+	case *array.Binary:
+		return string(c.Value(i)), true
+	case *array.String:
+		return c.Value(i), true
+	default:
+		return "", false
+	}
+}
+
+// row returns the string labels, numeric labels and units of the numeric
+// labels of row i, each of which is nil if the row has none.
+func (cols sampleLabelColumns) row(i int) (map[string]string, map[string]int64, map[string]string) {
+	var (
+		label    map[string]string
+		numLabel map[string]int64
+		numUnit  map[string]string
+	)
+	for name, c := range cols.labels {
+		if v, ok := stringValue(c, i); ok {
+			if label == nil {
+				label = map[string]string{}
+			}
+			label[name] = v
+		}
+	}
+	for name, c := range cols.numLabels {
+		if c.IsNull(i) {
+			continue
+		}
+		if numLabel == nil {
+			numLabel = map[string]int64{}
+		}
+		numLabel[name] = c.Value(i)
+		if u, ok := cols.numUnits[name]; ok {
+			if v, ok := stringValue(u, i); ok {
+				if numUnit == nil {
+					numUnit = map[string]string{}
+				}
+				numUnit[name] = v
+			}
+		}
+	}
+	return label, numLabel, numUnit
+}
+
 func (c *ArrowToProfileConverter) SymbolizeNormalizedProfile(ctx context.Context, p *profile.NormalizedProfile) (*profile.Profile, error) {
 	stacktraceIDs := make([]string, len(p.Samples))
 	for i, sample := range p.Samples {
@@ -113,6 +200,9 @@ func (c *ArrowToProfileConverter) SymbolizeNormalizedProfile(ctx context.Context
 			Value:     sample.Value,
 			DiffValue: sample.DiffValue,
 			Locations: stacktraceLocations[i],
+			Label:     sample.Label,
+			NumLabel:  sample.NumLabel,
+			NumUnit:   sample.NumUnit,
 		}
 	}
 
@@ -129,6 +219,18 @@ func (c *ArrowToProfileConverter) resolveStacktraces(ctx context.Context, stackt
 	ctx, span := c.tracer.Start(ctx, "resolve-stacktraces")
 	defer span.End()
 
+	stats := QueryStatsFromContext(ctx)
+	defer stats.ObserveResolve(time.Now())
+
+	if stats != nil {
+		distinct := make(map[string]struct{}, len(stacktraceIDs))
+		for _, id := range stacktraceIDs {
+			distinct[id] = struct{}{}
+		}
+		stats.AddDistinctStacktraces(uint64(len(distinct)))
+	}
+
+	stats.AddMetastoreLookups(1)
 	sres, err := c.m.Stacktraces(ctx, &pb.StacktracesRequest{
 		StacktraceIds: stacktraceIDs,
 	})
@@ -152,6 +254,7 @@ func (c *ArrowToProfileConverter) resolveStacktraces(ctx context.Context, stackt
 		}
 	}
 
+	stats.AddMetastoreLookups(1)
 	lres, err := c.m.Locations(ctx, &pb.LocationsRequest{LocationIds: locationIDs})
 	if err != nil {
 		return nil, err
@@ -196,6 +299,7 @@ func (c *ArrowToProfileConverter) getLocationsFromSerializedLocations(
 
 	var mappings []*pb.Mapping
 	if len(mappingIDs) > 0 {
+		QueryStatsFromContext(ctx).AddMetastoreLookups(1)
 		mres, err := c.m.Mappings(ctx, &pb.MappingsRequest{
 			MappingIds: mappingIDs,
 		})
@@ -219,6 +323,7 @@ func (c *ArrowToProfileConverter) getLocationsFromSerializedLocations(
 		}
 	}
 
+	QueryStatsFromContext(ctx).AddMetastoreLookups(1)
 	fres, err := c.m.Functions(ctx, &pb.FunctionsRequest{
 		FunctionIds: functionIDs,
 	})
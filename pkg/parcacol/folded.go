@@ -0,0 +1,173 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// FoldedStack is a single line of the collapsed/folded stack format.
+type FoldedStack struct {
+	// Frames are the function names of the stack ordered from the leaf to
+	// the root, the same way locations of a stacktrace are ordered.
+	Frames []string
+	Value  int64
+}
+
+// ParseFoldedStacks parses the collapsed/folded stack text format as produced
+// by Brendan Gregg's stackcollapse scripts. Every line holds the frames of one
+// stack separated by semicolons from the root to the leaf, followed by a space
+// and the sample value. Empty lines are ignored.
+func ParseFoldedStacks(r io.Reader) ([]FoldedStack, error) {
+	var (
+		stacks []FoldedStack
+		lineNo int
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		i := strings.LastIndexAny(line, " \t")
+		if i == -1 {
+			return nil, fmt.Errorf("line %d: missing sample value", lineNo)
+		}
+
+		value, err := strconv.ParseInt(line[i+1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid sample value %q: %w", lineNo, line[i+1:], err)
+		}
+		if value < 0 {
+			return nil, fmt.Errorf("line %d: negative sample value %d", lineNo, value)
+		}
+
+		stack := strings.TrimSpace(line[:i])
+		if stack == "" {
+			return nil, fmt.Errorf("line %d: empty stack", lineNo)
+		}
+
+		frames := strings.Split(stack, ";")
+		for j, k := 0, len(frames)-1; j < k; j, k = j+1, k-1 {
+			frames[j], frames[k] = frames[k], frames[j]
+		}
+
+		stacks = append(stacks, FoldedStack{
+			Frames: frames,
+			Value:  value,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", lineNo+1, err)
+	}
+
+	return stacks, nil
+}
+
+// NormalizeFolded creates the functions, locations and stacktraces of the
+// folded stacks in the metastore and returns the resulting profile. Every
+// distinct frame becomes a function with a single, already symbolized,
+// location.
+func (n *Normalizer) NormalizeFolded(ctx context.Context, meta profile.Meta, stacks []FoldedStack) (*profile.NormalizedProfile, error) {
+	frameIndex := map[string]int{}
+	functionsReq := []*pb.Function{}
+	for _, s := range stacks {
+		for _, frame := range s.Frames {
+			if _, ok := frameIndex[frame]; ok {
+				continue
+			}
+			frameIndex[frame] = len(functionsReq)
+			functionsReq = append(functionsReq, &pb.Function{
+				Name:       frame,
+				SystemName: frame,
+			})
+		}
+	}
+
+	functions, err := n.getOrCreateFunctions(ctx, functionsReq)
+	if err != nil {
+		return nil, fmt.Errorf("get or create functions: %w", err)
+	}
+
+	locationsReq := make([]*pb.Location, 0, len(functions))
+	for _, f := range functions {
+		locationsReq = append(locationsReq, &pb.Location{
+			Address: UnsymolizableLocationAddress,
+			Lines: []*pb.Line{{
+				FunctionId: f.Id,
+			}},
+		})
+	}
+
+	locations, err := n.getOrCreateLocations(ctx, locationsReq)
+	if err != nil {
+		return nil, fmt.Errorf("get or create locations: %w", err)
+	}
+
+	stacktracesReq := make([]*pb.Stacktrace, 0, len(stacks))
+	for _, s := range stacks {
+		locationIds := make([]string, 0, len(s.Frames))
+		for _, frame := range s.Frames {
+			locationIds = append(locationIds, locations[frameIndex[frame]].Id)
+		}
+		stacktracesReq = append(stacktracesReq, &pb.Stacktrace{
+			LocationIds: locationIds,
+		})
+	}
+
+	stacktraces, err := n.getOrCreateStacktraces(ctx, stacktracesReq)
+	if err != nil {
+		return nil, fmt.Errorf("get or create stacktraces: %w", err)
+	}
+
+	p := &profile.NormalizedProfile{
+		Meta:    meta,
+		Samples: make([]*profile.NormalizedSample, 0, len(stacks)),
+	}
+
+	// The same stack may appear on multiple lines, in which case the values
+	// are summed up.
+	sampleIndex := map[string]int{}
+	for i, s := range stacks {
+		if s.Value == 0 {
+			continue
+		}
+
+		id := stacktraces[i].Id
+		if index, ok := sampleIndex[id]; ok {
+			p.Samples[index].Value += s.Value
+			continue
+		}
+
+		sampleIndex[id] = len(p.Samples)
+		p.Samples = append(p.Samples, &profile.NormalizedSample{
+			StacktraceID: id,
+			Value:        s.Value,
+		})
+	}
+
+	return p, nil
+}
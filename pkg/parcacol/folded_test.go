@@ -0,0 +1,123 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/metastoretest"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestParseFoldedStacks(t *testing.T) {
+	stacks, err := ParseFoldedStacks(strings.NewReader(`
+main;handler;json.Marshal 10
+main;handler (app.py:12) 3
+
+main;idle 0
+`))
+	require.NoError(t, err)
+	require.Equal(t, []FoldedStack{{
+		Frames: []string{"json.Marshal", "handler", "main"},
+		Value:  10,
+	}, {
+		Frames: []string{"handler (app.py:12)", "main"},
+		Value:  3,
+	}, {
+		Frames: []string{"idle", "main"},
+		Value:  0,
+	}}, stacks)
+
+	for _, input := range []string{
+		"main;handler",
+		"main;handler ten",
+		"main;handler -1",
+		" 10",
+	} {
+		_, err := ParseFoldedStacks(strings.NewReader(input))
+		require.Error(t, err, input)
+	}
+}
+
+func TestNormalizeFolded(t *testing.T) {
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	ctx := context.Background()
+
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+	mc := metastore.NewInProcessClient(m)
+
+	stacks, err := ParseFoldedStacks(strings.NewReader(`main;handler;json.Marshal 10
+main;handler 3
+main;handler;json.Marshal 5
+main;idle 0
+`))
+	require.NoError(t, err)
+
+	meta := profile.Meta{
+		Name:       "cpu",
+		SampleType: profile.ValueType{Type: "samples", Unit: "count"},
+	}
+	p, err := NewNormalizer(mc, nil).NormalizeFolded(ctx, meta, stacks)
+	require.NoError(t, err)
+	require.Equal(t, meta, p.Meta)
+
+	// Identical stacks are merged and samples without value are dropped.
+	require.Len(t, p.Samples, 2)
+	require.Equal(t, int64(15), p.Samples[0].Value)
+	require.Equal(t, int64(3), p.Samples[1].Value)
+
+	st, err := mc.Stacktraces(ctx, &pb.StacktracesRequest{
+		StacktraceIds: []string{p.Samples[0].StacktraceID},
+	})
+	require.NoError(t, err)
+	require.Len(t, st.Stacktraces[0].LocationIds, 3)
+
+	locs, err := mc.Locations(ctx, &pb.LocationsRequest{
+		LocationIds: st.Stacktraces[0].LocationIds,
+	})
+	require.NoError(t, err)
+
+	functionIds := make([]string, 0, len(locs.Locations))
+	for _, l := range locs.Locations {
+		require.Len(t, l.Lines, 1)
+		functionIds = append(functionIds, l.Lines[0].FunctionId)
+	}
+
+	fns, err := mc.Functions(ctx, &pb.FunctionsRequest{
+		FunctionIds: functionIds,
+	})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(fns.Functions))
+	for _, f := range fns.Functions {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{"json.Marshal", "handler", "main"}, names)
+}
@@ -25,6 +25,7 @@ import (
 
 	pprofproto "github.com/parca-dev/parca/gen/proto/go/google/pprof"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 type Table interface {
@@ -37,6 +38,7 @@ type Ingester struct {
 	table      Table
 	normalizer *Normalizer
 	schema     *dynparquet.Schema
+	rollups    *Rollups
 }
 
 func NewIngester(logger log.Logger, normalizer *Normalizer, table Table, schema *dynparquet.Schema) *Ingester {
@@ -48,6 +50,13 @@ func NewIngester(logger log.Logger, normalizer *Normalizer, table Table, schema
 	}
 }
 
+// WithRollups makes the ingester add the profiles that are written after
+// their time was rolled up to the rollups.
+func (ing *Ingester) WithRollups(r *Rollups) *Ingester {
+	ing.rollups = r
+	return ing
+}
+
 var ErrMissingNameLabel = errors.New("missing __name__ label")
 
 func separateNameFromLabels(ls labels.Labels) (string, map[string]struct{}, labels.Labels, error) {
@@ -99,115 +108,47 @@ func (ing Ingester) Ingest(ctx context.Context, ls labels.Labels, p *pprofproto.
 	return nil
 }
 
-func (ing Ingester) IngestProfile(ctx context.Context, ls labels.Labels, p *profile.NormalizedProfile) error {
-	buffer, err := NormalizedProfileToParquetBuffer(ing.schema, ls, p)
+// IngestFolded normalizes and ingests a profile given as folded stacks. The
+// name of the profile is taken from the __name__ label.
+func (ing Ingester) IngestFolded(ctx context.Context, ls labels.Labels, meta profile.Meta, stacks []FoldedStack) error {
+	name, _, ls, err := separateNameFromLabels(ls)
 	if err != nil {
-		return fmt.Errorf("failed to convert samples to buffer: %w", err)
+		return fmt.Errorf("prepare labels: %w", err)
 	}
+	meta.Name = name
 
-	_, err = ing.table.InsertBuffer(ctx, buffer)
+	p, err := ing.normalizer.NormalizeFolded(ctx, meta, stacks)
 	if err != nil {
-		return fmt.Errorf("insert buffer: %w", err)
+		return fmt.Errorf("normalize folded stacks: %w", err)
 	}
 
-	return nil
-}
-
-func validatePprofProfile(p *pprofproto.Profile) error {
-	stringTableLen := int64(len(p.StringTable))
-
-	if stringTableLen > 0 && p.StringTable[0] != "" {
-		return fmt.Errorf("first item in string table is expected to be empty string, but it is %q", p.StringTable[0])
+	if len(p.Samples) == 0 {
+		level.Debug(ing.logger).Log("msg", "no samples found in profile, dropping it", "name", p.Meta.Name, "sample_type", p.Meta.SampleType.Type, "sample_unit", p.Meta.SampleType.Unit, "labels", ls)
+		return nil
 	}
 
-	// Check that all mappings/locations/functions are in the tables
-	// Check that there are no duplicate ids
-	mappingsNum := uint64(len(p.Mapping))
-	for i, m := range p.Mapping {
-		if m == nil {
-			return fmt.Errorf("profile has nil mapping")
-		}
-		if m.Id != uint64(i+1) {
-			return fmt.Errorf("mapping id is not sequential")
-		}
-		if m.Filename != 0 && m.Filename > stringTableLen {
-			return fmt.Errorf("mapping (id: %d) has invalid filename index %d", m.Id, m.Filename)
-		}
-		if m.BuildId != 0 && m.BuildId > stringTableLen {
-			return fmt.Errorf("mapping (id: %d) has invalid buildid index %d", m.Id, m.Filename)
-		}
+	if err := ing.IngestProfile(ctx, ls, p); err != nil {
+		return fmt.Errorf("ingest profile: %w", err)
 	}
 
-	functionsNum := uint64(len(p.Function))
-	for i, f := range p.Function {
-		if f == nil {
-			return fmt.Errorf("profile has nil function")
-		}
-		if f.Id != uint64(i+1) {
-			return fmt.Errorf("function id is not sequential")
-		}
-		if f.Name != 0 && f.Name > stringTableLen {
-			return fmt.Errorf("function (id: %d) has invalid name index %d", f.Id, f.Name)
-		}
-		if f.SystemName != 0 && f.SystemName > stringTableLen {
-			return fmt.Errorf("function (id: %d) has invalid name index %d", f.Id, f.SystemName)
-		}
-		if f.Filename != 0 && f.Filename > stringTableLen {
-			return fmt.Errorf("function (id: %d) has invalid filename index %d", f.Id, f.Filename)
-		}
-	}
-
-	locationsNum := uint64(len(p.Location))
-	for i, l := range p.Location {
-		if l == nil {
-			return fmt.Errorf("profile has nil location")
-		}
-		if l.Id != uint64(i+1) {
-			return fmt.Errorf("location id is not sequential")
-		}
-		if l.MappingId != 0 && l.MappingId > mappingsNum {
-			return fmt.Errorf("location has invalid mapping id: %d", l.MappingId)
-		}
-		for _, ln := range l.Line {
-			if ln.FunctionId != 0 && ln.FunctionId > functionsNum {
-				return fmt.Errorf("location %d has invalid function id: %d", l.Id, ln.FunctionId)
-			}
-		}
-	}
+	return nil
+}
 
-	// Check that sample values are consistent
-	sampleLen := len(p.SampleType)
-	if sampleLen == 0 && len(p.Sample) != 0 {
-		return fmt.Errorf("missing sample type information")
+func (ing Ingester) IngestProfile(ctx context.Context, ls labels.Labels, p *profile.NormalizedProfile) error {
+	buffer, err := NormalizedProfileToParquetBuffer(ing.schema, ls, p)
+	if err != nil {
+		return fmt.Errorf("failed to convert samples to buffer: %w", err)
 	}
 
-	for i, s := range p.Sample {
-		if s == nil {
-			return fmt.Errorf("profile has nil sample")
-		}
-		if len(s.Value) != sampleLen {
-			return fmt.Errorf("mismatch: sample has %d values vs. %d types", len(s.Value), len(p.SampleType))
-		}
-		for j, l := range s.LocationId {
-			if l == 0 {
-				return fmt.Errorf("location ids of stacktraces must be non-zero")
-			}
-			if l > locationsNum {
-				return fmt.Errorf("sample %d location number %d (%d) is out of range", i, j, l)
-			}
-		}
-		for j, label := range s.Label {
-			if label.Key == 0 {
-				return fmt.Errorf("sample %d label %d has no key", i, j)
-			}
-			if label.Key != 0 && label.Key > stringTableLen {
-				return fmt.Errorf("sample %d label %d has invalid key index %d", i, j, label.Key)
-			}
-			if label.Str != 0 && label.Str > stringTableLen {
-				return fmt.Errorf("sample %d label %d has invalid str index %d", i, j, label.Key)
-			}
+	insert := func() error {
+		if _, err := ing.table.InsertBuffer(ctx, buffer); err != nil {
+			return fmt.Errorf("insert buffer: %w", err)
 		}
+		return nil
+	}
+	if ing.rollups == nil {
+		return insert()
 	}
 
-	return nil
+	return ing.rollups.Write(ctx, tenant.FromContext(ctx), ls, p, insert)
 }
@@ -66,7 +66,7 @@ func TestPprofToParquet(t *testing.T) {
 	p := &pprofpb.Profile{}
 	require.NoError(t, p.UnmarshalVT(MustReadAllGzip(t, "../query/testdata/alloc_objects.pb.gz")))
 
-	nps, err := NewNormalizer(metastore).NormalizePprof(ctx, "memory", map[string]struct{}{}, p, false)
+	nps, err := NewNormalizer(metastore, nil).NormalizePprof(ctx, "memory", map[string]struct{}{}, p, false)
 	require.NoError(t, err)
 
 	for i, np := range nps {
@@ -95,3 +95,23 @@ func TestPprofToParquet(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatePprofProfileLabels(t *testing.T) {
+	t.Parallel()
+
+	profile := func(label *pprofpb.Label) *pprofpb.Profile {
+		return &pprofpb.Profile{
+			StringTable: []string{"", "size", "bytes"},
+			SampleType:  []*pprofpb.ValueType{{}},
+			Sample: []*pprofpb.Sample{{
+				Value: []int64{1},
+				Label: []*pprofpb.Label{label},
+			}},
+		}
+	}
+
+	require.NoError(t, validatePprofProfile(profile(&pprofpb.Label{Key: 1, Num: 1, NumUnit: 2})))
+	require.Error(t, validatePprofProfile(profile(&pprofpb.Label{Key: 3})))
+	require.Error(t, validatePprofProfile(profile(&pprofpb.Label{Key: 1, Str: 3})))
+	require.Error(t, validatePprofProfile(profile(&pprofpb.Label{Key: 1, Num: 1, NumUnit: 3})))
+}
@@ -17,9 +17,11 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/profile"
 )
 
@@ -27,16 +29,69 @@ const (
 	UnsymolizableLocationAddress = 0x0
 )
 
+// The pprof labels holding the IDs of the span a sample was recorded in, as
+// set by otel-profiling-go for example. They are stored in columns of their
+// own instead of as pprof labels.
+const (
+	LabelTraceID = "trace_id"
+	LabelSpanID  = "span_id"
+)
+
 type Normalizer struct {
 	metastore pb.MetastoreServiceClient
+	cache     *NormalizerCache
 }
 
-func NewNormalizer(metastore pb.MetastoreServiceClient) *Normalizer {
+// NewNormalizer returns a Normalizer creating the entries of profiles in the
+// metastore. The cache may be nil, in which case every entry is looked up in
+// the metastore.
+func NewNormalizer(metastore pb.MetastoreServiceClient, cache *NormalizerCache) *Normalizer {
 	return &Normalizer{
 		metastore: metastore,
+		cache:     cache,
 	}
 }
 
+func (n *Normalizer) getOrCreateMappings(ctx context.Context, mappings []*pb.Mapping) ([]*pb.Mapping, error) {
+	return getOrCreate(ctx, n.cache, cacheKindMappings, mappings, metastore.MakeMappingID, func(ctx context.Context, mappings []*pb.Mapping) ([]*pb.Mapping, error) {
+		res, err := n.metastore.GetOrCreateMappings(ctx, &pb.GetOrCreateMappingsRequest{Mappings: mappings})
+		if err != nil {
+			return nil, err
+		}
+		return res.Mappings, nil
+	})
+}
+
+func (n *Normalizer) getOrCreateFunctions(ctx context.Context, functions []*pb.Function) ([]*pb.Function, error) {
+	return getOrCreate(ctx, n.cache, cacheKindFunctions, functions, metastore.MakeFunctionID, func(ctx context.Context, functions []*pb.Function) ([]*pb.Function, error) {
+		res, err := n.metastore.GetOrCreateFunctions(ctx, &pb.GetOrCreateFunctionsRequest{Functions: functions})
+		if err != nil {
+			return nil, err
+		}
+		return res.Functions, nil
+	})
+}
+
+func (n *Normalizer) getOrCreateLocations(ctx context.Context, locations []*pb.Location) ([]*pb.Location, error) {
+	return getOrCreate(ctx, n.cache, cacheKindLocations, locations, metastore.MakeLocationID, func(ctx context.Context, locations []*pb.Location) ([]*pb.Location, error) {
+		res, err := n.metastore.GetOrCreateLocations(ctx, &pb.GetOrCreateLocationsRequest{Locations: locations})
+		if err != nil {
+			return nil, err
+		}
+		return res.Locations, nil
+	})
+}
+
+func (n *Normalizer) getOrCreateStacktraces(ctx context.Context, stacktraces []*pb.Stacktrace) ([]*pb.Stacktrace, error) {
+	return getOrCreate(ctx, n.cache, cacheKindStacktraces, stacktraces, metastore.MakeStacktraceID, func(ctx context.Context, stacktraces []*pb.Stacktrace) ([]*pb.Stacktrace, error) {
+		res, err := n.metastore.GetOrCreateStacktraces(ctx, &pb.GetOrCreateStacktracesRequest{Stacktraces: stacktraces})
+		if err != nil {
+			return nil, err
+		}
+		return res.Stacktraces, nil
+	})
+}
+
 func (n *Normalizer) NormalizePprof(ctx context.Context, name string, takenLabelNames map[string]struct{}, p *pprofpb.Profile, normalizedAddress bool) ([]*profile.NormalizedProfile, error) {
 	mappings, err := n.NormalizeMappings(ctx, p.Mapping, p.StringTable)
 	if err != nil {
@@ -77,8 +132,9 @@ func (n *Normalizer) NormalizePprof(ctx context.Context, name string, takenLabel
 	}
 
 	for i, sample := range p.Sample {
-		labels, numLabels := labelsFromSample(takenLabelNames, p.StringTable, sample.Label)
-		key := sampleKey(stacktraces[i].Id, labels, numLabels)
+		labels, numLabels, numUnits := labelsFromSample(takenLabelNames, p.StringTable, sample.Label)
+		traceID, spanID := traceFromSample(p.StringTable, sample.Label)
+		key := sampleKey(stacktraces[i].Id, labels, numLabels, numUnits) + traceID + ";" + spanID
 		for j, value := range sample.Value {
 			if value == 0 {
 				continue
@@ -89,6 +145,9 @@ func (n *Normalizer) NormalizePprof(ctx context.Context, name string, takenLabel
 				Value:        sample.Value[j],
 				Label:        labels,
 				NumLabel:     numLabels,
+				NumUnit:      numUnits,
+				TraceID:      traceID,
+				SpanID:       spanID,
 			}
 
 			index, ok := sampleIndex[j][key]
@@ -104,20 +163,22 @@ func (n *Normalizer) NormalizePprof(ctx context.Context, name string, takenLabel
 	return profiles, nil
 }
 
-func sampleKey(stacktraceID string, labels map[string]string, numLabels map[string]int64) string {
+func sampleKey(stacktraceID string, labels map[string]string, numLabels map[string]int64, numUnits map[string]string) string {
 	key := stacktraceID + ";"
 	for k, v := range labels {
 		key += fmt.Sprintf("%s=%s;", k, v)
 	}
 	key += ";"
 	for k, v := range numLabels {
-		key += fmt.Sprintf("%s=%d;", k, v)
+		key += fmt.Sprintf("%s=%d%s;", k, v, numUnits[k])
 	}
 	return key
 }
 
-// TODO: support num label units.
-func labelsFromSample(takenLabelNames map[string]struct{}, stringTable []string, plabels []*pprofpb.Label) (map[string]string, map[string]int64) {
+// labelsFromSample returns the string and numeric labels of a sample, as
+// well as the units of the numeric labels that have one. Numeric labels are
+// converted to the canonical unit of their dimension.
+func labelsFromSample(takenLabelNames map[string]struct{}, stringTable []string, plabels []*pprofpb.Label) (map[string]string, map[string]int64, map[string]string) {
 	labels := map[string][]string{}
 	labelNames := []string{}
 	for _, label := range plabels {
@@ -126,6 +187,9 @@ func labelsFromSample(takenLabelNames map[string]struct{}, stringTable []string,
 		}
 
 		key := stringTable[label.Key]
+		if key == LabelTraceID || key == LabelSpanID {
+			continue
+		}
 		if _, ok := labels[key]; !ok {
 			labels[key] = []string{}
 			labelNames = append(labelNames, key)
@@ -147,16 +211,41 @@ func labelsFromSample(takenLabelNames map[string]struct{}, stringTable []string,
 	}
 
 	numLabels := map[string]int64{}
+	numUnits := map[string]string{}
 	for _, label := range plabels {
 		key := stringTable[label.Key]
 		if label.Num != 0 {
 			if _, ok := numLabels[key]; !ok {
-				numLabels[key] = label.Num
+				value, unit := profile.NormalizeNumLabel(key, label.Num, stringTable[label.NumUnit])
+				numLabels[key] = value
+				if unit != "" {
+					numUnits[key] = unit
+				}
 			}
 		}
 	}
 
-	return resLabels, numLabels
+	return resLabels, numLabels, numUnits
+}
+
+// traceFromSample returns the lowercase hex encoded IDs of the trace and span
+// a sample was recorded in, which are empty if the sample has no such labels.
+func traceFromSample(stringTable []string, plabels []*pprofpb.Label) (string, string) {
+	var traceID, spanID string
+	for _, label := range plabels {
+		if label.Str == 0 {
+			continue
+		}
+
+		// Like for other labels, the first value of a label is used.
+		switch key := stringTable[label.Key]; {
+		case key == LabelTraceID && traceID == "":
+			traceID = strings.ToLower(stringTable[label.Str])
+		case key == LabelSpanID && spanID == "":
+			spanID = strings.ToLower(stringTable[label.Str])
+		}
+	}
+	return traceID, spanID
 }
 
 type mappingNormalizationInfo struct {
@@ -183,13 +272,13 @@ func (n *Normalizer) NormalizeMappings(ctx context.Context, mappings []*pprofpb.
 		})
 	}
 
-	res, err := n.metastore.GetOrCreateMappings(ctx, req)
+	res, err := n.getOrCreateMappings(ctx, req.Mappings)
 	if err != nil {
 		return nil, err
 	}
 
-	mapInfos := make([]mappingNormalizationInfo, 0, len(res.Mappings))
-	for i, mapping := range res.Mappings {
+	mapInfos := make([]mappingNormalizationInfo, 0, len(res))
+	for i, mapping := range res {
 		mapInfos = append(mapInfos, mappingNormalizationInfo{
 			id:     mapping.Id,
 			offset: int64(mappings[i].MemoryStart) - int64(mapping.Start),
@@ -213,12 +302,12 @@ func (n *Normalizer) NormalizeFunctions(ctx context.Context, functions []*pprofp
 		})
 	}
 
-	res, err := n.metastore.GetOrCreateFunctions(ctx, req)
+	res, err := n.getOrCreateFunctions(ctx, req.Functions)
 	if err != nil {
 		return nil, fmt.Errorf("get or create functions: %w", err)
 	}
 
-	return res.Functions, nil
+	return res, nil
 }
 
 func (n *Normalizer) NormalizeLocations(
@@ -277,12 +366,7 @@ func (n *Normalizer) NormalizeLocations(
 		})
 	}
 
-	res, err := n.metastore.GetOrCreateLocations(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res.Locations, nil
+	return n.getOrCreateLocations(ctx, req.Locations)
 }
 
 func (n *Normalizer) NormalizeStacktraces(ctx context.Context, samples []*pprofpb.Sample, locations []*pb.Location) ([]*pb.Stacktrace, error) {
@@ -302,10 +386,5 @@ func (n *Normalizer) NormalizeStacktraces(ctx context.Context, samples []*pprofp
 		})
 	}
 
-	res, err := n.metastore.GetOrCreateStacktraces(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res.Stacktraces, nil
+	return n.getOrCreateStacktraces(ctx, req.Stacktraces)
 }
@@ -0,0 +1,158 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// The kinds of metastore entries cached by the NormalizerCache.
+const (
+	cacheKindMappings    = "mappings"
+	cacheKindFunctions   = "functions"
+	cacheKindLocations   = "locations"
+	cacheKindStacktraces = "stacktraces"
+)
+
+// NormalizerCache remembers the mappings, functions, locations and
+// stacktraces that are known to exist in the metastore, so normalizing a
+// profile only has to get or create the ones that are new. Entries are keyed
+// by their tenant and the content hash the metastore uses as their ID.
+type NormalizerCache struct {
+	caches map[string]*lru.Cache
+
+	// mtx guards generation, which is incremented by every purge. Entries
+	// looked up in the metastore before a purge are not added after it.
+	mtx        sync.RWMutex
+	generation uint64
+
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+}
+
+// NewNormalizerCache returns a NormalizerCache holding up to size entries of
+// every kind.
+func NewNormalizerCache(reg prometheus.Registerer, size int) (*NormalizerCache, error) {
+	c := &NormalizerCache{
+		caches: map[string]*lru.Cache{},
+		hits: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "normalizer_cache_hits_total",
+				Help: "Total number of metastore entries found in the normalizer cache, partitioned by kind.",
+			},
+			[]string{"kind"},
+		),
+		misses: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "normalizer_cache_misses_total",
+				Help: "Total number of metastore entries not found in the normalizer cache, partitioned by kind.",
+			},
+			[]string{"kind"},
+		),
+	}
+
+	for _, kind := range []string{cacheKindMappings, cacheKindFunctions, cacheKindLocations, cacheKindStacktraces} {
+		cache, err := lru.New(size)
+		if err != nil {
+			return nil, fmt.Errorf("create %s cache: %w", kind, err)
+		}
+		c.caches[kind] = cache
+	}
+
+	return c, nil
+}
+
+// Purge removes all entries from the cache. It must be called whenever
+// entries may have been deleted from the metastore.
+func (c *NormalizerCache) Purge() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.generation++
+	for _, cache := range c.caches {
+		cache.Purge()
+	}
+}
+
+// getOrCreate returns the entries of items, taking the ones known to exist
+// from the cache and passing the others to create. The created entries are
+// added to the cache, unless it was purged in the meantime. A nil cache
+// passes all items to create.
+func getOrCreate[T any](
+	ctx context.Context,
+	c *NormalizerCache,
+	kind string,
+	items []T,
+	id func(T) string,
+	create func(context.Context, []T) ([]T, error),
+) ([]T, error) {
+	if c == nil {
+		return create(ctx, items)
+	}
+
+	c.mtx.RLock()
+	generation := c.generation
+	c.mtx.RUnlock()
+
+	cache := c.caches[kind]
+	prefix := tenant.FromContext(ctx) + "/"
+	res := make([]T, len(items))
+	keys := make([]string, len(items))
+	missing := make([]T, 0, len(items))
+	missingIndex := make([]int, 0, len(items))
+	for i, item := range items {
+		keys[i] = prefix + id(item)
+		if v, ok := cache.Get(keys[i]); ok {
+			res[i] = v.(T)
+			continue
+		}
+		missing = append(missing, item)
+		missingIndex = append(missingIndex, i)
+	}
+
+	c.hits.WithLabelValues(kind).Add(float64(len(items) - len(missing)))
+	c.misses.WithLabelValues(kind).Add(float64(len(missing)))
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	created, err := create(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(created) != len(missing) {
+		return nil, fmt.Errorf("expected %d %s, got %d", len(missing), kind, len(created))
+	}
+
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	for j, item := range created {
+		i := missingIndex[j]
+		res[i] = item
+		if c.generation == generation {
+			cache.Add(keys[i], item)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/metastoretest"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// countingClient counts the stacktraces passed to GetOrCreateStacktraces.
+type countingClient struct {
+	pb.MetastoreServiceClient
+	stacktraces int
+}
+
+func (c *countingClient) GetOrCreateStacktraces(ctx context.Context, in *pb.GetOrCreateStacktracesRequest, opts ...grpc.CallOption) (*pb.GetOrCreateStacktracesResponse, error) {
+	c.stacktraces += len(in.Stacktraces)
+	return c.MetastoreServiceClient.GetOrCreateStacktraces(ctx, in, opts...)
+}
+
+func TestNormalizerCache(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewNopLogger()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	ctx := context.Background()
+
+	p := &pprofpb.Profile{}
+	require.NoError(t, p.UnmarshalVT(MustReadAllGzip(t, "../query/testdata/alloc_objects.pb.gz")))
+
+	m := metastoretest.NewTestMetastore(t, logger, prometheus.NewRegistry(), tracer)
+	client := &countingClient{MetastoreServiceClient: metastore.NewInProcessClient(m)}
+
+	expected, err := NewNormalizer(client, nil).NormalizePprof(ctx, "memory", map[string]struct{}{}, p, false)
+	require.NoError(t, err)
+	require.Equal(t, len(p.Sample), client.stacktraces)
+
+	cache, err := NewNormalizerCache(prometheus.NewRegistry(), 100_000)
+	require.NoError(t, err)
+	normalizer := NewNormalizer(client, cache)
+
+	// The first profile fills the cache, the second one is normalized from
+	// it without any request to the metastore.
+	for i := 0; i < 2; i++ {
+		client.stacktraces = 0
+		nps, err := normalizer.NormalizePprof(ctx, "memory", map[string]struct{}{}, p, false)
+		require.NoError(t, err)
+		require.Equal(t, expected, nps)
+	}
+	require.Equal(t, 0, client.stacktraces)
+	require.Equal(t, float64(len(p.Mapping)), testutil.ToFloat64(cache.hits.WithLabelValues(cacheKindMappings)))
+	require.Equal(t, float64(len(p.Sample)), testutil.ToFloat64(cache.hits.WithLabelValues(cacheKindStacktraces)))
+	require.Equal(t, float64(len(p.Sample)), testutil.ToFloat64(cache.misses.WithLabelValues(cacheKindStacktraces)))
+
+	// Entries are cached per tenant.
+	_, err = normalizer.NormalizePprof(tenant.NewContext(ctx, "team-a"), "memory", map[string]struct{}{}, p, false)
+	require.NoError(t, err)
+	require.Equal(t, len(p.Sample), client.stacktraces)
+
+	// After a purge all entries are requested again.
+	cache.Purge()
+	client.stacktraces = 0
+	_, err = normalizer.NormalizePprof(ctx, "memory", map[string]struct{}{}, p, false)
+	require.NoError(t, err)
+	require.Equal(t, len(p.Sample), client.stacktraces)
+}
@@ -29,6 +29,7 @@ func TestLabelsFromSample(t *testing.T) {
 		samples         []*pprofpb.Label
 		resultLabels    map[string]string
 		resultNumLabels map[string]int64
+		resultNumUnits  map[string]string
 	}{{
 		name: "descending order",
 		takenLabels: map[string]struct{}{
@@ -47,6 +48,7 @@ func TestLabelsFromSample(t *testing.T) {
 			"exported_exported_foo": "bar",
 		},
 		resultNumLabels: map[string]int64{},
+		resultNumUnits:  map[string]string{},
 	}, {
 		name: "ascending order",
 		takenLabels: map[string]struct{}{
@@ -65,13 +67,47 @@ func TestLabelsFromSample(t *testing.T) {
 			"exported_exported_a": "baz",
 		},
 		resultNumLabels: map[string]int64{},
+		resultNumUnits:  map[string]string{},
+	}, {
+		name:        "num label units",
+		stringTable: []string{"", "alloc", "kilobytes", "bytes", "latency", "ms", "requests"},
+		samples: []*pprofpb.Label{{
+			Key:     1,
+			Num:     2,
+			NumUnit: 2,
+		}, {
+			Key: 3,
+			Num: 512,
+		}, {
+			Key:     4,
+			Num:     3,
+			NumUnit: 5,
+		}, {
+			Key:     6,
+			Num:     7,
+			NumUnit: 6,
+		}},
+		resultLabels: map[string]string{},
+		resultNumLabels: map[string]int64{
+			"alloc":    2048,
+			"bytes":    512,
+			"latency":  3000000,
+			"requests": 7,
+		},
+		resultNumUnits: map[string]string{
+			"alloc":    "bytes",
+			"bytes":    "bytes",
+			"latency":  "nanoseconds",
+			"requests": "requests",
+		},
 	}}
 
 	for _, c := range cases {
 		t.Run("", func(t *testing.T) {
-			labels, numLabels := labelsFromSample(c.takenLabels, c.stringTable, c.samples)
+			labels, numLabels, numUnits := labelsFromSample(c.takenLabels, c.stringTable, c.samples)
 			require.Equal(t, c.resultLabels, labels)
 			require.Equal(t, c.resultNumLabels, numLabels)
+			require.Equal(t, c.resultNumUnits, numUnits)
 		})
 	}
 }
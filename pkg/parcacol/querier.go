@@ -17,12 +17,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/polarsignals/frostdb/query"
 	"github.com/polarsignals/frostdb/query/logicalplan"
 	"github.com/prometheus/prometheus/model/labels"
@@ -38,6 +40,7 @@ import (
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 var (
@@ -72,6 +75,19 @@ type Querier struct {
 	tableName string
 	converter *ArrowToProfileConverter
 	tracer    trace.Tracer
+	rollups   *Rollups
+}
+
+// WithRollups makes the querier read the rollups instead of the profiles
+// they were rolled up from, where the queries allow it.
+func (q *Querier) WithRollups(r *Rollups) *Querier {
+	q.rollups = r
+	return q
+}
+
+// table returns the name of the table of the tenant of the context.
+func (q *Querier) table(ctx context.Context) string {
+	return tenant.TableName(q.tableName, tenant.FromContext(ctx))
 }
 
 func (q *Querier) Labels(
@@ -81,7 +97,7 @@ func (q *Querier) Labels(
 ) ([]string, error) {
 	seen := map[string]struct{}{}
 
-	err := q.engine.ScanSchema(q.tableName).
+	err := q.engine.ScanSchema(q.table(ctx)).
 		Distinct(logicalplan.Col("name")).
 		Filter(logicalplan.Col("name").RegexMatch("^labels\\..+$")).
 		Execute(ctx, func(ctx context.Context, ar arrow.Record) error {
@@ -116,6 +132,42 @@ func (q *Querier) Labels(
 	return vals, nil
 }
 
+// StacktraceIDs calls fn with the ID of every stacktrace referenced by the
+// profiles and rollups of the tenant of the context.
+func (q *Querier) StacktraceIDs(ctx context.Context, fn func(id string)) error {
+	if err := q.stacktraceIDs(ctx, q.engine.ScanTable(q.table(ctx)), fn); err != nil {
+		return err
+	}
+	if q.rollups == nil {
+		return nil
+	}
+	// The rows recording the state of the rollups don't reference a
+	// stacktrace.
+	return q.stacktraceIDs(ctx, q.engine.ScanTable(q.rollups.table(tenant.FromContext(ctx))).
+		Filter(logicalplan.Col(ColumnName).NotEq(logicalplan.Literal(rollupStateName))), fn)
+}
+
+func (q *Querier) stacktraceIDs(ctx context.Context, scan query.Builder, fn func(id string)) error {
+	return scan.
+		Distinct(logicalplan.Col(ColumnStacktrace)).
+		Execute(ctx, func(ctx context.Context, ar arrow.Record) error {
+			if ar.NumCols() != 1 {
+				return fmt.Errorf("expected 1 column, got %d", ar.NumCols())
+			}
+
+			col, ok := ar.Column(0).(*array.Binary)
+			if !ok {
+				return fmt.Errorf("expected binary column, got %T", ar.Column(0))
+			}
+
+			for i := 0; i < col.Len(); i++ {
+				fn(string(col.Value(i)))
+			}
+
+			return nil
+		})
+}
+
 func (q *Querier) Values(
 	ctx context.Context,
 	labelName string,
@@ -124,7 +176,7 @@ func (q *Querier) Values(
 ) ([]string, error) {
 	vals := []string{}
 
-	err := q.engine.ScanTable(q.tableName).
+	err := q.engine.ScanTable(q.table(ctx)).
 		Distinct(logicalplan.Col("labels."+labelName)).
 		Execute(ctx, func(ctx context.Context, ar arrow.Record) error {
 			if ar.NumCols() != 1 {
@@ -183,7 +235,115 @@ func MatchersToBooleanExpressions(matchers []*labels.Matcher) ([]logicalplan.Exp
 	return exprs, nil
 }
 
+// numLabelFilterRegexp matches filters of numeric pprof labels, for example
+// bytes > 1MiB.
+var numLabelFilterRegexp = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\s*(>=|<=|==|!=|=|>|<)\s*(\S.*)$`)
+
+// SplitQuery splits a query into its selector and the numeric pprof label
+// filters following it, separated by pipes, for example
+// memory:alloc_space:bytes:space:bytes{job="api"} | bytes > 1MiB.
+func SplitQuery(query string) (string, []string, error) {
+	end, err := selectorEnd(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	selector, rest := query[:end], strings.TrimSpace(query[end:])
+	if rest == "" {
+		return selector, nil, nil
+	}
+	if !strings.HasPrefix(rest, "|") {
+		return "", nil, fmt.Errorf("unexpected %q after selector", rest)
+	}
+
+	filters := strings.Split(rest[1:], "|")
+	for i, f := range filters {
+		filters[i] = strings.TrimSpace(f)
+	}
+	return selector, filters, nil
+}
+
+// selectorEnd returns the index of the end of the selector at the start of
+// the query, which is either after the closing brace of its label matchers or
+// at the first pipe. Quoted label values are skipped, so they may contain
+// braces and pipes.
+func selectorEnd(query string) (int, error) {
+	var (
+		quote   rune
+		escaped bool
+	)
+	for i, r := range query {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && r == '\\' && quote != '`':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '}':
+			return i + 1, nil
+		case r == '|':
+			return i, nil
+		}
+	}
+	if quote != 0 {
+		return 0, errors.New("unterminated quoted string")
+	}
+	return len(query), nil
+}
+
+// NumLabelFilterToBooleanExpression converts a filter of a numeric pprof
+// label to an expression. Quantities with a unit only match values stored
+// with the same unit, after both were converted to the canonical unit of
+// their dimension.
+func NumLabelFilterToBooleanExpression(filter string) (logicalplan.Expr, error) {
+	m := numLabelFilterRegexp.FindStringSubmatch(filter)
+	if m == nil {
+		return nil, fmt.Errorf("invalid filter %q, must be of the form <label> <op> <quantity>", filter)
+	}
+	name, op := m[1], m[2]
+
+	value, unit, err := profile.ParseQuantity(m[3])
+	if err != nil {
+		return nil, err
+	}
+
+	ref := logicalplan.Col(ColumnPprofNumLabels + "." + name)
+	var expr logicalplan.Expr
+	switch op {
+	case ">":
+		expr = ref.Gt(logicalplan.Literal(value))
+	case ">=":
+		expr = ref.GtEq(logicalplan.Literal(value))
+	case "<":
+		expr = ref.Lt(logicalplan.Literal(value))
+	case "<=":
+		expr = ref.LtEq(logicalplan.Literal(value))
+	case "=", "==":
+		expr = ref.Eq(logicalplan.Literal(value))
+	case "!=":
+		expr = ref.NotEq(logicalplan.Literal(value))
+	}
+
+	if unit == "" {
+		return expr, nil
+	}
+	return logicalplan.And(
+		expr,
+		logicalplan.Col(ColumnPprofNumUnits+"."+name).Eq(logicalplan.Literal(unit)),
+	), nil
+}
+
 func QueryToFilterExprs(query string) (profile.Meta, []logicalplan.Expr, error) {
+	query, numLabelFilters, err := SplitQuery(query)
+	if err != nil {
+		return profile.Meta{}, nil, status.Errorf(codes.InvalidArgument, "failed to parse query: %v", err)
+	}
+
 	parsedSelector, err := parser.ParseMetricSelector(query)
 	if err != nil {
 		return profile.Meta{}, nil, status.Error(codes.InvalidArgument, "failed to parse query")
@@ -224,6 +384,14 @@ func QueryToFilterExprs(query string) (profile.Meta, []logicalplan.Expr, error)
 		logicalplan.Col("period_unit").Eq(logicalplan.Literal(periodUnit)),
 	}, labelFilterExpressions...)
 
+	for _, f := range numLabelFilters {
+		expr, err := NumLabelFilterToBooleanExpression(f)
+		if err != nil {
+			return profile.Meta{}, nil, status.Errorf(codes.InvalidArgument, "failed to parse query: %v", err)
+		}
+		exprs = append(exprs, expr)
+	}
+
 	deltaPlan := logicalplan.Col("duration").Eq(logicalplan.Literal(0))
 	if delta {
 		deltaPlan = logicalplan.Col("duration").NotEq(logicalplan.Literal(0))
@@ -238,12 +406,19 @@ func QueryToFilterExprs(query string) (profile.Meta, []logicalplan.Expr, error)
 	}, exprs, nil
 }
 
+// QueryRange returns the sums of the values of the profiles matching the
+// query per series and timestamp. If step is not zero the values are summed
+// in time buckets of its width instead, which are read from the rollups if
+// the step is a multiple of their resolution.
 func (q *Querier) QueryRange(
 	ctx context.Context,
 	query string,
 	startTime, endTime time.Time,
+	step time.Duration,
 	limit uint32,
 ) ([]*pb.MetricsSeries, error) {
+	defer QueryStatsFromContext(ctx).ObserveSelect(time.Now())
+
 	_, selectorExprs, err := QueryToFilterExprs(query)
 	if err != nil {
 		return nil, err
@@ -252,21 +427,137 @@ func (q *Querier) QueryRange(
 	start := timestamp.FromTime(startTime)
 	end := timestamp.FromTime(endTime)
 
-	exprs := append(
-		selectorExprs,
-		logicalplan.Col("timestamp").Gt(logicalplan.Literal(start)),
-		logicalplan.Col("timestamp").Lt(logicalplan.Literal(end)),
-	)
+	timeExpr := timeRangeExpr(start, end)
+	var rollupExpr logicalplan.Expr
+	if step > 0 {
+		timeExpr, rollupExpr = q.timeFilters(ctx, start, end, step)
+	}
+	filterExpr := logicalplan.And(append(selectorExprs[:len(selectorExprs):len(selectorExprs)], timeExpr)...)
+
+	records := make([]arrow.Record, 0, 2)
+	defer func() {
+		for _, ar := range records {
+			ar.Release()
+		}
+	}()
+
+	ar, err := q.sumPerSeries(ctx, q.table(ctx), filterExpr)
+	if err != nil {
+		return nil, err
+	}
+	if ar != nil {
+		records = append(records, ar)
+	}
+	if rollupExpr != nil {
+		ar, err := q.sumPerSeries(ctx, q.rollups.table(tenant.FromContext(ctx)), logicalplan.And(append(selectorExprs[:len(selectorExprs):len(selectorExprs)], rollupExpr)...))
+		if err != nil {
+			return nil, fmt.Errorf("read rollups: %w", err)
+		}
+		if ar != nil {
+			records = append(records, ar)
+		}
+	}
 
-	filterExpr := logicalplan.And(exprs...)
+	rows := int64(0)
+	for _, ar := range records {
+		rows += ar.NumRows()
+	}
+	if rows == 0 {
+		return nil, status.Error(
+			codes.NotFound,
+			"No data found for the query, try a different query or time range or no data has been written to be queried yet.",
+		)
+	}
 
 	resSeries := []*pb.MetricsSeries{}
 	labelsetToIndex := map[string]int{}
 
 	labelSet := labels.Labels{}
 
+	for _, ar := range records {
+		timestampColumnIndex := 0
+		timestampColumnFound := false
+		valueColumnIndex := 0
+		valueColumnFound := false
+		labelColumnIndices := []int{}
+
+		fields := ar.Schema().Fields()
+		for i, field := range fields {
+			if field.Name == "timestamp" {
+				timestampColumnIndex = i
+				timestampColumnFound = true
+				continue
+			}
+			if field.Name == "sum(value)" {
+				valueColumnIndex = i
+				valueColumnFound = true
+				continue
+			}
+
+			if strings.HasPrefix(field.Name, "labels.") {
+				labelColumnIndices = append(labelColumnIndices, i)
+			}
+		}
+
+		if ar.NumRows() == 0 {
+			continue
+		}
+
+		if !timestampColumnFound {
+			return nil, ErrTimestampColumnNotFound
+		}
+
+		if !valueColumnFound {
+			return nil, ErrValueColumnNotFound
+		}
+
+		for i := 0; i < int(ar.NumRows()); i++ {
+			labelSet = rowLabelSet(ar, labelColumnIndices, i, labelSet[:0])
+			s := labelSet.String()
+			index, ok := labelsetToIndex[s]
+			if !ok {
+				pbLabelSet := make([]*profilestorepb.Label, 0, len(labelSet))
+				for _, l := range labelSet {
+					pbLabelSet = append(pbLabelSet, &profilestorepb.Label{
+						Name:  l.Name,
+						Value: l.Value,
+					})
+				}
+				resSeries = append(resSeries, &pb.MetricsSeries{Labelset: &profilestorepb.LabelSet{Labels: pbLabelSet}})
+				index = len(resSeries) - 1
+				labelsetToIndex[s] = index
+			}
+
+			series := resSeries[index]
+			series.Samples = append(series.Samples, &pb.MetricsSample{
+				Timestamp: timestamppb.New(timestamp.Time(stepTimestamp(ar.Column(timestampColumnIndex).(*array.Int64).Value(i), step))),
+				Value:     ar.Column(valueColumnIndex).(*array.Int64).Value(i),
+			})
+		}
+	}
+
+	// This is horrible and should be fixed. The data is sorted in the storage, we should not have to sort it here.
+	for _, series := range resSeries {
+		sort.Slice(series.Samples, func(i, j int) bool {
+			return series.Samples[i].Timestamp.AsTime().Before(series.Samples[j].Timestamp.AsTime())
+		})
+		if step > 0 {
+			series.Samples = sumSamples(series.Samples)
+		}
+	}
+
+	if err := q.addExemplars(ctx, filterExpr, step, resSeries, labelsetToIndex); err != nil {
+		return nil, fmt.Errorf("add exemplars: %w", err)
+	}
+
+	return resSeries, nil
+}
+
+// sumPerSeries sums the values of the profiles of the table matching the
+// filter per series and timestamp.
+func (q *Querier) sumPerSeries(ctx context.Context, table string, filterExpr logicalplan.Expr) (arrow.Record, error) {
 	var ar arrow.Record
-	err = q.engine.ScanTable(q.tableName).
+	err := q.engine.ScanTable(table).
 		Filter(filterExpr).
 		Aggregate(
 			logicalplan.Sum(logicalplan.Col("value")),
@@ -281,90 +572,242 @@ func (q *Querier) QueryRange(
 	if err != nil {
 		return nil, err
 	}
-	if ar == nil || ar.NumRows() == 0 {
-		return nil, status.Error(
-			codes.NotFound,
-			"No data found for the query, try a different query or time range or no data has been written to be queried yet.",
-		)
+	return ar, nil
+}
+
+// stepTimestamp returns the start of the time bucket of the step the
+// timestamp is in, or the timestamp itself if step is zero.
+func stepTimestamp(ts int64, step time.Duration) int64 {
+	if step <= 0 {
+		return ts
+	}
+	return alignDown(ts, step.Milliseconds())
+}
+
+// sumSamples merges the consecutive samples with the same timestamp, summing
+// their values.
+func sumSamples(samples []*pb.MetricsSample) []*pb.MetricsSample {
+	res := samples[:0]
+	for _, s := range samples {
+		if len(res) > 0 && res[len(res)-1].Timestamp.AsTime().Equal(s.Timestamp.AsTime()) {
+			res[len(res)-1].Value += s.Value
+			continue
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// timeRangeExpr returns the expression matching the timestamps in (start, end).
+func timeRangeExpr(start, end int64) logicalplan.Expr {
+	return logicalplan.And(
+		logicalplan.Col("timestamp").Gt(logicalplan.Literal(start)),
+		logicalplan.Col("timestamp").Lt(logicalplan.Literal(end)),
+	)
+}
+
+// timeFilters returns the expressions matching the timestamps in (start, end)
+// of the profiles and of the rollups of the tenant of the context a query
+// reads. The expression of the rollups is nil if they are not read.
+func (q *Querier) timeFilters(ctx context.Context, start, end int64, step time.Duration) (logicalplan.Expr, logicalplan.Expr) {
+	if q.rollups == nil {
+		return timeRangeExpr(start, end), nil
+	}
+	from, to, ok := q.rollups.split(tenant.FromContext(ctx), start, end, step)
+	if !ok {
+		return timeRangeExpr(start, end), nil
 	}
 
-	timestampColumnIndex := 0
-	timestampColumnFound := false
-	valueColumnIndex := 0
-	valueColumnFound := false
-	labelColumnIndices := []int{}
+	return logicalplan.Or(
+			logicalplan.And(
+				logicalplan.Col("timestamp").Gt(logicalplan.Literal(start)),
+				logicalplan.Col("timestamp").Lt(logicalplan.Literal(from)),
+			),
+			logicalplan.And(
+				logicalplan.Col("timestamp").GtEq(logicalplan.Literal(to)),
+				logicalplan.Col("timestamp").Lt(logicalplan.Literal(end)),
+			),
+		),
+		logicalplan.And(
+			logicalplan.Col("timestamp").GtEq(logicalplan.Literal(from)),
+			logicalplan.Col("timestamp").Lt(logicalplan.Literal(to)),
+		)
+}
 
+// rowLabelSet appends the labels of a row to the label-set and returns it
+// sorted.
+func rowLabelSet(ar arrow.Record, labelColumnIndices []int, i int, labelSet labels.Labels) labels.Labels {
 	fields := ar.Schema().Fields()
-	for i, field := range fields {
-		if field.Name == "timestamp" {
-			timestampColumnIndex = i
-			timestampColumnFound = true
+	for _, labelColumnIndex := range labelColumnIndices {
+		col := ar.Column(labelColumnIndex).(*array.Binary)
+		if col.IsNull(i) {
 			continue
 		}
-		if field.Name == "sum(value)" {
-			valueColumnIndex = i
-			valueColumnFound = true
-			continue
+
+		v := col.Value(i)
+		if len(v) > 0 {
+			labelSet = append(labelSet, labels.Label{Name: strings.TrimPrefix(fields[labelColumnIndex].Name, "labels."), Value: string(v)})
 		}
+	}
 
-		if strings.HasPrefix(field.Name, "labels.") {
+	sort.Sort(labelSet)
+	return labelSet
+}
+
+// maxExemplarsPerSample is the maximum number of exemplars of a sample of a
+// range query.
+const maxExemplarsPerSample = 5
+
+// exemplarKey identifies a sample of the series of a range query.
+type exemplarKey struct {
+	series    int
+	timestamp int64
+}
+
+// addExemplars adds the spans in which the largest parts of the values of the
+// samples of the series were recorded to the samples as exemplars. The spans
+// are read by an aggregation of their own, which only groups the samples
+// recorded in a span, so the cardinality of the traces doesn't add to the cost
+// of summing the values of the series.
+func (q *Querier) addExemplars(
+	ctx context.Context,
+	filterExpr logicalplan.Expr,
+	step time.Duration,
+	series []*pb.MetricsSeries,
+	labelsetToIndex map[string]int,
+) error {
+	var ar arrow.Record
+	err := q.engine.ScanTable(q.table(ctx)).
+		Filter(logicalplan.And(
+			filterExpr,
+			logicalplan.Col(ColumnTraceID).NotEq(logicalplan.Literal("")),
+		)).
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col(ColumnValue)),
+			logicalplan.DynCol(ColumnLabels),
+			logicalplan.Col(ColumnTimestamp),
+			logicalplan.Col(ColumnTraceID),
+			logicalplan.Col(ColumnSpanID),
+		).
+		Execute(ctx, func(ctx context.Context, r arrow.Record) error {
+			r.Retain()
+			ar = r
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	if ar == nil {
+		return nil
+	}
+	defer ar.Release()
+
+	var (
+		timestamps         *array.Int64
+		values             *array.Int64
+		traceIDs           *array.Binary
+		spanIDs            *array.Binary
+		labelColumnIndices []int
+	)
+	for i, field := range ar.Schema().Fields() {
+		switch {
+		case field.Name == ColumnTimestamp:
+			timestamps = ar.Column(i).(*array.Int64)
+		case field.Name == "sum(value)":
+			values = ar.Column(i).(*array.Int64)
+		case field.Name == ColumnTraceID:
+			traceIDs = ar.Column(i).(*array.Binary)
+		case field.Name == ColumnSpanID:
+			spanIDs = ar.Column(i).(*array.Binary)
+		case strings.HasPrefix(field.Name, ColumnLabels+"."):
 			labelColumnIndices = append(labelColumnIndices, i)
 		}
 	}
+	if timestamps == nil || values == nil || traceIDs == nil {
+		// No sample matching the query was recorded in a span.
+		return nil
+	}
+
+	exemplars := map[exemplarKey][]*pb.Exemplar{}
+	labelSet := labels.Labels{}
+	for i := 0; i < int(ar.NumRows()); i++ {
+		if traceIDs.IsNull(i) || traceIDs.ValueLen(i) == 0 {
+			continue
+		}
 
-	if !timestampColumnFound {
-		return nil, ErrTimestampColumnNotFound
+		labelSet = rowLabelSet(ar, labelColumnIndices, i, labelSet[:0])
+		index, ok := labelsetToIndex[labelSet.String()]
+		if !ok {
+			continue
+		}
+
+		exemplar := &pb.Exemplar{
+			TraceId: traceIDs.ValueString(i),
+			Value:   values.Value(i),
+		}
+		if spanIDs != nil && !spanIDs.IsNull(i) {
+			exemplar.SpanId = spanIDs.ValueString(i)
+		}
+		key := exemplarKey{series: index, timestamp: stepTimestamp(timestamps.Value(i), step)}
+		exemplars[key] = append(exemplars[key], exemplar)
 	}
 
-	if !valueColumnFound {
-		return nil, ErrValueColumnNotFound
+	setExemplars(series, exemplars)
+	return nil
+}
+
+// setExemplars sets the exemplars of the samples of the series to the spans
+// with the largest values of the sample.
+func setExemplars(series []*pb.MetricsSeries, exemplars map[exemplarKey][]*pb.Exemplar) {
+	if len(exemplars) == 0 {
+		return
 	}
 
-	for i := 0; i < int(ar.NumRows()); i++ {
-		labelSet = labelSet[:0]
-		for _, labelColumnIndex := range labelColumnIndices {
-			col := ar.Column(labelColumnIndex).(*array.Binary)
-			if col.IsNull(i) {
+	for i, s := range series {
+		for _, sample := range s.Samples {
+			e := exemplars[exemplarKey{series: i, timestamp: timestamp.FromTime(sample.Timestamp.AsTime())}]
+			if len(e) == 0 {
 				continue
 			}
 
-			v := col.Value(i)
-			if len(v) > 0 {
-				labelSet = append(labelSet, labels.Label{Name: strings.TrimPrefix(fields[labelColumnIndex].Name, "labels."), Value: string(v)})
-			}
-		}
-
-		sort.Sort(labelSet)
-		s := labelSet.String()
-		index, ok := labelsetToIndex[s]
-		if !ok {
-			pbLabelSet := make([]*profilestorepb.Label, 0, len(labelSet))
-			for _, l := range labelSet {
-				pbLabelSet = append(pbLabelSet, &profilestorepb.Label{
-					Name:  l.Name,
-					Value: l.Value,
-				})
+			e = sumExemplars(e)
+			sort.Slice(e, func(i, j int) bool {
+				a, b := e[i], e[j]
+				if a.Value != b.Value {
+					return a.Value > b.Value
+				}
+				if a.TraceId != b.TraceId {
+					return a.TraceId < b.TraceId
+				}
+				return a.SpanId < b.SpanId
+			})
+			if len(e) > maxExemplarsPerSample {
+				e = e[:maxExemplarsPerSample]
 			}
-			resSeries = append(resSeries, &pb.MetricsSeries{Labelset: &profilestorepb.LabelSet{Labels: pbLabelSet}})
-			index = len(resSeries) - 1
-			labelsetToIndex[s] = index
+			sample.Exemplars = e
 		}
-
-		series := resSeries[index]
-		series.Samples = append(series.Samples, &pb.MetricsSample{
-			Timestamp: timestamppb.New(timestamp.Time(ar.Column(timestampColumnIndex).(*array.Int64).Value(i))),
-			Value:     ar.Column(valueColumnIndex).(*array.Int64).Value(i),
-		})
 	}
+}
 
-	// This is horrible and should be fixed. The data is sorted in the storage, we should not have to sort it here.
-	for _, series := range resSeries {
-		sort.Slice(series.Samples, func(i, j int) bool {
-			return series.Samples[i].Timestamp.AsTime().Before(series.Samples[j].Timestamp.AsTime())
-		})
+// sumExemplars merges the exemplars of the same span, which are recorded at
+// different timestamps of a time bucket, summing their values.
+func sumExemplars(exemplars []*pb.Exemplar) []*pb.Exemplar {
+	type spanKey struct {
+		traceID string
+		spanID  string
 	}
-
-	return resSeries, nil
+	seen := make(map[spanKey]*pb.Exemplar, len(exemplars))
+	res := exemplars[:0]
+	for _, e := range exemplars {
+		k := spanKey{traceID: e.TraceId, spanID: e.SpanId}
+		if prev, ok := seen[k]; ok {
+			prev.Value += e.Value
+			continue
+		}
+		seen[k] = e
+		res = append(res, e)
+	}
+	return res
 }
 
 func (q *Querier) ProfileTypes(
@@ -373,7 +816,7 @@ func (q *Querier) ProfileTypes(
 	seen := map[string]struct{}{}
 	res := []*pb.ProfileType{}
 
-	err := q.engine.ScanTable(q.tableName).
+	err := q.engine.ScanTable(q.table(ctx)).
 		Distinct(
 			logicalplan.Col(ColumnName),
 			logicalplan.Col(ColumnSampleType),
@@ -540,6 +983,7 @@ func (q *Querier) findSingle(ctx context.Context, query string, t time.Time) (ar
 	span.SetAttributes(attribute.String("query", query))
 	span.SetAttributes(attribute.Int64("time", t.Unix()))
 	defer span.End()
+	defer QueryStatsFromContext(ctx).ObserveSelect(time.Now())
 
 	meta, selectorExprs, err := QueryToFilterExprs(query)
 	if err != nil {
@@ -554,13 +998,14 @@ func (q *Querier) findSingle(ctx context.Context, query string, t time.Time) (ar
 	)
 
 	var ar arrow.Record
-	err = q.engine.ScanTable(q.tableName).
+	err = q.engine.ScanTable(q.table(ctx)).
 		Filter(filterExpr).
 		Aggregate(
 			logicalplan.Sum(logicalplan.Col("value")),
 			logicalplan.Col("stacktrace"),
-			logicalplan.DynCol("pprof_labels"),
-			logicalplan.DynCol("pprof_num_labels"),
+			logicalplan.DynCol(ColumnPprofLabels),
+			logicalplan.DynCol(ColumnPprofNumLabels),
+			logicalplan.DynCol(ColumnPprofNumUnits),
 		).
 		Execute(ctx, func(ctx context.Context, r arrow.Record) error {
 			r.Retain()
@@ -605,9 +1050,40 @@ func (q *Querier) QueryMerge(ctx context.Context, query string, start, end time.
 	return p, nil
 }
 
-func (q *Querier) selectMerge(ctx context.Context, query string, startTime, endTime time.Time) (arrow.Record, string, profile.Meta, error) {
+// QueryTrace merges the samples matching the query that were recorded in the
+// trace or span. Either of the hex encoded IDs may be empty.
+func (q *Querier) QueryTrace(ctx context.Context, query string, start, end time.Time, traceID, spanID string) (*profile.Profile, error) {
+	ctx, span := q.tracer.Start(ctx, "Querier/QueryTrace")
+	defer span.End()
+
+	var filters []logicalplan.Expr
+	if traceID != "" {
+		filters = append(filters, logicalplan.Col(ColumnTraceID).Eq(logicalplan.Literal(strings.ToLower(traceID))))
+	}
+	if spanID != "" {
+		filters = append(filters, logicalplan.Col(ColumnSpanID).Eq(logicalplan.Literal(strings.ToLower(spanID))))
+	}
+
+	r, valueColumn, meta, err := q.selectMerge(ctx, query, start, end, filters...)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Release()
+
+	return q.arrowRecordToProfile(
+		ctx,
+		r,
+		valueColumn,
+		meta,
+	)
+}
+
+// selectMerge aggregates the values of the samples matching the query and
+// the additional filters per stacktrace.
+func (q *Querier) selectMerge(ctx context.Context, query string, startTime, endTime time.Time, filters ...logicalplan.Expr) (arrow.Record, string, profile.Meta, error) {
 	ctx, span := q.tracer.Start(ctx, "Querier/selectMerge")
 	defer span.End()
+	defer QueryStatsFromContext(ctx).ObserveSelect(time.Now())
 
 	meta, selectorExprs, err := QueryToFilterExprs(query)
 	if err != nil {
@@ -617,16 +1093,56 @@ func (q *Querier) selectMerge(ctx context.Context, query string, startTime, endT
 	start := timestamp.FromTime(startTime)
 	end := timestamp.FromTime(endTime)
 
-	filterExpr := logicalplan.And(
-		append(
-			selectorExprs,
-			logicalplan.Col("timestamp").Gt(logicalplan.Literal(start)),
-			logicalplan.Col("timestamp").Lt(logicalplan.Literal(end)),
-		)...,
-	)
+	timeExpr := timeRangeExpr(start, end)
+	var rollupExpr logicalplan.Expr
+	// The rollups lack the traces the additional filters select.
+	if len(filters) == 0 {
+		timeExpr, rollupExpr = q.timeFilters(ctx, start, end, 0)
+	}
 
+	exprs := make([]logicalplan.Expr, 0, len(selectorExprs)+1+len(filters))
+	exprs = append(exprs, selectorExprs...)
+	exprs = append(exprs, timeExpr)
+	exprs = append(exprs, filters...)
+
+	ar, err := q.sumPerStacktrace(ctx, q.table(ctx), logicalplan.And(exprs...))
+	if err != nil {
+		return nil, "", profile.Meta{}, err
+	}
+
+	if rollupExpr != nil {
+		rolledUp, err := q.sumPerStacktrace(
+			ctx,
+			q.rollups.table(tenant.FromContext(ctx)),
+			logicalplan.And(append(selectorExprs[:len(selectorExprs):len(selectorExprs)], rollupExpr)...),
+		)
+		if err != nil {
+			if ar != nil {
+				ar.Release()
+			}
+			return nil, "", profile.Meta{}, fmt.Errorf("read rollups: %w", err)
+		}
+
+		ar, err = mergeStacktraceRecords(ar, rolledUp)
+		if err != nil {
+			return nil, "", profile.Meta{}, fmt.Errorf("merge rollups: %w", err)
+		}
+	}
+
+	meta = profile.Meta{
+		Name:       meta.Name,
+		SampleType: meta.SampleType,
+		PeriodType: meta.PeriodType,
+		Timestamp:  start,
+	}
+	return ar, "sum(value)", meta, nil
+}
+
+// sumPerStacktrace sums the values of the samples of the table matching the
+// filter per stacktrace.
+func (q *Querier) sumPerStacktrace(ctx context.Context, table string, filterExpr logicalplan.Expr) (arrow.Record, error) {
 	var ar arrow.Record
-	err = q.engine.ScanTable(q.tableName).
+	err := q.engine.ScanTable(table).
 		Filter(filterExpr).
 		Aggregate(
 			logicalplan.Sum(logicalplan.Col("value")),
@@ -638,14 +1154,69 @@ func (q *Querier) selectMerge(ctx context.Context, query string, startTime, endT
 			return nil
 		})
 	if err != nil {
-		return nil, "", profile.Meta{}, err
+		return nil, err
 	}
+	return ar, nil
+}
 
-	meta = profile.Meta{
-		Name:       meta.Name,
-		SampleType: meta.SampleType,
-		PeriodType: meta.PeriodType,
-		Timestamp:  start,
+// mergeStacktraceRecords merges two records of values summed per stacktrace
+// into one, releasing them.
+func mergeStacktraceRecords(a, b arrow.Record) (arrow.Record, error) {
+	if b == nil || b.NumRows() == 0 {
+		if b != nil {
+			b.Release()
+		}
+		return a, nil
 	}
-	return ar, "sum(value)", meta, nil
+	if a == nil || a.NumRows() == 0 {
+		if a != nil {
+			a.Release()
+		}
+		return b, nil
+	}
+	defer a.Release()
+	defer b.Release()
+
+	values := map[string]int64{}
+	stacktraces := []string{}
+	for _, ar := range []arrow.Record{a, b} {
+		st, err := BinaryFieldFromRecord(ar, "stacktrace")
+		if err != nil {
+			return nil, err
+		}
+		indices := ar.Schema().FieldIndices("sum(value)")
+		if len(indices) != 1 {
+			return nil, ErrValueColumnNotFound
+		}
+		v, ok := ar.Column(indices[0]).(*array.Int64)
+		if !ok {
+			return nil, fmt.Errorf("expected column %q to be an int64 column, got %T", "sum(value)", ar.Column(indices[0]))
+		}
+
+		for i := 0; i < int(ar.NumRows()); i++ {
+			id := st.ValueString(i)
+			if _, ok := values[id]; !ok {
+				stacktraces = append(stacktraces, id)
+			}
+			values[id] += v.Value(i)
+		}
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "stacktrace", Type: arrow.BinaryTypes.Binary},
+			{Name: "sum(value)", Type: arrow.PrimitiveTypes.Int64},
+		},
+		nil,
+	))
+	defer builder.Release()
+
+	stacktraceBuilder := builder.Field(0).(*array.BinaryBuilder)
+	valueBuilder := builder.Field(1).(*array.Int64Builder)
+	for _, id := range stacktraces {
+		stacktraceBuilder.AppendString(id)
+		valueBuilder.Append(values[id])
+	}
+
+	return builder.NewRecord(), nil
 }
@@ -0,0 +1,59 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		query    string
+		selector string
+		filters  []string
+	}{{
+		query:    `memory:alloc_space:bytes:space:bytes{job="api"}`,
+		selector: `memory:alloc_space:bytes:space:bytes{job="api"}`,
+	}, {
+		query:    `memory:alloc_space:bytes:space:bytes | bytes > 1MiB`,
+		selector: `memory:alloc_space:bytes:space:bytes `,
+		filters:  []string{"bytes > 1MiB"},
+	}, {
+		query:    `memory:alloc_space:bytes:space:bytes{job="a}|b", path=~'{x}'} | bytes > 1MiB | size < 3 }`,
+		selector: `memory:alloc_space:bytes:space:bytes{job="a}|b", path=~'{x}'}`,
+		filters:  []string{"bytes > 1MiB", "size < 3 }"},
+	}, {
+		query:    `memory:alloc_space:bytes:space:bytes{job="a\"}"}|n>1`,
+		selector: `memory:alloc_space:bytes:space:bytes{job="a\"}"}`,
+		filters:  []string{"n>1"},
+	}}
+	for _, c := range cases {
+		selector, filters, err := SplitQuery(c.query)
+		require.NoError(t, err, c.query)
+		require.Equal(t, c.selector, selector, c.query)
+		require.Equal(t, c.filters, filters, c.query)
+	}
+
+	for _, query := range []string{
+		`memory:alloc_space:bytes:space:bytes{job="api} | bytes > 1`,
+		`memory:alloc_space:bytes:space:bytes{job="api"} bytes > 1`,
+	} {
+		_, _, err := SplitQuery(query)
+		require.Error(t, err, query)
+	}
+}
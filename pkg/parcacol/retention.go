@@ -0,0 +1,657 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/oklog/ulid"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/segmentio/parquet-go"
+	"github.com/thanos-io/objstore"
+
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+// blockFile is the name of the file holding the data of a persisted block,
+// blocks are stored as <table>/<ULID>/data.parquet.
+const blockFile = "data.parquet"
+
+// Retention deletes the profiles that are older than their maximum age from
+// the blocks the tables persisted to the bucket. Blocks that only hold
+// expired profiles are deleted, blocks that hold some are rewritten without
+// them. Profiles that have not been persisted yet are not deleted, they are
+// once their block has been persisted.
+//
+// A rewritten block is uploaded next to the original block, which it replaces
+// once the original block is deleted. Queries must read the blocks through a
+// bucket returned by NewBlocksBucket, which hides the rewritten block until
+// then, so that they read either of the blocks but never both.
+type Retention struct {
+	logger log.Logger
+	tables []*Tables
+	bucket objstore.Bucket
+
+	mtx   sync.Mutex
+	cfg   *config.Retention
+	rules []retentionRule
+
+	reclaimed *prometheus.CounterVec
+	deleted   *prometheus.CounterVec
+	rewritten *prometheus.CounterVec
+	samples   *prometheus.CounterVec
+	failures  *prometheus.CounterVec
+	lastRun   prometheus.Gauge
+	now       func() time.Time
+}
+
+type retentionRule struct {
+	matchers []*labels.Matcher
+	maxAge   time.Duration
+}
+
+// NewRetention returns a disabled Retention for the blocks of the tables in
+// bucket. The bucket may be nil if blocks are not persisted.
+func NewRetention(logger log.Logger, reg prometheus.Registerer, bucket objstore.Bucket, tables ...*Tables) *Retention {
+	return &Retention{
+		logger: logger,
+		tables: tables,
+		bucket: bucket,
+		reclaimed: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "retention_reclaimed_bytes_total",
+				Help: "Total number of bytes of persisted blocks reclaimed by deleting expired profiles, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		deleted: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "retention_deleted_blocks_total",
+				Help: "Total number of persisted blocks deleted because all their profiles expired, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		rewritten: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "retention_rewritten_blocks_total",
+				Help: "Total number of persisted blocks rewritten without their expired profiles, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		samples: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "retention_deleted_samples_total",
+				Help: "Total number of expired samples deleted, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		failures: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "retention_failures_total",
+				Help: "Total number of persisted blocks whose expired profiles failed to be deleted, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		lastRun: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Name: "retention_last_run_timestamp_seconds",
+				Help: "Time the expired profiles were last deleted.",
+			},
+		),
+		now: time.Now,
+	}
+}
+
+// ApplyConfig replaces the configuration of the retention, a nil
+// configuration keeps all profiles. A configuration that deletes profiles is
+// rejected if blocks are not persisted.
+func (r *Retention) ApplyConfig(cfg *config.Retention) error {
+	var rules []retentionRule
+	if cfg != nil {
+		deletes := cfg.MaxAge != 0
+		for _, rule := range cfg.Rules {
+			matchers, err := rule.Matchers()
+			if err != nil {
+				return fmt.Errorf("retention: %w", err)
+			}
+			rules = append(rules, retentionRule{
+				matchers: matchers,
+				maxAge:   time.Duration(rule.MaxAge),
+			})
+			deletes = deletes || rule.MaxAge != 0
+		}
+		if deletes && r.bucket == nil {
+			return errors.New("retention: profiles are only deleted from persisted blocks, persistence must be enabled")
+		}
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.cfg = cfg
+	r.rules = rules
+	return nil
+}
+
+func (r *Retention) interval() time.Duration {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.cfg == nil {
+		return time.Duration(config.DefaultRetentionInterval)
+	}
+	return time.Duration(r.cfg.Interval)
+}
+
+// Run deletes expired profiles at the configured interval until the context
+// is canceled.
+func (r *Retention) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval()):
+			if err := r.Enforce(ctx); err != nil {
+				level.Warn(r.logger).Log("msg", "failed to delete expired profiles", "err", err)
+			}
+		}
+	}
+}
+
+// retentionPolicy holds the cutoffs of a run of the retention, in
+// milliseconds since the epoch. Samples with a timestamp before their cutoff
+// are expired, a cutoff of math.MinInt64 keeps samples forever.
+type retentionPolicy struct {
+	cutoff int64
+	rules  []retentionRule
+	// cutoffs holds the cutoff of every rule.
+	cutoffs []int64
+	// min and max are the earliest and latest of all cutoffs.
+	min, max int64
+}
+
+func newRetentionPolicy(now time.Time, maxAge time.Duration, rules []retentionRule) *retentionPolicy {
+	cutoff := func(maxAge time.Duration) int64 {
+		if maxAge == 0 {
+			return math.MinInt64
+		}
+		return now.Add(-maxAge).UnixMilli()
+	}
+
+	p := &retentionPolicy{
+		cutoff: cutoff(maxAge),
+		rules:  rules,
+	}
+	p.min, p.max = p.cutoff, p.cutoff
+	for _, rule := range rules {
+		c := cutoff(rule.maxAge)
+		p.cutoffs = append(p.cutoffs, c)
+		if c < p.min {
+			p.min = c
+		}
+		if c > p.max {
+			p.max = c
+		}
+	}
+	return p
+}
+
+// expired returns whether the sample with the timestamp and labels is
+// expired.
+func (p *retentionPolicy) expired(timestamp int64, label func(name string) string) bool {
+	for i, rule := range p.rules {
+		if matches(rule.matchers, label) {
+			return timestamp < p.cutoffs[i]
+		}
+	}
+	return timestamp < p.cutoff
+}
+
+func matches(matchers []*labels.Matcher, label func(name string) string) bool {
+	for _, m := range matchers {
+		if !m.Matches(label(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Enforce deletes the expired profiles from all persisted blocks.
+func (r *Retention) Enforce(ctx context.Context) error {
+	r.mtx.Lock()
+	cfg, rules := r.cfg, r.rules
+	r.mtx.Unlock()
+
+	if r.bucket == nil || cfg == nil {
+		return nil
+	}
+
+	p := newRetentionPolicy(r.now(), time.Duration(cfg.MaxAge), rules)
+	if p.max == math.MinInt64 {
+		// All profiles are kept forever.
+		return nil
+	}
+
+	var tables []string
+	tenants := map[string]string{}
+	schemas := map[string]*dynparquet.Schema{}
+	if err := r.bucket.Iter(ctx, "", func(dir string) error {
+		name := strings.TrimSuffix(dir, objstore.DirDelim)
+		for _, t := range r.tables {
+			if id, ok := t.TenantOf(name); ok {
+				tables = append(tables, name)
+				tenants[name] = id
+				schemas[name] = t.Schema()
+				return nil
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("list tables: %w", err)
+	}
+
+	for _, table := range tables {
+		tenantID := tenants[table]
+
+		// Blocks are listed before any is rewritten, so rewritten blocks
+		// are not visited again.
+		var blocks []ulid.ULID
+		if err := r.bucket.Iter(ctx, table+objstore.DirDelim, func(dir string) error {
+			id, err := ulid.Parse(path.Base(dir))
+			if err != nil {
+				return nil
+			}
+			blocks = append(blocks, id)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("list blocks of table %q: %w", table, err)
+		}
+
+		listed := make(map[ulid.ULID]bool, len(blocks))
+		for _, id := range blocks {
+			listed[id] = true
+		}
+		hidden := hiddenBlocks(blocks)
+
+		for _, id := range blocks {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if hidden[id] {
+				// The block is the rewrite of a block a run failed to
+				// delete, the block is rewritten again, as the rewrite
+				// may be incomplete.
+				continue
+			}
+
+			if err := r.enforceBlock(ctx, tenantID, table, schemas[table], id, listed[rewrittenID(id)], p); err != nil {
+				r.failures.WithLabelValues(tenantID).Inc()
+				level.Warn(r.logger).Log("msg", "failed to delete expired profiles of block", "table", table, "block", id, "err", err)
+			}
+		}
+	}
+
+	r.lastRun.SetToCurrentTime()
+	return nil
+}
+
+// enforceBlock deletes the expired profiles of the block. If a run that
+// failed to delete the block already rewrote it, the rewrite is replaced, or
+// deleted if the block is no longer rewritten.
+func (r *Retention) enforceBlock(ctx context.Context, tenantID, table string, schema *dynparquet.Schema, id ulid.ULID, rewritten bool, p *retentionPolicy) error {
+	name := path.Join(table, id.String(), blockFile)
+	newName := path.Join(table, rewrittenID(id).String(), blockFile)
+	attrs, err := r.bucket.Attributes(ctx, name)
+	if err != nil {
+		return fmt.Errorf("get attributes: %w", err)
+	}
+
+	deleteRewrite := func() error {
+		if !rewritten {
+			return nil
+		}
+		if err := r.bucket.Delete(ctx, newName); err != nil {
+			return fmt.Errorf("delete previous rewrite: %w", err)
+		}
+		return nil
+	}
+
+	file, err := parquet.OpenFile(&bucketReaderAt{ctx: ctx, bucket: r.bucket, name: name}, attrs.Size)
+	if err != nil {
+		return fmt.Errorf("open block: %w", err)
+	}
+
+	expired := file.NumRows()
+	if min, max := timestampBounds(file); max >= p.min {
+		if min >= p.max {
+			// No sample is expired no matter which rule it matches.
+			return deleteRewrite()
+		}
+		if expired, err = countExpired(file, p); err != nil {
+			return fmt.Errorf("find expired samples: %w", err)
+		}
+		if expired == 0 {
+			return deleteRewrite()
+		}
+	}
+
+	if expired == file.NumRows() {
+		// The rewrite is deleted first, so that queries never read it.
+		if err := deleteRewrite(); err != nil {
+			return err
+		}
+		if err := r.bucket.Delete(ctx, name); err != nil {
+			return fmt.Errorf("delete block: %w", err)
+		}
+		r.deleted.WithLabelValues(tenantID).Inc()
+		r.samples.WithLabelValues(tenantID).Add(float64(expired))
+		r.reclaimed.WithLabelValues(tenantID).Add(float64(attrs.Size))
+		return nil
+	}
+
+	// The rewritten block is streamed to the bucket, replacing a previous
+	// rewrite. Queries don't read it until the original block is deleted,
+	// if deleting it fails the next run rewrites it again.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(rewriteBlock(pw, schema, file, p))
+	}()
+	if err := r.bucket.Upload(ctx, newName, pr); err != nil {
+		pr.CloseWithError(err)
+		return fmt.Errorf("upload rewritten block: %w", err)
+	}
+	newAttrs, err := r.bucket.Attributes(ctx, newName)
+	if err != nil {
+		return fmt.Errorf("get attributes of rewritten block: %w", err)
+	}
+
+	if err := r.bucket.Delete(ctx, name); err != nil {
+		return fmt.Errorf("delete rewritten block: %w", err)
+	}
+	r.rewritten.WithLabelValues(tenantID).Inc()
+	r.samples.WithLabelValues(tenantID).Add(float64(expired))
+	// A rewritten block may be larger than the original block if few of
+	// its samples expired, as it is encoded differently.
+	if reclaimed := attrs.Size - newAttrs.Size; reclaimed > 0 {
+		r.reclaimed.WithLabelValues(tenantID).Add(float64(reclaimed))
+	}
+	return nil
+}
+
+// hiddenBlocks returns the IDs of the blocks that are rewrites of other
+// blocks of the IDs, which are not read until the original block is deleted.
+func hiddenBlocks(ids []ulid.ULID) map[ulid.ULID]bool {
+	hidden := map[ulid.ULID]bool{}
+	for _, id := range ids {
+		hidden[rewrittenID(id)] = true
+	}
+	return hidden
+}
+
+// NewBlocksBucket returns the bucket that the database persists its blocks to
+// and reads them from. Blocks rewritten by the Retention are not listed until
+// the block they replace is deleted.
+func NewBlocksBucket(bucket objstore.Bucket) objstore.Bucket {
+	return &blocksBucket{Bucket: bucket}
+}
+
+type blocksBucket struct {
+	objstore.Bucket
+}
+
+// Iter implements the objstore.Bucket interface.
+func (b *blocksBucket) Iter(ctx context.Context, dir string, f func(string) error, options ...objstore.IterOption) error {
+	var (
+		names []string
+		ids   []ulid.ULID
+	)
+	if err := b.Bucket.Iter(ctx, dir, func(name string) error {
+		names = append(names, name)
+		if id, err := ulid.Parse(path.Base(name)); err == nil {
+			ids = append(ids, id)
+		}
+		return nil
+	}, options...); err != nil {
+		return err
+	}
+
+	hidden := hiddenBlocks(ids)
+	for _, name := range names {
+		if id, err := ulid.Parse(path.Base(name)); err == nil && hidden[id] {
+			continue
+		}
+		if err := f(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewrittenID returns the ID of the block the block with the ID is rewritten
+// to. It keeps the time of the original block, which frostdb relies on to
+// tell persisted blocks from blocks that are being persisted while it
+// queries, and derives the rest from the original ID.
+func rewrittenID(id ulid.ULID) ulid.ULID {
+	sum := sha256.Sum256(id[:])
+	newID := id
+	// The entropy of a ULID is 10 bytes, so this can't fail.
+	_ = newID.SetEntropy(sum[:10])
+	return newID
+}
+
+// timestampBounds returns the earliest and latest timestamp of the samples of
+// the block. If the block has no column index for the timestamps, the bounds
+// of all int64 are returned so that every sample is checked.
+func timestampBounds(file *parquet.File) (int64, int64) {
+	min, max := int64(math.MaxInt64), int64(math.MinInt64)
+
+	column := dynparquet.FindChildIndex(file.Schema().Fields(), ColumnTimestamp)
+	if column < 0 {
+		return math.MinInt64, math.MaxInt64
+	}
+
+	for _, rg := range file.RowGroups() {
+		index := rg.ColumnChunks()[column].ColumnIndex()
+		if index == nil || index.NumPages() == 0 {
+			return math.MinInt64, math.MaxInt64
+		}
+		for i := 0; i < index.NumPages(); i++ {
+			if v := index.MinValue(i).Int64(); v < min {
+				min = v
+			}
+			if v := index.MaxValue(i).Int64(); v > max {
+				max = v
+			}
+		}
+	}
+	return min, max
+}
+
+// countExpired returns the number of expired samples of the block. It only
+// reads the timestamps and the labels the rules select on.
+func countExpired(file *parquet.File, p *retentionPolicy) (int64, error) {
+	fields := file.Schema().Fields()
+	timestampColumn := dynparquet.FindChildIndex(fields, ColumnTimestamp)
+	if timestampColumn < 0 {
+		return 0, errors.New("block has no timestamp column")
+	}
+	labelColumns := map[string]int{}
+	for _, rule := range p.rules {
+		for _, m := range rule.matchers {
+			if i := dynparquet.FindChildIndex(fields, ColumnLabels+"."+m.Name); i >= 0 {
+				labelColumns[m.Name] = i
+			}
+		}
+	}
+
+	var expired int64
+	for _, rg := range file.RowGroups() {
+		chunks := rg.ColumnChunks()
+
+		var timestamps []int64
+		if err := readColumn(chunks[timestampColumn], func(v parquet.Value) {
+			timestamps = append(timestamps, v.Int64())
+		}); err != nil {
+			return 0, err
+		}
+
+		values := make(map[string][]string, len(labelColumns))
+		for name, i := range labelColumns {
+			var column []string
+			if err := readColumn(chunks[i], func(v parquet.Value) {
+				if v.IsNull() {
+					column = append(column, "")
+					return
+				}
+				column = append(column, string(v.ByteArray()))
+			}); err != nil {
+				return 0, err
+			}
+			values[name] = column
+		}
+
+		for row, timestamp := range timestamps {
+			label := func(name string) string {
+				column, ok := values[name]
+				if !ok {
+					return ""
+				}
+				return column[row]
+			}
+			if p.expired(timestamp, label) {
+				expired++
+			}
+		}
+	}
+	return expired, nil
+}
+
+// readColumn calls fn with every value of the column chunk.
+func readColumn(chunk parquet.ColumnChunk, fn func(parquet.Value)) error {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	values := make([]parquet.Value, 64)
+	for {
+		page, err := pages.ReadPage()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		reader := page.Values()
+		for {
+			n, err := reader.ReadValues(values)
+			for _, v := range values[:n] {
+				fn(v)
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// rewriteBlock writes the samples of the block that are not expired to w.
+func rewriteBlock(w io.Writer, schema *dynparquet.Schema, file *parquet.File, p *retentionPolicy) error {
+	serialized, err := dynparquet.NewSerializedBuffer(file)
+	if err != nil {
+		return err
+	}
+
+	fields := file.Schema().Fields()
+	timestampColumn := dynparquet.FindChildIndex(fields, ColumnTimestamp)
+	if timestampColumn < 0 {
+		return errors.New("block has no timestamp column")
+	}
+	// The schema is flat, so the index of a field is the index of its
+	// column.
+	labelColumns := map[string]int{}
+	for i, f := range fields {
+		if strings.HasPrefix(f.Name(), ColumnLabels+".") {
+			labelColumns[strings.TrimPrefix(f.Name(), ColumnLabels+".")] = i
+		}
+	}
+
+	writer, err := schema.NewWriter(w, serialized.DynamicColumns())
+	if err != nil {
+		return err
+	}
+
+	reader := serialized.Reader()
+	rows := make([]parquet.Row, 64)
+	for {
+		n, err := reader.ReadRows(rows)
+		for _, row := range rows[:n] {
+			label := func(name string) string {
+				i, ok := labelColumns[name]
+				if !ok || row[i].IsNull() {
+					return ""
+				}
+				return string(row[i].ByteArray())
+			}
+			if p.expired(row[timestampColumn].Int64(), label) {
+				continue
+			}
+			if _, err := writer.WriteRows([]parquet.Row{row}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// bucketReaderAt reads an object of a bucket with range requests, so that
+// only the parts of a block that are needed are downloaded.
+type bucketReaderAt struct {
+	ctx    context.Context
+	bucket objstore.Bucket
+	name   string
+}
+
+func (b *bucketReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	rc, err := b.bucket.GetRange(b.ctx, b.name, off, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	return io.ReadFull(rc, p)
+}
@@ -0,0 +1,248 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/oklog/ulid"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+func uploadBlock(t *testing.T, bucket objstore.Bucket, schema *dynparquet.Schema, table string, ls labels.Labels, timestamps ...int64) {
+	var buf *dynparquet.Buffer
+	for _, ts := range timestamps {
+		b, err := NormalizedProfileToParquetBuffer(schema, ls, &profile.NormalizedProfile{
+			Samples: []*profile.NormalizedSample{{StacktraceID: "stacktrace", Value: 1}},
+			Meta:    profile.Meta{Name: "memory", Timestamp: ts},
+		})
+		require.NoError(t, err)
+		if buf == nil {
+			buf = b
+			continue
+		}
+		_, err = buf.WriteRows(rowsOf(t, b))
+		require.NoError(t, err)
+	}
+
+	data, err := schema.SerializeBuffer(buf)
+	require.NoError(t, err)
+
+	id, err := ulid.New(ulid.Now(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, bucket.Upload(context.Background(), path.Join(table, id.String(), blockFile), bytes.NewReader(data)))
+}
+
+// retentionBucket is an in-memory bucket whose deletes can be made to fail.
+// It reads uploads before passing them on, as the in-memory bucket holds its
+// lock while reading an upload, while rewritten blocks are streamed from the
+// original block.
+type retentionBucket struct {
+	*objstore.InMemBucket
+	failDelete bool
+}
+
+func (b *retentionBucket) Upload(ctx context.Context, name string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return b.InMemBucket.Upload(ctx, name, bytes.NewReader(data))
+}
+
+func (b *retentionBucket) Delete(ctx context.Context, name string) error {
+	if b.failDelete {
+		return errors.New("delete failed")
+	}
+	return b.InMemBucket.Delete(ctx, name)
+}
+
+func rowsOf(t *testing.T, buf *dynparquet.Buffer) []parquet.Row {
+	var res []parquet.Row
+	rows := buf.Rows()
+	defer rows.Close()
+	for {
+		row := make([]parquet.Row, 1)
+		n, err := rows.ReadRows(row)
+		res = append(res, row[:n]...)
+		if err == io.EOF {
+			return res
+		}
+		require.NoError(t, err)
+	}
+}
+
+// blockTimestamps returns the sorted timestamps of the samples of every block
+// of the table.
+func blockTimestamps(t *testing.T, bucket objstore.Bucket, table string) [][]int64 {
+	ctx := context.Background()
+
+	var res [][]int64
+	require.NoError(t, bucket.Iter(ctx, table+"/", func(dir string) error {
+		r, err := bucket.Get(ctx, path.Join(dir, blockFile))
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		serialized, err := dynparquet.ReaderFromBytes(data)
+		require.NoError(t, err)
+		column := dynparquet.FindChildIndex(serialized.ParquetFile().Schema().Fields(), ColumnTimestamp)
+
+		var timestamps []int64
+		reader := serialized.Reader()
+		rows := make([]parquet.Row, 1)
+		for {
+			n, err := reader.ReadRows(rows)
+			for _, row := range rows[:n] {
+				timestamps = append(timestamps, row[column].Int64())
+			}
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+		res = append(res, timestamps)
+		return nil
+	}))
+	sort.Slice(res, func(i, j int) bool { return res[i][0] < res[j][0] })
+	return res
+}
+
+func TestRetention(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Schema()
+	require.NoError(t, err)
+
+	now := time.Unix(1_000_000, 0)
+	old := now.Add(-3 * time.Hour).UnixMilli()
+	recent := now.Add(-time.Minute).UnixMilli()
+
+	bucket := &retentionBucket{InMemBucket: objstore.NewInMemBucket()}
+	dev := labels.FromStrings("job", "dev")
+	prod := labels.FromStrings("job", "prod")
+	uploadBlock(t, bucket, schema, "stacktraces", dev, old, old+1)
+	uploadBlock(t, bucket, schema, "stacktraces", dev, old+2, recent)
+	uploadBlock(t, bucket, schema, "stacktraces", dev, recent+1)
+	uploadBlock(t, bucket, schema, "stacktraces", prod, old+3)
+	uploadBlock(t, bucket, schema, "stacktraces_team-a", dev, old+4, recent+2)
+	uploadBlock(t, bucket, schema, "rollups_team-a", dev, old, recent)
+
+	reg := prometheus.NewRegistry()
+	r := NewRetention(log.NewNopLogger(), reg, bucket, NewTables(nil, schema, "stacktraces", nil), NewTables(nil, schema, "rollups", nil))
+	r.now = func() time.Time { return now }
+
+	// Without a configuration all profiles are kept.
+	require.NoError(t, r.Enforce(context.Background()))
+	require.Equal(t, [][]int64{{old, old + 1}, {old + 2, recent}, {old + 3}, {recent + 1}}, blockTimestamps(t, bucket, "stacktraces"))
+
+	require.NoError(t, r.ApplyConfig(&config.Retention{
+		MaxAge:   model.Duration(time.Hour),
+		Interval: config.DefaultRetentionInterval,
+		Rules: []*config.RetentionRule{{
+			Selector: `{job="prod"}`,
+			MaxAge:   0,
+		}},
+	}))
+	require.NoError(t, r.Enforce(context.Background()))
+
+	require.Equal(t, [][]int64{{old + 3}, {recent}, {recent + 1}}, blockTimestamps(t, bucket, "stacktraces"))
+	require.Equal(t, [][]int64{{recent + 2}}, blockTimestamps(t, bucket, "stacktraces_team-a"))
+	require.Equal(t, [][]int64{{recent}}, blockTimestamps(t, bucket, "rollups_team-a"))
+
+	require.Equal(t, 1.0, testutil.ToFloat64(r.deleted.WithLabelValues(tenant.Default)))
+	require.Equal(t, 1.0, testutil.ToFloat64(r.rewritten.WithLabelValues(tenant.Default)))
+	require.Equal(t, 3.0, testutil.ToFloat64(r.samples.WithLabelValues(tenant.Default)))
+	require.Equal(t, 2.0, testutil.ToFloat64(r.rewritten.WithLabelValues("team-a")))
+	require.Equal(t, 2.0, testutil.ToFloat64(r.samples.WithLabelValues("team-a")))
+	require.Greater(t, testutil.ToFloat64(r.reclaimed.WithLabelValues(tenant.Default)), 0.0)
+	require.Equal(t, 0.0, testutil.ToFloat64(r.failures.WithLabelValues(tenant.Default)))
+}
+
+func TestRetentionFailedDelete(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Schema()
+	require.NoError(t, err)
+
+	now := time.Unix(1_000_000, 0)
+	old := now.Add(-3 * time.Hour).UnixMilli()
+	recent := now.Add(-time.Minute).UnixMilli()
+
+	bucket := &retentionBucket{InMemBucket: objstore.NewInMemBucket()}
+	uploadBlock(t, bucket, schema, "stacktraces", labels.FromStrings("job", "dev"), old, recent)
+
+	r := NewRetention(log.NewNopLogger(), prometheus.NewRegistry(), bucket, NewTables(nil, schema, "stacktraces", nil))
+	r.now = func() time.Time { return now }
+	require.NoError(t, r.ApplyConfig(&config.Retention{
+		MaxAge:   model.Duration(time.Hour),
+		Interval: config.DefaultRetentionInterval,
+	}))
+
+	// The original block stays next to the rewritten one, which queries
+	// don't read until the original block is deleted.
+	bucket.failDelete = true
+	require.NoError(t, r.Enforce(context.Background()))
+	require.Equal(t, [][]int64{{old, recent}, {recent}}, blockTimestamps(t, bucket, "stacktraces"))
+	require.Equal(t, [][]int64{{old, recent}}, blockTimestamps(t, NewBlocksBucket(bucket), "stacktraces"))
+	require.Equal(t, 1.0, testutil.ToFloat64(r.failures.WithLabelValues(tenant.Default)))
+	require.Equal(t, 0.0, testutil.ToFloat64(r.rewritten.WithLabelValues(tenant.Default)))
+
+	// The next run rewrites it again, replacing the rewrite.
+	bucket.failDelete = false
+	require.NoError(t, r.Enforce(context.Background()))
+	require.Equal(t, [][]int64{{recent}}, blockTimestamps(t, bucket, "stacktraces"))
+	require.Equal(t, [][]int64{{recent}}, blockTimestamps(t, NewBlocksBucket(bucket), "stacktraces"))
+	require.Equal(t, 1.0, testutil.ToFloat64(r.rewritten.WithLabelValues(tenant.Default)))
+	require.Equal(t, 1.0, testutil.ToFloat64(r.failures.WithLabelValues(tenant.Default)))
+}
+
+func TestRetentionWithoutPersistence(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Schema()
+	require.NoError(t, err)
+
+	r := NewRetention(log.NewNopLogger(), prometheus.NewRegistry(), nil, NewTables(nil, schema, "stacktraces", nil))
+	require.NoError(t, r.ApplyConfig(nil))
+	require.NoError(t, r.ApplyConfig(&config.Retention{Interval: config.DefaultRetentionInterval}))
+	require.Error(t, r.ApplyConfig(&config.Retention{
+		Interval: config.DefaultRetentionInterval,
+		Rules: []*config.RetentionRule{{
+			Selector: `{job="dev"}`,
+			MaxAge:   model.Duration(time.Hour),
+		}},
+	}))
+}
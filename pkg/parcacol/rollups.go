@@ -0,0 +1,774 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// Rollups downsamples the profiles of the tables that are older than the
+// configured age. The values of their samples are summed per series,
+// stacktrace, pprof labels and profile type in time buckets of the configured
+// resolution, which are written to the rollup tables with the start of their
+// bucket as timestamp. The traces of samples are not rolled up.
+//
+// Rollups are a cache of queries, the profiles they were rolled up from are
+// kept until retention deletes them.
+//
+// Buckets are rolled up in order, so all profiles of a tenant before its
+// watermark are rolled up. Profiles written with a timestamp before the
+// watermark, for example by imports, are added to the rollups of their bucket
+// when they are written. The watermark and the resolution of a tenant are
+// persisted in its rollup table, the resolution can't be changed once
+// profiles of the tenant were rolled up.
+type Rollups struct {
+	logger log.Logger
+	engine Engine
+	raw    *Tables
+	tables *Tables
+
+	mtx sync.Mutex
+	cfg *config.Rollups
+	// states holds the state of the rollups of the tenants loaded so far.
+	states map[string]rollupState
+	// locks serialize rolling up the buckets of a tenant with the writes of
+	// its profiles, so that every profile is rolled up exactly once.
+	locks map[string]*sync.RWMutex
+
+	buckets  *prometheus.CounterVec
+	samples  *prometheus.CounterVec
+	failures *prometheus.CounterVec
+	lastRun  prometheus.Gauge
+	now      func() time.Time
+}
+
+// NewRollups returns disabled Rollups of the profiles of the raw tables,
+// which are read with the engine and written to tables.
+func NewRollups(logger log.Logger, reg prometheus.Registerer, engine Engine, raw, tables *Tables) *Rollups {
+	return &Rollups{
+		logger: logger,
+		engine: engine,
+		raw:    raw,
+		tables: tables,
+		states: map[string]rollupState{},
+		locks:  map[string]*sync.RWMutex{},
+		buckets: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "rollups_buckets_total",
+				Help: "Total number of time buckets of profiles rolled up, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		samples: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "rollups_written_samples_total",
+				Help: "Total number of samples written to rollups, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		failures: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "rollups_failures_total",
+				Help: "Total number of failures to roll up the profiles of a tenant, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		lastRun: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Name: "rollups_last_run_timestamp_seconds",
+				Help: "Time profiles were last rolled up.",
+			},
+		),
+		now: time.Now,
+	}
+}
+
+// rollupStateName is the name of the rows of a rollup table that record the
+// state of the rollups of its tenant. Their timestamp is the watermark and
+// their duration the resolution in nanoseconds. They are inserted together
+// with the rollups they advance the watermark past.
+const rollupStateName = "__rollups__"
+
+// rollupState is the state of the rollups of a tenant.
+type rollupState struct {
+	// watermark is the time in milliseconds since the epoch before which all
+	// profiles are rolled up.
+	watermark int64
+	// resolution is the width of the buckets in milliseconds, it is zero
+	// until profiles are rolled up.
+	resolution int64
+}
+
+// ApplyConfig replaces the configuration of the rollups, a nil configuration
+// disables them. It fails if the resolution differs from the one the profiles
+// of a tenant were rolled up with.
+func (r *Rollups) ApplyConfig(ctx context.Context, cfg *config.Rollups) error {
+	if cfg != nil {
+		if err := r.checkResolution(ctx, time.Duration(cfg.Resolution)); err != nil {
+			return err
+		}
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.cfg = cfg
+	return nil
+}
+
+// checkResolution returns an error if the profiles of a tenant were rolled up
+// with another resolution.
+func (r *Rollups) checkResolution(ctx context.Context, resolution time.Duration) error {
+	tenants, err := r.raw.Tenants(ctx)
+	if err != nil {
+		return err
+	}
+	rolledUp, err := r.tables.Tenants(ctx)
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]struct{}{}
+	for _, id := range append(tenants, rolledUp...) {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		state, err := r.loadState(ctx, id)
+		if err != nil {
+			return fmt.Errorf("load rollups state of tenant %q: %w", id, err)
+		}
+		if state.resolution != 0 && state.resolution != resolution.Milliseconds() {
+			return fmt.Errorf(
+				"profiles of tenant %q are rolled up with a resolution of %s, it can't be changed to %s",
+				id, time.Duration(state.resolution)*time.Millisecond, resolution,
+			)
+		}
+	}
+	return nil
+}
+
+func (r *Rollups) interval() time.Duration {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.cfg == nil {
+		return time.Duration(config.DefaultRollupInterval)
+	}
+	return time.Duration(r.cfg.Interval)
+}
+
+// Run rolls up profiles at the configured interval until the context is
+// canceled.
+func (r *Rollups) Run(ctx context.Context) error {
+	for {
+		if err := r.Compact(ctx); err != nil {
+			level.Warn(r.logger).Log("msg", "failed to roll up profiles", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval()):
+		}
+	}
+}
+
+// Compact rolls up the profiles of all tenants that are older than the
+// configured age.
+func (r *Rollups) Compact(ctx context.Context) error {
+	r.mtx.Lock()
+	cfg := r.cfg
+	r.mtx.Unlock()
+	if cfg == nil {
+		return nil
+	}
+
+	resolution := time.Duration(cfg.Resolution).Milliseconds()
+	cutoff := alignDown(r.now().Add(-time.Duration(cfg.After)).UnixMilli(), resolution)
+
+	tenants, err := r.raw.Tenants(ctx)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, id := range tenants {
+		if err := r.compactTenant(ctx, id, resolution, cutoff); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed++
+			r.failures.WithLabelValues(id).Inc()
+			level.Warn(r.logger).Log("msg", "failed to roll up profiles of tenant", "tenant", id, "err", err)
+		}
+	}
+	r.lastRun.SetToCurrentTime()
+
+	if failed > 0 {
+		return fmt.Errorf("failed to roll up profiles of %d tenants", failed)
+	}
+	return nil
+}
+
+func (r *Rollups) compactTenant(ctx context.Context, id string, resolution, cutoff int64) error {
+	state, err := r.loadState(ctx, id)
+	if err != nil {
+		return err
+	}
+	if state.resolution != 0 && state.resolution != resolution {
+		return fmt.Errorf("profiles are rolled up with a resolution of %s", time.Duration(state.resolution)*time.Millisecond)
+	}
+	watermark := state.watermark
+	if watermark >= cutoff {
+		return nil
+	}
+
+	buckets, err := r.bucketsWithProfiles(ctx, id, watermark, cutoff, resolution)
+	if err != nil {
+		return err
+	}
+
+	lock := r.tenantLock(id)
+	for _, b := range buckets {
+		lock.Lock()
+		err := r.rollupBucket(ctx, id, b, resolution)
+		lock.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Profiles may have been written to the buckets without any profiles
+	// while the others were rolled up, they are rolled up before the
+	// watermark passes them.
+	lock.Lock()
+	defer lock.Unlock()
+
+	state, _ = r.state(id)
+	buckets, err = r.bucketsWithProfiles(ctx, id, state.watermark, cutoff, resolution)
+	if err != nil {
+		return err
+	}
+	for _, b := range buckets {
+		if err := r.rollupBucket(ctx, id, b, resolution); err != nil {
+			return err
+		}
+	}
+
+	state = rollupState{watermark: cutoff, resolution: resolution}
+	buf, err := r.stateBuffer(state)
+	if err != nil {
+		return err
+	}
+	if err := r.insert(ctx, id, buf); err != nil {
+		return fmt.Errorf("insert rollups state: %w", err)
+	}
+	r.setState(id, state)
+
+	return nil
+}
+
+// loadState returns the state of the rollups of the tenant, which is read
+// from its rollup table the first time.
+func (r *Rollups) loadState(ctx context.Context, id string) (rollupState, error) {
+	if state, ok := r.state(id); ok {
+		return state, nil
+	}
+
+	state, err := r.readState(ctx, id)
+	if err != nil {
+		return rollupState{}, err
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	// The state may have been loaded concurrently and advanced since.
+	if s, ok := r.states[id]; ok {
+		return s, nil
+	}
+	r.states[id] = state
+	return state, nil
+}
+
+// readState returns the latest state recorded in the rollup table of the
+// tenant.
+func (r *Rollups) readState(ctx context.Context, id string) (rollupState, error) {
+	state := rollupState{watermark: math.MinInt64}
+	err := r.engine.ScanTable(r.table(id)).
+		Filter(logicalplan.Col(ColumnName).Eq(logicalplan.Literal(rollupStateName))).
+		Aggregate(
+			logicalplan.Max(logicalplan.Col(ColumnTimestamp)),
+			logicalplan.Col(ColumnDuration),
+		).
+		Execute(ctx, func(ctx context.Context, ar arrow.Record) error {
+			var watermarks, durations *array.Int64
+			for i, field := range ar.Schema().Fields() {
+				switch field.Name {
+				case "max(timestamp)":
+					watermarks = ar.Column(i).(*array.Int64)
+				case ColumnDuration:
+					durations = ar.Column(i).(*array.Int64)
+				}
+			}
+			if ar.NumRows() == 0 {
+				return nil
+			}
+			if watermarks == nil || durations == nil {
+				return fmt.Errorf("unexpected columns of rollups state: %v", ar.Schema())
+			}
+
+			for i := 0; i < int(ar.NumRows()); i++ {
+				if watermarks.Value(i) > state.watermark {
+					state.watermark = watermarks.Value(i)
+					state.resolution = time.Duration(durations.Value(i)).Milliseconds()
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return rollupState{}, fmt.Errorf("read rollups state: %w", err)
+	}
+	return state, nil
+}
+
+// stateBuffer returns a buffer of the row recording the state.
+func (r *Rollups) stateBuffer(state rollupState) (*dynparquet.Buffer, error) {
+	buf, err := NormalizedProfileToParquetBuffer(r.tables.Schema(), nil, &profile.NormalizedProfile{
+		Meta: profile.Meta{
+			Name:      rollupStateName,
+			Timestamp: state.watermark,
+			Duration:  (time.Duration(state.resolution) * time.Millisecond).Nanoseconds(),
+		},
+		Samples: []*profile.NormalizedSample{{}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("convert rollups state to buffer: %w", err)
+	}
+	return buf, nil
+}
+
+// insert inserts the buffer into the rollup table of the tenant.
+func (r *Rollups) insert(ctx context.Context, id string, buf *dynparquet.Buffer) error {
+	table, err := r.tables.Table(id)
+	if err != nil {
+		return err
+	}
+	_, err = table.InsertBuffer(ctx, buf)
+	return err
+}
+
+// bucketsWithProfiles returns the buckets in [from, to) that hold profiles of
+// the tenant.
+func (r *Rollups) bucketsWithProfiles(ctx context.Context, id string, from, to, resolution int64) ([]int64, error) {
+	timestamps, err := r.timestamps(ctx, tenant.TableName(r.raw.Name(), id), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("read timestamps of profiles: %w", err)
+	}
+
+	var buckets []int64
+	for _, ts := range timestamps {
+		b := alignDown(ts, resolution)
+		if len(buckets) == 0 || buckets[len(buckets)-1] != b {
+			buckets = append(buckets, b)
+		}
+	}
+	return buckets, nil
+}
+
+// rollupBucket rolls up the bucket starting at b and advances the watermark
+// past it.
+func (r *Rollups) rollupBucket(ctx context.Context, id string, b, resolution int64) error {
+	if err := r.rollup(ctx, id, b, resolution); err != nil {
+		return fmt.Errorf("roll up bucket %d: %w", b, err)
+	}
+	r.setState(id, rollupState{watermark: b + resolution, resolution: resolution})
+	r.buckets.WithLabelValues(id).Inc()
+	return nil
+}
+
+// Write writes a profile of the tenant with insert. If the bucket of the
+// profile was already rolled up, the profile is added to its rollups.
+func (r *Rollups) Write(ctx context.Context, id string, ls labels.Labels, p *profile.NormalizedProfile, insert func() error) error {
+	r.mtx.Lock()
+	cfg := r.cfg
+	r.mtx.Unlock()
+	if cfg == nil {
+		return insert()
+	}
+
+	lock := r.tenantLock(id)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	if err := insert(); err != nil {
+		return err
+	}
+
+	state, err := r.loadState(ctx, id)
+	if err != nil {
+		return err
+	}
+	if p.Meta.Timestamp >= state.watermark {
+		return nil
+	}
+
+	buf, err := NormalizedProfileToParquetBuffer(r.tables.Schema(), ls, profileRollup(p, state.resolution))
+	if err != nil {
+		return fmt.Errorf("convert rollup to buffer: %w", err)
+	}
+	if err := r.insert(ctx, id, buf); err != nil {
+		return fmt.Errorf("insert rollup: %w", err)
+	}
+
+	r.samples.WithLabelValues(id).Add(float64(len(p.Samples)))
+	return nil
+}
+
+// profileRollup returns the rollup of a single profile in its bucket.
+func profileRollup(p *profile.NormalizedProfile, resolution int64) *profile.NormalizedProfile {
+	meta := profile.Meta{
+		Name:       p.Meta.Name,
+		SampleType: p.Meta.SampleType,
+		PeriodType: p.Meta.PeriodType,
+		Period:     p.Meta.Period,
+		Timestamp:  alignDown(p.Meta.Timestamp, resolution),
+	}
+	// The rollups of delta profiles cover the whole bucket.
+	if p.Meta.Duration != 0 {
+		meta.Duration = (time.Duration(resolution) * time.Millisecond).Nanoseconds()
+	}
+
+	res := &profile.NormalizedProfile{Meta: meta}
+	samples := map[string]*profile.NormalizedSample{}
+	for _, s := range p.Samples {
+		key := sampleKey(s.StacktraceID, s.Label, s.NumLabel, s.NumUnit)
+		sample, ok := samples[key]
+		if !ok {
+			sample = &profile.NormalizedSample{
+				StacktraceID: s.StacktraceID,
+				Label:        s.Label,
+				NumLabel:     s.NumLabel,
+				NumUnit:      s.NumUnit,
+			}
+			samples[key] = sample
+			res.Samples = append(res.Samples, sample)
+		}
+		sample.Value += s.Value
+	}
+	return res
+}
+
+// timestamps returns the sorted distinct timestamps in [from, to) of the
+// table.
+func (r *Rollups) timestamps(ctx context.Context, table string, from, to int64) ([]int64, error) {
+	var res []int64
+	err := r.engine.ScanTable(table).
+		Filter(logicalplan.And(
+			logicalplan.Col(ColumnTimestamp).GtEq(logicalplan.Literal(from)),
+			logicalplan.Col(ColumnTimestamp).Lt(logicalplan.Literal(to)),
+		)).
+		Distinct(logicalplan.Col(ColumnTimestamp)).
+		Execute(ctx, func(ctx context.Context, ar arrow.Record) error {
+			for i, field := range ar.Schema().Fields() {
+				if field.Name != ColumnTimestamp {
+					continue
+				}
+				col := ar.Column(i).(*array.Int64)
+				for j := 0; j < col.Len(); j++ {
+					if !col.IsNull(j) {
+						res = append(res, col.Value(j))
+					}
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res, nil
+}
+
+// rollupGroup is the profile of a series and profile type in a bucket.
+type rollupGroup struct {
+	labels  labels.Labels
+	profile *profile.NormalizedProfile
+}
+
+// rollup writes the sums of the values of the profiles of the tenant in the
+// bucket starting at b, together with the state whose watermark is the end of
+// the bucket.
+func (r *Rollups) rollup(ctx context.Context, id string, b, resolution int64) error {
+	var records []arrow.Record
+	defer func() {
+		for _, ar := range records {
+			ar.Release()
+		}
+	}()
+
+	err := r.engine.ScanTable(tenant.TableName(r.raw.Name(), id)).
+		Filter(logicalplan.And(
+			logicalplan.Col(ColumnTimestamp).GtEq(logicalplan.Literal(b)),
+			logicalplan.Col(ColumnTimestamp).Lt(logicalplan.Literal(b+resolution)),
+		)).
+		Aggregate(
+			logicalplan.Sum(logicalplan.Col(ColumnValue)),
+			logicalplan.Col(ColumnName),
+			logicalplan.Col(ColumnSampleType),
+			logicalplan.Col(ColumnSampleUnit),
+			logicalplan.Col(ColumnPeriodType),
+			logicalplan.Col(ColumnPeriodUnit),
+			logicalplan.Col(ColumnPeriod),
+			logicalplan.Col(ColumnDuration),
+			logicalplan.DynCol(ColumnLabels),
+			logicalplan.Col(ColumnStacktrace),
+			logicalplan.DynCol(ColumnPprofLabels),
+			logicalplan.DynCol(ColumnPprofNumLabels),
+			logicalplan.DynCol(ColumnPprofNumUnits),
+		).
+		Execute(ctx, func(ctx context.Context, ar arrow.Record) error {
+			ar.Retain()
+			records = append(records, ar)
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("aggregate profiles: %w", err)
+	}
+
+	groups := map[string]*rollupGroup{}
+	var keys []string
+	for _, ar := range records {
+		if err := rollupGroups(ar, b, time.Duration(resolution)*time.Millisecond, groups, &keys); err != nil {
+			return err
+		}
+	}
+	schema := r.tables.Schema()
+	state, err := r.stateBuffer(rollupState{watermark: b + resolution, resolution: resolution})
+	if err != nil {
+		return err
+	}
+	buffers := make([]dynparquet.DynamicRowGroup, 0, len(keys)+1)
+	buffers = append(buffers, state)
+	samples := 0
+	for _, key := range keys {
+		g := groups[key]
+		buf, err := NormalizedProfileToParquetBuffer(schema, g.labels, g.profile)
+		if err != nil {
+			return fmt.Errorf("convert rollup to buffer: %w", err)
+		}
+		buffers = append(buffers, buf)
+		samples += len(g.profile.Samples)
+	}
+
+	// All rollups of a bucket are inserted at once with the state, so a
+	// bucket is either rolled up completely or not at all.
+	merged, err := schema.MergeDynamicRowGroups(buffers)
+	if err != nil {
+		return fmt.Errorf("merge rollups: %w", err)
+	}
+	buf, err := schema.NewBuffer(merged.DynamicColumns())
+	if err != nil {
+		return fmt.Errorf("create buffer: %w", err)
+	}
+	if _, err := buf.WriteRowGroup(merged); err != nil {
+		return fmt.Errorf("write rollups: %w", err)
+	}
+
+	if err := r.insert(ctx, id, buf); err != nil {
+		return fmt.Errorf("insert rollups: %w", err)
+	}
+
+	r.samples.WithLabelValues(id).Add(float64(samples))
+	return nil
+}
+
+// rollupGroups adds the rows of a record of aggregated profiles to the groups
+// of their series and profile type. The keys of new groups are appended to
+// keys in the order the groups are created.
+func rollupGroups(ar arrow.Record, b int64, resolution time.Duration, groups map[string]*rollupGroup, keys *[]string) error {
+	var (
+		values, periods, durations                                    *array.Int64
+		names, sampleTypes, sampleUnits, periodTypes, periodUnits, st *array.Binary
+		labelColumnIndices                                            []int
+	)
+	for i, field := range ar.Schema().Fields() {
+		switch {
+		case field.Name == "sum(value)":
+			values = ar.Column(i).(*array.Int64)
+		case field.Name == ColumnPeriod:
+			periods = ar.Column(i).(*array.Int64)
+		case field.Name == ColumnDuration:
+			durations = ar.Column(i).(*array.Int64)
+		case field.Name == ColumnName:
+			names = ar.Column(i).(*array.Binary)
+		case field.Name == ColumnSampleType:
+			sampleTypes = ar.Column(i).(*array.Binary)
+		case field.Name == ColumnSampleUnit:
+			sampleUnits = ar.Column(i).(*array.Binary)
+		case field.Name == ColumnPeriodType:
+			periodTypes = ar.Column(i).(*array.Binary)
+		case field.Name == ColumnPeriodUnit:
+			periodUnits = ar.Column(i).(*array.Binary)
+		case field.Name == ColumnStacktrace:
+			st = ar.Column(i).(*array.Binary)
+		case strings.HasPrefix(field.Name, ColumnLabels+"."):
+			labelColumnIndices = append(labelColumnIndices, i)
+		}
+	}
+	if ar.NumRows() == 0 {
+		return nil
+	}
+	pprofLabels := sampleLabelColumnsFromRecord(ar)
+	if values == nil || periods == nil || durations == nil || names == nil || sampleTypes == nil ||
+		sampleUnits == nil || periodTypes == nil || periodUnits == nil || st == nil {
+		return fmt.Errorf("unexpected columns of aggregated profiles: %v", ar.Schema())
+	}
+
+	for i := 0; i < int(ar.NumRows()); i++ {
+		meta := profile.Meta{
+			Name:       names.ValueString(i),
+			SampleType: profile.ValueType{Type: sampleTypes.ValueString(i), Unit: sampleUnits.ValueString(i)},
+			PeriodType: profile.ValueType{Type: periodTypes.ValueString(i), Unit: periodUnits.ValueString(i)},
+			Period:     periods.Value(i),
+			Timestamp:  b,
+		}
+		// The rollups of delta profiles cover the whole bucket.
+		if durations.Value(i) != 0 {
+			meta.Duration = resolution.Nanoseconds()
+		}
+		ls := rowLabelSet(ar, labelColumnIndices, i, nil)
+
+		key := fmt.Sprintf("%s;%s;%s;%s;%s;%s;%d;%d", ls.String(), meta.Name, meta.SampleType.Type, meta.SampleType.Unit, meta.PeriodType.Type, meta.PeriodType.Unit, meta.Period, meta.Duration)
+		g, ok := groups[key]
+		if !ok {
+			g = &rollupGroup{
+				labels:  ls,
+				profile: &profile.NormalizedProfile{Meta: meta},
+			}
+			groups[key] = g
+			*keys = append(*keys, key)
+		}
+		sample := &profile.NormalizedSample{
+			StacktraceID: st.ValueString(i),
+			Value:        values.Value(i),
+		}
+		sample.Label, sample.NumLabel, sample.NumUnit = pprofLabels.row(i)
+		g.profile.Samples = append(g.profile.Samples, sample)
+	}
+
+	return nil
+}
+
+func (r *Rollups) state(id string) (rollupState, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	s, ok := r.states[id]
+	return s, ok
+}
+
+func (r *Rollups) tenantLock(id string) *sync.RWMutex {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	l, ok := r.locks[id]
+	if !ok {
+		l = &sync.RWMutex{}
+		r.locks[id] = l
+	}
+	return l
+}
+
+func (r *Rollups) setState(id string, s rollupState) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.states[id] = s
+}
+
+// table returns the name of the rollup table of the tenant.
+func (r *Rollups) table(id string) string {
+	return tenant.TableName(r.tables.Name(), id)
+}
+
+// split returns the range [from, to) of timestamps of the rollups of the
+// tenant that a query of the profiles with a timestamp in (start, end) reads
+// instead of the profiles. The query must sum the values of the profiles in
+// time buckets of the step, unless it is zero. It returns false if no
+// rollups can be read.
+func (r *Rollups) split(id string, start, end int64, step time.Duration) (int64, int64, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.cfg == nil {
+		return 0, 0, false
+	}
+	state, ok := r.states[id]
+	if !ok || state.resolution == 0 || start == math.MaxInt64 {
+		return 0, 0, false
+	}
+	resolution := time.Duration(state.resolution) * time.Millisecond
+	if step%resolution != 0 {
+		return 0, 0, false
+	}
+
+	from := alignUp(start+1, state.resolution)
+	to := alignDown(end, state.resolution)
+	if state.watermark < to {
+		to = state.watermark
+	}
+	return from, to, from < to
+}
+
+// alignDown returns the largest multiple of d that is not greater than t.
+func alignDown(t, d int64) int64 {
+	m := t % d
+	if m < 0 {
+		m += d
+	}
+	return t - m
+}
+
+// alignUp returns the smallest multiple of d that is not less than t.
+func alignUp(t, d int64) int64 {
+	down := alignDown(t, d)
+	if down == t || down > math.MaxInt64-d {
+		return down
+	}
+	return down + d
+}
@@ -30,11 +30,13 @@ func NormalizedProfileToParquetBuffer(schema *dynparquet.Schema, ls labels.Label
 	names := labelNames(ls)
 	pprofLabels := profileLabelNames(p)
 	pprofNumLabels := profileNumLabelNames(p)
+	pprofNumUnits := profileNumUnitNames(p)
 
 	pb, err := schema.NewBuffer(map[string][]string{
 		ColumnLabels:         names,
 		ColumnPprofLabels:    pprofLabels,
 		ColumnPprofNumLabels: pprofNumLabels,
+		ColumnPprofNumUnits:  pprofNumUnits,
 	})
 	if err != nil {
 		return nil, err
@@ -47,6 +49,7 @@ func NormalizedProfileToParquetBuffer(schema *dynparquet.Schema, ls labels.Label
 			r[:0],
 			pprofLabels,
 			pprofNumLabels,
+			pprofNumUnits,
 			ls,
 			p.Meta,
 			sample,
@@ -105,12 +108,29 @@ func profileNumLabelNames(p *profile.NormalizedProfile) []string {
 	return names
 }
 
+func profileNumUnitNames(p *profile.NormalizedProfile) []string {
+	names := []string{}
+	seen := map[string]struct{}{}
+
+	for _, sample := range p.Samples {
+		for name := range sample.NumUnit {
+			if _, ok := seen[name]; !ok {
+				names = append(names, name)
+				seen[name] = struct{}{}
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // SampleToParquetRow converts a sample to a Parquet row. The passed labels
 // must be sorted.
 func SampleToParquetRow(
 	schema *dynparquet.Schema,
 	row parquet.Row,
-	profileLabelNames, profileNumLabelNames []string,
+	profileLabelNames, profileNumLabelNames, profileNumUnitNames []string,
 	ls labels.Labels,
 	meta profile.Meta,
 	s *profile.NormalizedSample,
@@ -151,6 +171,12 @@ func SampleToParquetRow(
 		case ColumnValue:
 			row = append(row, parquet.ValueOf(s.Value).Level(0, 0, columnIndex))
 			columnIndex++
+		case ColumnSpanID:
+			row = append(row, optionalValue(s.SpanID, columnIndex))
+			columnIndex++
+		case ColumnTraceID:
+			row = append(row, optionalValue(s.TraceID, columnIndex))
+			columnIndex++
 
 		// All remaining cases take care of dynamic columns
 		case ColumnLabels:
@@ -178,6 +204,16 @@ func SampleToParquetRow(
 					columnIndex++
 				}
 			}
+		case ColumnPprofNumUnits:
+			for _, name := range profileNumUnitNames {
+				if value, ok := s.NumUnit[name]; ok {
+					row = append(row, parquet.ValueOf(value).Level(0, 1, columnIndex))
+					columnIndex++
+				} else {
+					row = append(row, parquet.ValueOf(nil).Level(0, 0, columnIndex))
+					columnIndex++
+				}
+			}
 		default:
 			panic(fmt.Errorf("conversion not implement for column: %s", column.Name))
 		}
@@ -185,3 +221,12 @@ func SampleToParquetRow(
 
 	return row
 }
+
+// optionalValue returns the value of a nullable column, which is null if the
+// value is empty.
+func optionalValue(v string, columnIndex int) parquet.Value {
+	if v == "" {
+		return parquet.ValueOf(nil).Level(0, 0, columnIndex)
+	}
+	return parquet.ValueOf(v).Level(0, 1, columnIndex)
+}
@@ -0,0 +1,278 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+// SampleLabelFilter bounds the cardinality of the pprof labels of ingested
+// samples, which are stored in dynamic columns. Labels can be dropped by key
+// and the number of distinct values of each key within a window can be
+// capped. The values are only counted in memory, so a restart starts a new
+// window. It is safe for concurrent use, the samples of different tenants are
+// filtered in parallel. All methods are safe to call on a nil
+// SampleLabelFilter, in which case labels are kept as is.
+type SampleLabelFilter struct {
+	// mtx guards the rules and the tenants map, the state of every tenant
+	// has a lock of its own.
+	mtx     sync.RWMutex
+	rules   *sampleLabelRules
+	tenants map[string]*sampleLabelState
+
+	columns    *prometheus.GaugeVec
+	dropped    *prometheus.CounterVec
+	overflowed *prometheus.CounterVec
+	now        func() time.Time
+}
+
+// sampleLabelRules are the rules of a configuration, they are replaced as a
+// whole and never modified.
+type sampleLabelRules struct {
+	allow map[string]struct{}
+	deny  map[string]struct{}
+	cfg   config.SampleLabels
+}
+
+type sampleLabelState struct {
+	mtx sync.Mutex
+	// values holds the distinct values of every string label key admitted
+	// since windowStart, it is only populated while a maximum is
+	// configured.
+	values      map[string]map[string]struct{}
+	windowStart time.Time
+	strKeys     map[string]struct{}
+	numKeys     map[string]struct{}
+}
+
+// NewSampleLabelFilter returns a SampleLabelFilter keeping all labels.
+func NewSampleLabelFilter(reg prometheus.Registerer) *SampleLabelFilter {
+	return &SampleLabelFilter{
+		rules: &sampleLabelRules{cfg: config.SampleLabels{
+			OverflowValue: config.DefaultSampleLabelOverflowValue,
+			ValuesWindow:  config.DefaultSampleLabelValuesWindow,
+		}},
+		tenants: map[string]*sampleLabelState{},
+		columns: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sample_label_dynamic_columns",
+				Help: "Number of distinct pprof label keys stored since the process started, partitioned by tenant and column. Every key is a dynamic column of the tables of the tenant.",
+			},
+			[]string{"tenant", "column"},
+		),
+		dropped: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sample_labels_dropped_total",
+				Help: "Total number of pprof labels dropped because of their key, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		overflowed: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sample_label_values_overflowed_total",
+				Help: "Total number of pprof label values replaced because their key reached its maximum number of values within the window, partitioned by tenant.",
+			},
+			[]string{"tenant"},
+		),
+		now: time.Now,
+	}
+}
+
+// ApplyConfig replaces the configuration of the filter. Values that were
+// already admitted stay admitted until their window ends.
+func (f *SampleLabelFilter) ApplyConfig(cfg *config.SampleLabels) error {
+	c := config.SampleLabels{
+		OverflowValue: config.DefaultSampleLabelOverflowValue,
+		ValuesWindow:  config.DefaultSampleLabelValuesWindow,
+	}
+	if cfg != nil {
+		c = *cfg
+	}
+	if len(c.AllowKeys) > 0 && len(c.DenyKeys) > 0 {
+		return fmt.Errorf("only one of allow and deny keys may be set")
+	}
+	if c.OverflowValue == "" {
+		return fmt.Errorf("empty overflow value")
+	}
+	if c.ValuesWindow <= 0 {
+		return fmt.Errorf("values window must be positive")
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.rules = &sampleLabelRules{
+		allow: keySet(c.AllowKeys),
+		deny:  keySet(c.DenyKeys),
+		cfg:   c,
+	}
+	return nil
+}
+
+func keySet(keys []string) map[string]struct{} {
+	if len(keys) == 0 {
+		return nil
+	}
+	res := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		res[k] = struct{}{}
+	}
+	return res
+}
+
+func (r *sampleLabelRules) keep(key string) bool {
+	if r.allow != nil {
+		_, ok := r.allow[key]
+		return ok
+	}
+	_, denied := r.deny[key]
+	return !denied
+}
+
+// tenant returns the rules and the state of the tenant.
+func (f *SampleLabelFilter) tenant(id string) (*sampleLabelRules, *sampleLabelState) {
+	f.mtx.RLock()
+	rules, s := f.rules, f.tenants[id]
+	f.mtx.RUnlock()
+	if s != nil {
+		return rules, s
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	s, ok := f.tenants[id]
+	if !ok {
+		s = &sampleLabelState{
+			values:      map[string]map[string]struct{}{},
+			windowStart: f.now(),
+			strKeys:     map[string]struct{}{},
+			numKeys:     map[string]struct{}{},
+		}
+		f.tenants[id] = s
+	}
+	return f.rules, s
+}
+
+// startWindow forgets the admitted values once the window that started at
+// windowStart has ended.
+func (s *sampleLabelState) startWindow(now time.Time, window time.Duration) {
+	if now.Sub(s.windowStart) < window {
+		return
+	}
+	s.values = map[string]map[string]struct{}{}
+	s.windowStart = now
+}
+
+// admit returns whether the value of a string label is stored as is.
+func (s *sampleLabelState) admit(key, value string, max int) bool {
+	if max <= 0 {
+		return true
+	}
+
+	values, ok := s.values[key]
+	if !ok {
+		values = map[string]struct{}{}
+		s.values[key] = values
+	}
+	if _, ok := values[value]; ok {
+		return true
+	}
+	if len(values) >= max {
+		return false
+	}
+	values[value] = struct{}{}
+	return true
+}
+
+// Filter drops the labels of the samples of p whose key isn't allowed and
+// replaces the values exceeding the maximum of their key. The profile is
+// modified in place.
+func (f *SampleLabelFilter) Filter(tenantID string, p *pprofpb.Profile) {
+	if f == nil {
+		return
+	}
+
+	rules, s := f.tenant(tenantID)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.startWindow(f.now(), time.Duration(rules.cfg.ValuesWindow))
+	overflowIndex := int64(-1)
+	dropped, overflowed := 0, 0
+
+	for _, sample := range p.Sample {
+		if sample == nil {
+			continue
+		}
+		kept := sample.Label[:0]
+		for _, label := range sample.Label {
+			if !validStringIndexes(p.StringTable, label.Key, label.Str) {
+				// Left for validation to reject.
+				kept = append(kept, label)
+				continue
+			}
+
+			key := p.StringTable[label.Key]
+			if !rules.keep(key) {
+				dropped++
+				continue
+			}
+
+			switch {
+			case label.Str != 0:
+				if !s.admit(key, p.StringTable[label.Str], rules.cfg.MaxValuesPerKey) {
+					if overflowIndex < 0 {
+						p.StringTable = append(p.StringTable, rules.cfg.OverflowValue)
+						overflowIndex = int64(len(p.StringTable) - 1)
+					}
+					label.Str = overflowIndex
+					overflowed++
+				}
+				s.strKeys[key] = struct{}{}
+			case label.Num != 0:
+				s.numKeys[key] = struct{}{}
+			}
+			kept = append(kept, label)
+		}
+		sample.Label = kept
+	}
+
+	if dropped > 0 {
+		f.dropped.WithLabelValues(tenantID).Add(float64(dropped))
+	}
+	if overflowed > 0 {
+		f.overflowed.WithLabelValues(tenantID).Add(float64(overflowed))
+	}
+	f.columns.WithLabelValues(tenantID, ColumnPprofLabels).Set(float64(len(s.strKeys)))
+	f.columns.WithLabelValues(tenantID, ColumnPprofNumLabels).Set(float64(len(s.numKeys)))
+}
+
+func validStringIndexes(stringTable []string, indexes ...int64) bool {
+	for _, i := range indexes {
+		if i < 0 || i >= int64(len(stringTable)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+// sampleLabelsProfile returns a profile with a sample for each request ID,
+// every sample labeled with a request ID, a handler and a numeric size.
+func sampleLabelsProfile(requestIDs ...string) *pprofpb.Profile {
+	p := &pprofpb.Profile{
+		StringTable: []string{"", "request_id", "handler", "/api", "bytes"},
+	}
+	for _, id := range requestIDs {
+		p.StringTable = append(p.StringTable, id)
+		p.Sample = append(p.Sample, &pprofpb.Sample{
+			Value: []int64{1},
+			Label: []*pprofpb.Label{
+				{Key: 1, Str: int64(len(p.StringTable) - 1)},
+				{Key: 2, Str: 3},
+				{Key: 4, Num: 512},
+			},
+		})
+	}
+	return p
+}
+
+func sampleLabelValues(p *pprofpb.Profile, key string) []string {
+	var res []string
+	for _, s := range p.Sample {
+		for _, l := range s.Label {
+			if p.StringTable[l.Key] == key && l.Str != 0 {
+				res = append(res, p.StringTable[l.Str])
+			}
+		}
+	}
+	return res
+}
+
+func TestSampleLabelFilter(t *testing.T) {
+	t.Parallel()
+
+	// A nil filter keeps all labels.
+	var nilFilter *SampleLabelFilter
+	p := sampleLabelsProfile("a")
+	nilFilter.Filter("default", p)
+	require.Len(t, p.Sample[0].Label, 3)
+
+	f := NewSampleLabelFilter(prometheus.NewRegistry())
+
+	p = sampleLabelsProfile("a", "b")
+	f.Filter("default", p)
+	require.Equal(t, []string{"a", "b"}, sampleLabelValues(p, "request_id"))
+	require.Equal(t, 2.0, testutil.ToFloat64(f.columns.WithLabelValues("default", ColumnPprofLabels)))
+	require.Equal(t, 1.0, testutil.ToFloat64(f.columns.WithLabelValues("default", ColumnPprofNumLabels)))
+
+	require.Error(t, f.ApplyConfig(&config.SampleLabels{
+		AllowKeys:     []string{"handler"},
+		DenyKeys:      []string{"request_id"},
+		OverflowValue: "other",
+	}))
+	require.Error(t, f.ApplyConfig(&config.SampleLabels{}))
+
+	// Deny list.
+	require.NoError(t, f.ApplyConfig(&config.SampleLabels{
+		DenyKeys:      []string{"request_id"},
+		OverflowValue: "other",
+		ValuesWindow:  config.DefaultSampleLabelValuesWindow,
+	}))
+	p = sampleLabelsProfile("a", "b")
+	f.Filter("team-a", p)
+	require.Empty(t, sampleLabelValues(p, "request_id"))
+	require.Equal(t, []string{"/api", "/api"}, sampleLabelValues(p, "handler"))
+	require.Equal(t, 2.0, testutil.ToFloat64(f.dropped.WithLabelValues("team-a")))
+	require.Equal(t, 1.0, testutil.ToFloat64(f.columns.WithLabelValues("team-a", ColumnPprofLabels)))
+
+	// Allow list, numeric labels are subject to it as well.
+	require.NoError(t, f.ApplyConfig(&config.SampleLabels{
+		AllowKeys:     []string{"handler"},
+		OverflowValue: "other",
+		ValuesWindow:  config.DefaultSampleLabelValuesWindow,
+	}))
+	p = sampleLabelsProfile("a")
+	f.Filter("team-b", p)
+	require.Len(t, p.Sample[0].Label, 1)
+	require.Equal(t, []string{"/api"}, sampleLabelValues(p, "handler"))
+	require.Equal(t, 0.0, testutil.ToFloat64(f.columns.WithLabelValues("team-b", ColumnPprofNumLabels)))
+
+	// Values exceeding the maximum are replaced, values seen before are kept.
+	require.NoError(t, f.ApplyConfig(&config.SampleLabels{
+		MaxValuesPerKey: 2,
+		OverflowValue:   "other",
+		ValuesWindow:    model.Duration(time.Hour),
+	}))
+	p = sampleLabelsProfile("a", "b", "c", "d")
+	f.Filter("team-c", p)
+	require.Equal(t, []string{"a", "b", "other", "other"}, sampleLabelValues(p, "request_id"))
+	require.Equal(t, []string{"/api", "/api", "/api", "/api"}, sampleLabelValues(p, "handler"))
+	require.Equal(t, 2.0, testutil.ToFloat64(f.overflowed.WithLabelValues("team-c")))
+
+	p = sampleLabelsProfile("b", "e")
+	f.Filter("team-c", p)
+	require.Equal(t, []string{"b", "other"}, sampleLabelValues(p, "request_id"))
+
+	// Tenants have separate values.
+	p = sampleLabelsProfile("c", "d")
+	f.Filter("team-d", p)
+	require.Equal(t, []string{"c", "d"}, sampleLabelValues(p, "request_id"))
+
+	// The values are forgotten once the window ended.
+	now := time.Now()
+	f.now = func() time.Time { return now.Add(time.Hour) }
+	p = sampleLabelsProfile("e", "f", "a")
+	f.Filter("team-c", p)
+	require.Equal(t, []string{"e", "f", "other"}, sampleLabelValues(p, "request_id"))
+
+	require.Error(t, f.ApplyConfig(&config.SampleLabels{OverflowValue: "other"}))
+
+	// Labels with indexes out of the string table are left for validation
+	// to reject.
+	p = sampleLabelsProfile("a")
+	p.Sample[0].Label = append(p.Sample[0].Label,
+		&pprofpb.Label{Key: int64(len(p.StringTable))},
+		&pprofpb.Label{Key: 1, Str: int64(len(p.StringTable))},
+	)
+	p.SampleType = []*pprofpb.ValueType{{}}
+	f.Filter("team-e", p)
+	require.Len(t, p.Sample[0].Label, 5)
+	require.ErrorContains(t, validatePprofProfile(p), "invalid key index")
+
+	p.Sample = append(p.Sample, nil)
+	f.Filter("team-e", p)
+}
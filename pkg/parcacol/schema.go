@@ -29,10 +29,13 @@ const (
 	ColumnPeriodUnit     = "period_unit"
 	ColumnPprofLabels    = "pprof_labels"
 	ColumnPprofNumLabels = "pprof_num_labels"
+	ColumnPprofNumUnits  = "pprof_num_units"
 	ColumnSampleType     = "sample_type"
 	ColumnSampleUnit     = "sample_unit"
+	ColumnSpanID         = "span_id"
 	ColumnStacktrace     = "stacktrace"
 	ColumnTimestamp      = "timestamp"
+	ColumnTraceID        = "trace_id"
 	ColumnValue          = "value"
 )
 
@@ -99,6 +102,14 @@ func Schema() (*dynparquet.Schema, error) {
 					Nullable: true,
 				},
 				Dynamic: true,
+			}, {
+				Name: ColumnPprofNumUnits,
+				StorageLayout: &schemapb.StorageLayout{
+					Type:     schemapb.StorageLayout_TYPE_STRING,
+					Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
+					Nullable: true,
+				},
+				Dynamic: true,
 			}, {
 				Name: ColumnSampleType,
 				StorageLayout: &schemapb.StorageLayout{
@@ -113,6 +124,17 @@ func Schema() (*dynparquet.Schema, error) {
 					Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
 				},
 				Dynamic: false,
+			}, {
+				// The trace and span IDs are columns of their own rather
+				// than pprof labels, as they are unique to few samples and
+				// queried by their exact value.
+				Name: ColumnSpanID,
+				StorageLayout: &schemapb.StorageLayout{
+					Type:     schemapb.StorageLayout_TYPE_STRING,
+					Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
+					Nullable: true,
+				},
+				Dynamic: false,
 			}, {
 				Name: ColumnStacktrace,
 				StorageLayout: &schemapb.StorageLayout{
@@ -129,6 +151,14 @@ func Schema() (*dynparquet.Schema, error) {
 					Compression: schemapb.StorageLayout_COMPRESSION_LZ4_RAW,
 				},
 				Dynamic: false,
+			}, {
+				Name: ColumnTraceID,
+				StorageLayout: &schemapb.StorageLayout{
+					Type:     schemapb.StorageLayout_TYPE_STRING,
+					Encoding: schemapb.StorageLayout_ENCODING_RLE_DICTIONARY,
+					Nullable: true,
+				},
+				Dynamic: false,
 			}, {
 				Name: ColumnValue,
 				StorageLayout: &schemapb.StorageLayout{
@@ -174,6 +204,18 @@ func Schema() (*dynparquet.Schema, error) {
 				Name:       ColumnPprofNumLabels,
 				Direction:  schemapb.SortingColumn_DIRECTION_ASCENDING,
 				NullsFirst: true,
+			}, {
+				// Sorting columns get bloom filters, which let queries
+				// by trace or span skip the row groups and blocks of
+				// other spans. Row groups without any trace data have
+				// none and are still read.
+				Name:       ColumnTraceID,
+				Direction:  schemapb.SortingColumn_DIRECTION_ASCENDING,
+				NullsFirst: true,
+			}, {
+				Name:       ColumnSpanID,
+				Direction:  schemapb.SortingColumn_DIRECTION_ASCENDING,
+				NullsFirst: true,
 			},
 		},
 	})
@@ -0,0 +1,184 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+)
+
+// QueryStats collects statistics about the execution of a single query. All
+// methods are safe to call on a nil QueryStats, in which case nothing is
+// recorded.
+type QueryStats struct {
+	mtx sync.Mutex
+
+	rowsScanned         uint64
+	distinctStacktraces uint64
+	metastoreLookups    uint64
+
+	selectDuration  time.Duration
+	resolveDuration time.Duration
+	renderDuration  time.Duration
+}
+
+type queryStatsKey struct{}
+
+// ContextWithQueryStats returns a context that records query statistics into s.
+func ContextWithQueryStats(ctx context.Context, s *QueryStats) context.Context {
+	return context.WithValue(ctx, queryStatsKey{}, s)
+}
+
+// QueryStatsFromContext returns the QueryStats of the context or nil if the
+// context does not record any.
+func QueryStatsFromContext(ctx context.Context) *QueryStats {
+	s, _ := ctx.Value(queryStatsKey{}).(*QueryStats)
+	return s
+}
+
+func (s *QueryStats) AddRowsScanned(n uint64) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.rowsScanned += n
+}
+
+func (s *QueryStats) AddDistinctStacktraces(n uint64) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.distinctStacktraces += n
+}
+
+func (s *QueryStats) AddMetastoreLookups(n uint64) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.metastoreLookups += n
+}
+
+// ObserveSelect adds the time spent selecting data from storage since start.
+func (s *QueryStats) ObserveSelect(start time.Time) {
+	if s == nil {
+		return
+	}
+	d := time.Since(start)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.selectDuration += d
+}
+
+// ObserveResolve adds the time spent resolving stacktraces since start.
+func (s *QueryStats) ObserveResolve(start time.Time) {
+	if s == nil {
+		return
+	}
+	d := time.Since(start)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.resolveDuration += d
+}
+
+// ObserveRender adds the time spent rendering the report since start.
+func (s *QueryStats) ObserveRender(start time.Time) {
+	if s == nil {
+		return
+	}
+	d := time.Since(start)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.renderDuration += d
+}
+
+// Proto returns the protobuf representation of the statistics.
+func (s *QueryStats) Proto() *pb.QueryStats {
+	if s == nil {
+		return nil
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return &pb.QueryStats{
+		RowsScanned:                s.rowsScanned,
+		DistinctStacktraces:        s.distinctStacktraces,
+		MetastoreLookups:           s.metastoreLookups,
+		SelectDuration:             durationpb.New(s.selectDuration),
+		ResolveStacktracesDuration: durationpb.New(s.resolveDuration),
+		RenderReportDuration:       durationpb.New(s.renderDuration),
+	}
+}
+
+// StatsTableProvider wraps a TableProvider and records the rows read by
+// table scans into the QueryStats of the scan's context.
+type StatsTableProvider struct {
+	logicalplan.TableProvider
+}
+
+// NewStatsTableProvider returns a TableProvider recording scanned rows.
+func NewStatsTableProvider(p logicalplan.TableProvider) *StatsTableProvider {
+	return &StatsTableProvider{TableProvider: p}
+}
+
+func (p *StatsTableProvider) GetTable(name string) logicalplan.TableReader {
+	t := p.TableProvider.GetTable(name)
+	if t == nil {
+		return nil
+	}
+	return &statsTableReader{TableReader: t}
+}
+
+type statsTableReader struct {
+	logicalplan.TableReader
+}
+
+// Iterator counts the rows the table passes to the callbacks. The table
+// skips the granules and row groups whose statistics don't match the filter
+// and passes the rows of the others on to be filtered.
+func (t *statsTableReader) Iterator(
+	ctx context.Context,
+	tx uint64,
+	pool memory.Allocator,
+	schema *arrow.Schema,
+	options logicalplan.IterOptions,
+	callbacks []logicalplan.Callback,
+) error {
+	stats := QueryStatsFromContext(ctx)
+	if stats == nil {
+		return t.TableReader.Iterator(ctx, tx, pool, schema, options, callbacks)
+	}
+
+	counting := make([]logicalplan.Callback, 0, len(callbacks))
+	for _, callback := range callbacks {
+		callback := callback
+		counting = append(counting, func(ctx context.Context, r arrow.Record) error {
+			stats.AddRowsScanned(uint64(r.NumRows()))
+			return callback(ctx, r)
+		})
+	}
+
+	return t.TableReader.Iterator(ctx, tx, pool, schema, options, counting)
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+func TestQueryStatsRowsScannedSpans(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	col, err := frostdb.New()
+	require.NoError(t, err)
+	db, err := col.DB(ctx, "parca")
+	require.NoError(t, err)
+	schema, err := Schema()
+	require.NoError(t, err)
+	tables := NewTables(db, schema, "stacktraces", nil)
+	table, err := tables.Table("team-a")
+	require.NoError(t, err)
+
+	insert := func(traceID, spanID string) {
+		buf, err := NormalizedProfileToParquetBuffer(schema, labels.FromStrings("job", "dev"), &profile.NormalizedProfile{
+			Samples: []*profile.NormalizedSample{{StacktraceID: "stacktrace", Value: 1, TraceID: traceID, SpanID: spanID}},
+			Meta:    profile.Meta{Name: "memory", Timestamp: 1},
+		})
+		require.NoError(t, err)
+		_, err = table.InsertBuffer(ctx, buf)
+		require.NoError(t, err)
+	}
+	engine := query.NewEngine(memory.DefaultAllocator, NewStatsTableProvider(tables))
+	count := func(expr logicalplan.Expr) uint64 {
+		stats := &QueryStats{}
+		b := engine.ScanTable(tenant.TableName("stacktraces", "team-a"))
+		if expr != nil {
+			b = b.Filter(expr)
+		}
+		require.NoError(t, b.Execute(ContextWithQueryStats(ctx, stats), func(ctx context.Context, ar arrow.Record) error {
+			return nil
+		}))
+		return stats.Proto().RowsScanned
+	}
+
+	// Row groups of spans other than the queried one are skipped by their
+	// bloom filters.
+	insert("0af7651916cd43dd8448eb211c80319c", "00f067aa0ba902b7")
+	insert("4bf92f3577b34da6a3ce929d0e0e4736", "53995c3f42cd8ad8")
+	require.Equal(t, uint64(2), count(nil))
+	require.Equal(t, uint64(1), count(logicalplan.Col(ColumnSpanID).Eq(logicalplan.Literal("53995c3f42cd8ad8"))))
+	require.Equal(t, uint64(1), count(logicalplan.Col(ColumnTraceID).Eq(logicalplan.Literal("0af7651916cd43dd8448eb211c80319c"))))
+	require.Equal(t, uint64(0), count(logicalplan.Col(ColumnTraceID).Eq(logicalplan.Literal("b7ad6b7169203331b7ad6b7169203331"))))
+}
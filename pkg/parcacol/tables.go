@@ -0,0 +1,203 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/thanos-io/objstore"
+
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// Tables maintains a separate table for the profiles of every tenant. Tables
+// are created the first time a tenant writes profiles.
+type Tables struct {
+	db     *frostdb.DB
+	schema *dynparquet.Schema
+	name   string
+	bucket objstore.Bucket
+
+	mtx    sync.RWMutex
+	tables map[string]*frostdb.Table
+}
+
+// NewTables returns Tables creating the tables of tenants in db. The table of
+// the default tenant is called name, the tables of all other tenants are
+// suffixed with their tenant ID. The bucket holds the blocks persisted by db,
+// it is nil if persistence is disabled.
+func NewTables(db *frostdb.DB, schema *dynparquet.Schema, name string, bucket objstore.Bucket) *Tables {
+	return &Tables{
+		db:     db,
+		schema: schema,
+		name:   name,
+		bucket: bucket,
+		tables: map[string]*frostdb.Table{},
+	}
+}
+
+// Name returns the base name of the tables.
+func (t *Tables) Name() string {
+	return t.name
+}
+
+// Schema returns the schema shared by all tables.
+func (t *Tables) Schema() *dynparquet.Schema {
+	return t.schema
+}
+
+// Table returns the table of the tenant, creating it if it doesn't exist yet.
+func (t *Tables) Table(id string) (*frostdb.Table, error) {
+	t.mtx.RLock()
+	table, ok := t.tables[id]
+	t.mtx.RUnlock()
+	if ok {
+		return table, nil
+	}
+
+	if err := tenant.Validate(id); err != nil {
+		return nil, err
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if table, ok := t.tables[id]; ok {
+		return table, nil
+	}
+
+	table, err := t.db.Table(tenant.TableName(t.name, id), frostdb.NewTableConfig(t.schema))
+	if err != nil {
+		return nil, fmt.Errorf("create table of tenant %q: %w", id, err)
+	}
+	t.tables[id] = table
+	return table, nil
+}
+
+// Tenants returns the sorted IDs of the tenants that have a table, either
+// created since the process started or persisted to the bucket. Tables only
+// recovered from the write-ahead log are listed once their tenant writes
+// again.
+func (t *Tables) Tenants(ctx context.Context) ([]string, error) {
+	seen := map[string]struct{}{}
+	t.mtx.RLock()
+	for id := range t.tables {
+		seen[id] = struct{}{}
+	}
+	t.mtx.RUnlock()
+
+	if t.bucket != nil {
+		if err := t.bucket.Iter(ctx, "", func(dir string) error {
+			if id, ok := t.TenantOf(strings.TrimSuffix(dir, objstore.DirDelim)); ok {
+				seen[id] = struct{}{}
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("list tables: %w", err)
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// GetTable implements the logicalplan.TableProvider interface. Queries of a
+// tenant without a table read an empty table, they don't create one.
+func (t *Tables) GetTable(name string) logicalplan.TableReader {
+	id, ok := t.TenantOf(name)
+	if !ok {
+		return nil
+	}
+
+	t.mtx.RLock()
+	table, ok := t.tables[id]
+	t.mtx.RUnlock()
+	if ok {
+		return table
+	}
+
+	// The table may have been recovered from the write-ahead log or the
+	// persisted blocks without being used since.
+	if table, ok := t.db.TableProvider().GetTable(name).(*frostdb.Table); ok && table != nil {
+		return table
+	}
+	return emptyTable{schema: t.schema}
+}
+
+// TenantOf returns the ID of the tenant whose table is called name, or false
+// if name isn't the name of one of the tables.
+func (t *Tables) TenantOf(name string) (string, bool) {
+	if name == t.name {
+		return tenant.Default, true
+	}
+	if !strings.HasPrefix(name, t.name+"_") {
+		return "", false
+	}
+	id := strings.TrimPrefix(name, t.name+"_")
+	if tenant.Validate(id) != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// MultiTableProvider provides the tables of the first of its providers that
+// has a table of the requested name.
+type MultiTableProvider []logicalplan.TableProvider
+
+func (p MultiTableProvider) GetTable(name string) logicalplan.TableReader {
+	for _, provider := range p {
+		if t := provider.GetTable(name); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// emptyTable is the table of a tenant that has not written any profiles.
+type emptyTable struct {
+	schema *dynparquet.Schema
+}
+
+func (t emptyTable) View(ctx context.Context, fn func(ctx context.Context, tx uint64) error) error {
+	return fn(ctx, 0)
+}
+
+func (t emptyTable) Iterator(context.Context, uint64, memory.Allocator, *arrow.Schema, logicalplan.IterOptions, []logicalplan.Callback) error {
+	return nil
+}
+
+func (t emptyTable) SchemaIterator(context.Context, uint64, memory.Allocator, logicalplan.IterOptions, []logicalplan.Callback) error {
+	return nil
+}
+
+func (t emptyTable) ArrowSchema(context.Context, uint64, memory.Allocator, logicalplan.IterOptions) (*arrow.Schema, error) {
+	return arrow.NewSchema(nil, nil), nil
+}
+
+func (t emptyTable) Schema() *dynparquet.Schema {
+	return t.schema
+}
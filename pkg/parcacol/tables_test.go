@@ -0,0 +1,55 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/polarsignals/frostdb"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+)
+
+func TestTablesTenants(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	col, err := frostdb.New()
+	require.NoError(t, err)
+	db, err := col.DB(ctx, "parca")
+	require.NoError(t, err)
+	schema, err := Schema()
+	require.NoError(t, err)
+
+	// Blocks persisted before a restart.
+	bucket := objstore.NewInMemBucket()
+	for _, name := range []string{"stacktraces_team-b/01GCRRD0J0Q7TSWAX8RSJ8V2C9/data.parquet", "rollups_team-c/01GCRRD0J0Q7TSWAX8RSJ8V2C9/data.parquet"} {
+		require.NoError(t, bucket.Upload(ctx, name, bytes.NewReader(nil)))
+	}
+
+	tables := NewTables(db, schema, "stacktraces", bucket)
+	_, err = tables.Table("team-a")
+	require.NoError(t, err)
+
+	// Reading the table of a tenant doesn't create it.
+	require.IsType(t, emptyTable{}, tables.GetTable("stacktraces_team-d"))
+	require.Nil(t, tables.GetTable("rollups_team-c"))
+
+	tenants, err := tables.Tenants(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-a", "team-b"}, tenants)
+}
@@ -0,0 +1,205 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"fmt"
+
+	pprofproto "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+)
+
+// The reasons a profile or a sample fails validation.
+const (
+	ReasonInvalidStringTable  = "invalid_string_table"
+	ReasonInvalidMapping      = "invalid_mapping"
+	ReasonInvalidFunction     = "invalid_function"
+	ReasonInvalidLocation     = "invalid_location"
+	ReasonMissingSampleType   = "missing_sample_type"
+	ReasonNilSample           = "nil_sample"
+	ReasonSampleValueMismatch = "sample_value_mismatch"
+	ReasonInvalidLocationID   = "invalid_location_id"
+	ReasonInvalidLabel        = "invalid_label"
+)
+
+// ValidationError is the failure of a profile, or of a single sample of it,
+// to validate.
+type ValidationError struct {
+	// Reason identifies the kind of failure.
+	Reason string
+	// Sample is the index of the invalid sample, or -1 if the profile
+	// itself is invalid.
+	Sample int
+	Err    error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func profileError(reason, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Reason: reason, Sample: -1, Err: fmt.Errorf(format, args...)}
+}
+
+func sampleError(i int, reason, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Reason: reason, Sample: i, Err: fmt.Errorf(format, args...)}
+}
+
+func validatePprofProfile(p *pprofproto.Profile) error {
+	if err := validatePprofTables(p); err != nil {
+		return err
+	}
+
+	for i, s := range p.Sample {
+		if err := validatePprofSample(p, i, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveInvalidSamples removes the samples of p that fail validation and
+// returns their validation errors, indexed by their position in the original
+// profile. If the profile itself is invalid, its validation error is returned
+// alone and p is left unchanged.
+func RemoveInvalidSamples(p *pprofproto.Profile) []*ValidationError {
+	if err := validatePprofTables(p); err != nil {
+		return []*ValidationError{err}
+	}
+
+	var errs []*ValidationError
+	valid := p.Sample[:0]
+	for i, s := range p.Sample {
+		if err := validatePprofSample(p, i, s); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		valid = append(valid, s)
+	}
+	p.Sample = valid
+	return errs
+}
+
+// validatePprofTables validates everything about a profile except its
+// samples.
+func validatePprofTables(p *pprofproto.Profile) *ValidationError {
+	stringTableLen := int64(len(p.StringTable))
+
+	if stringTableLen > 0 && p.StringTable[0] != "" {
+		return profileError(ReasonInvalidStringTable, "first item in string table is expected to be empty string, but it is %q", p.StringTable[0])
+	}
+
+	// Check that all mappings/locations/functions are in the tables
+	// Check that there are no duplicate ids
+	mappingsNum := uint64(len(p.Mapping))
+	for i, m := range p.Mapping {
+		if m == nil {
+			return profileError(ReasonInvalidMapping, "profile has nil mapping")
+		}
+		if m.Id != uint64(i+1) {
+			return profileError(ReasonInvalidMapping, "mapping id is not sequential")
+		}
+		if m.Filename != 0 && m.Filename >= stringTableLen {
+			return profileError(ReasonInvalidMapping, "mapping (id: %d) has invalid filename index %d", m.Id, m.Filename)
+		}
+		if m.BuildId != 0 && m.BuildId >= stringTableLen {
+			return profileError(ReasonInvalidMapping, "mapping (id: %d) has invalid buildid index %d", m.Id, m.BuildId)
+		}
+	}
+
+	functionsNum := uint64(len(p.Function))
+	for i, f := range p.Function {
+		if f == nil {
+			return profileError(ReasonInvalidFunction, "profile has nil function")
+		}
+		if f.Id != uint64(i+1) {
+			return profileError(ReasonInvalidFunction, "function id is not sequential")
+		}
+		if f.Name != 0 && f.Name >= stringTableLen {
+			return profileError(ReasonInvalidFunction, "function (id: %d) has invalid name index %d", f.Id, f.Name)
+		}
+		if f.SystemName != 0 && f.SystemName >= stringTableLen {
+			return profileError(ReasonInvalidFunction, "function (id: %d) has invalid name index %d", f.Id, f.SystemName)
+		}
+		if f.Filename != 0 && f.Filename >= stringTableLen {
+			return profileError(ReasonInvalidFunction, "function (id: %d) has invalid filename index %d", f.Id, f.Filename)
+		}
+	}
+
+	for i, l := range p.Location {
+		if l == nil {
+			return profileError(ReasonInvalidLocation, "profile has nil location")
+		}
+		if l.Id != uint64(i+1) {
+			return profileError(ReasonInvalidLocation, "location id is not sequential")
+		}
+		if l.MappingId != 0 && l.MappingId > mappingsNum {
+			return profileError(ReasonInvalidLocation, "location has invalid mapping id: %d", l.MappingId)
+		}
+		for _, ln := range l.Line {
+			if ln.FunctionId != 0 && ln.FunctionId > functionsNum {
+				return profileError(ReasonInvalidLocation, "location %d has invalid function id: %d", l.Id, ln.FunctionId)
+			}
+		}
+	}
+
+	if len(p.SampleType) == 0 && len(p.Sample) != 0 {
+		return profileError(ReasonMissingSampleType, "missing sample type information")
+	}
+
+	return nil
+}
+
+// validatePprofSample validates the i-th sample of a profile whose tables
+// are valid.
+func validatePprofSample(p *pprofproto.Profile, i int, s *pprofproto.Sample) *ValidationError {
+	stringTableLen := int64(len(p.StringTable))
+	locationsNum := uint64(len(p.Location))
+
+	// Check that sample values are consistent
+	if s == nil {
+		return sampleError(i, ReasonNilSample, "profile has nil sample")
+	}
+	if len(s.Value) != len(p.SampleType) {
+		return sampleError(i, ReasonSampleValueMismatch, "mismatch: sample has %d values vs. %d types", len(s.Value), len(p.SampleType))
+	}
+	for j, l := range s.LocationId {
+		if l == 0 {
+			return sampleError(i, ReasonInvalidLocationID, "location ids of stacktraces must be non-zero")
+		}
+		if l > locationsNum {
+			return sampleError(i, ReasonInvalidLocationID, "sample %d location number %d (%d) is out of range", i, j, l)
+		}
+	}
+	for j, label := range s.Label {
+		if label.Key == 0 {
+			return sampleError(i, ReasonInvalidLabel, "sample %d label %d has no key", i, j)
+		}
+		if label.Key != 0 && label.Key >= stringTableLen {
+			return sampleError(i, ReasonInvalidLabel, "sample %d label %d has invalid key index %d", i, j, label.Key)
+		}
+		if label.Str != 0 && label.Str >= stringTableLen {
+			return sampleError(i, ReasonInvalidLabel, "sample %d label %d has invalid str index %d", i, j, label.Str)
+		}
+		if label.NumUnit != 0 && label.NumUnit >= stringTableLen {
+			return sampleError(i, ReasonInvalidLabel, "sample %d label %d has invalid num unit index %d", i, j, label.NumUnit)
+		}
+	}
+
+	return nil
+}
@@ -49,6 +49,7 @@ type SymbolizedSample struct {
 	DiffValue int64
 	Label     map[string]string
 	NumLabel  map[string]int64
+	NumUnit   map[string]string
 }
 
 type NormalizedSample struct {
@@ -57,6 +58,11 @@ type NormalizedSample struct {
 	DiffValue    int64
 	Label        map[string]string
 	NumLabel     map[string]int64
+	NumUnit      map[string]string
+	// TraceID and SpanID are the hex encoded IDs of the span the sample was
+	// recorded in, if any.
+	TraceID string
+	SpanID  string
 }
 
 type Profile struct {
@@ -0,0 +1,144 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The units numeric labels of a known dimension are stored in.
+const (
+	UnitBytes       = "bytes"
+	UnitNanoseconds = "nanoseconds"
+)
+
+type numUnit struct {
+	unit  string
+	scale int64
+}
+
+// numUnits maps the lower case spellings of the units of the known
+// dimensions to their canonical unit. Like pprof, multiples of bytes are
+// powers of 1024.
+var numUnits = map[string]numUnit{}
+
+func init() {
+	for scale, spellings := range map[int64][]string{
+		1:       {"b", "byte", "bytes"},
+		1 << 10: {"kb", "kib", "kbyte", "kilobyte", "kilobytes"},
+		1 << 20: {"mb", "mib", "mbyte", "megabyte", "megabytes"},
+		1 << 30: {"gb", "gib", "gbyte", "gigabyte", "gigabytes"},
+		1 << 40: {"tb", "tib", "tbyte", "terabyte", "terabytes"},
+		1 << 50: {"pb", "pib", "pbyte", "petabyte", "petabytes"},
+	} {
+		for _, s := range spellings {
+			numUnits[s] = numUnit{unit: UnitBytes, scale: scale}
+		}
+	}
+
+	for scale, spellings := range map[int64][]string{
+		1:                            {"ns", "nanosecond", "nanoseconds"},
+		1000:                         {"us", "µs", "microsecond", "microseconds"},
+		1000 * 1000:                  {"ms", "millisecond", "milliseconds"},
+		1000 * 1000 * 1000:           {"s", "sec", "second", "seconds"},
+		60 * 1000 * 1000 * 1000:      {"m", "min", "minute", "minutes"},
+		60 * 60 * 1000 * 1000 * 1000: {"h", "hour", "hours"},
+	} {
+		for _, s := range spellings {
+			numUnits[s] = numUnit{unit: UnitNanoseconds, scale: scale}
+		}
+	}
+}
+
+// NormalizeNumLabel converts the value of a numeric label to the canonical
+// unit of its dimension, so that values of the same key are comparable no
+// matter which unit they were recorded in. Values of unknown units are
+// returned as is. Following pprof, a label named bytes without a unit is
+// in bytes. Values that don't fit into an int64 in the canonical unit are
+// clamped to the largest or smallest int64.
+func NormalizeNumLabel(key string, value int64, unit string) (int64, string) {
+	if unit == "" && key == "bytes" {
+		return value, UnitBytes
+	}
+
+	u, ok := numUnits[strings.ToLower(unit)]
+	if !ok {
+		return value, unit
+	}
+	switch {
+	case value > math.MaxInt64/u.scale:
+		return math.MaxInt64, u.unit
+	case value < math.MinInt64/u.scale:
+		return math.MinInt64, u.unit
+	}
+	return value * u.scale, u.unit
+}
+
+// ParseQuantity parses a number followed by an optional unit, for example
+// 1MiB, 1.5s or 42. Quantities of known units are returned in the canonical
+// unit of their dimension. Quantities that don't fit into an int64 in that unit
+// are rejected.
+func ParseQuantity(s string) (int64, string, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == '.' || r == '-' || r == '+')
+	})
+	if i == -1 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], strings.TrimSpace(s[i:])
+	if number == "" {
+		return 0, "", fmt.Errorf("quantity %q does not start with a number", s)
+	}
+
+	if unit == "" {
+		v, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return 0, "", fmt.Errorf("quantity %q without a unit must be an integer", s)
+		}
+		return v, "", nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("parse quantity %q: %w", s, err)
+	}
+	u, ok := numUnits[strings.ToLower(unit)]
+	if !ok {
+		if f != math.Trunc(f) {
+			return 0, "", fmt.Errorf("quantity %q of unknown unit must be an integer", s)
+		}
+		if !fitsInt64(f) {
+			return 0, "", fmt.Errorf("quantity %q is out of range", s)
+		}
+		return int64(f), unit, nil
+	}
+
+	v := math.Round(f * float64(u.scale))
+	if !fitsInt64(v) {
+		return 0, "", fmt.Errorf("quantity %q is out of range", s)
+	}
+	return int64(v), u.unit, nil
+}
+
+// fitsInt64 returns whether the integral float can be converted to an int64
+// without overflowing. The bounds are the float64 values closest to them,
+// math.MaxInt64 itself rounds up to 2^63.
+func fitsInt64(f float64) bool {
+	return f >= math.MinInt64 && f < math.MaxInt64
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuantity(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in    string
+		value int64
+		unit  string
+	}{
+		{in: "42", value: 42},
+		{in: "1MiB", value: 1 << 20, unit: UnitBytes},
+		{in: "1.5 kb", value: 1536, unit: UnitBytes},
+		{in: "250ms", value: 250000000, unit: UnitNanoseconds},
+		{in: "2h", value: 7200000000000, unit: UnitNanoseconds},
+		{in: "3 requests", value: 3, unit: "requests"},
+	}
+	for _, c := range cases {
+		value, unit, err := ParseQuantity(c.in)
+		require.NoError(t, err, c.in)
+		require.Equal(t, c.value, value, c.in)
+		require.Equal(t, c.unit, unit, c.in)
+	}
+
+	for _, in := range []string{"", "MiB", "1.5", "1.5 requests", "3000000h", "-9000PB", "10000000000000000000 requests", "9223372036854775808"} {
+		_, _, err := ParseQuantity(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestNormalizeNumLabel(t *testing.T) {
+	t.Parallel()
+
+	value, unit := NormalizeNumLabel("alloc", 2, "KB")
+	require.Equal(t, int64(2048), value)
+	require.Equal(t, UnitBytes, unit)
+
+	value, unit = NormalizeNumLabel("bytes", 512, "")
+	require.Equal(t, int64(512), value)
+	require.Equal(t, UnitBytes, unit)
+
+	value, unit = NormalizeNumLabel("requests", 3, "")
+	require.Equal(t, int64(3), value)
+	require.Equal(t, "", unit)
+
+	// Values that overflow in the canonical unit are clamped.
+	value, unit = NormalizeNumLabel("duration", 3000000, "h")
+	require.Equal(t, int64(math.MaxInt64), value)
+	require.Equal(t, UnitNanoseconds, unit)
+
+	value, _ = NormalizeNumLabel("alloc", -1<<24, "TB")
+	require.Equal(t, int64(math.MinInt64), value)
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// ErrDecompressedSizeExceeded is returned when a payload decompresses to more
+// than the allowed number of bytes.
+var ErrDecompressedSizeExceeded = errors.New("decompressed size exceeds limit")
+
+// Encoding is the compression of a raw profile payload.
+type Encoding int
+
+const (
+	EncodingNone Encoding = iota
+	EncodingGzip
+	EncodingZstd
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case EncodingGzip:
+		return "gzip"
+	case EncodingZstd:
+		return "zstd"
+	default:
+		return "none"
+	}
+}
+
+// DetectEncoding detects the compression of a payload from its magic bytes.
+// Payloads that are neither gzip nor zstd compressed are assumed to be
+// uncompressed.
+func DetectEncoding(b []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(b, gzipMagic):
+		return EncodingGzip
+	case bytes.HasPrefix(b, zstdMagic):
+		return EncodingZstd
+	default:
+		return EncodingNone
+	}
+}
+
+// Decompress decompresses a gzip, zstd or uncompressed payload. If maxSize is
+// greater than zero and the payload decompresses to more than maxSize bytes,
+// ErrDecompressedSizeExceeded is returned.
+func Decompress(b []byte, maxSize int64) ([]byte, Encoding, error) {
+	enc := DetectEncoding(b)
+
+	var r io.Reader
+	switch enc {
+	case EncodingGzip:
+		gr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, enc, fmt.Errorf("create gzip reader: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	case EncodingZstd:
+		zr, err := zstd.NewReader(bytes.NewReader(b), zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, enc, fmt.Errorf("create zstd reader: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		if maxSize > 0 && int64(len(b)) > maxSize {
+			return nil, enc, ErrDecompressedSizeExceeded
+		}
+		return b, enc, nil
+	}
+
+	if maxSize > 0 {
+		// Read one byte more than allowed to be able to tell whether the
+		// limit was exceeded.
+		r = io.LimitReader(r, maxSize+1)
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, enc, fmt.Errorf("decompress %s payload: %w", enc, err)
+	}
+	if maxSize > 0 && int64(len(content)) > maxSize {
+		return nil, enc, ErrDecompressedSizeExceeded
+	}
+
+	return content, enc, nil
+}
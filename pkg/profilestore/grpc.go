@@ -15,12 +15,15 @@ package profilestore
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"google.golang.org/grpc"
 
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 // GRPCForwarder forward profiles via gRPC to another Parca instance
@@ -42,9 +45,72 @@ func NewGRPCForwarder(conn grpc.ClientConnInterface, logger log.Logger) *GRPCFor
 func (s *GRPCForwarder) WriteRaw(ctx context.Context, req *profilestorepb.WriteRawRequest) (*profilestorepb.WriteRawResponse, error) {
 	// TODO: Batch writes to only send a request every now and then.
 	// See https://github.com/parca-dev/parca-agent/blob/main/pkg/agent/write_client.go#L28
-	resp, err := s.client.WriteRaw(ctx, req)
+	resp, err := s.client.WriteRaw(tenant.NewOutgoingContext(ctx), req)
 	if err != nil {
 		level.Warn(s.logger).Log("msg", "failed to forward profiles", "err", err)
 	}
 	return resp, err
 }
+
+func (s *GRPCForwarder) WriteFolded(ctx context.Context, req *profilestorepb.WriteFoldedRequest) (*profilestorepb.WriteFoldedResponse, error) {
+	resp, err := s.client.WriteFolded(tenant.NewOutgoingContext(ctx), req)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to forward folded profile", "err", err)
+	}
+	return resp, err
+}
+
+func (s *GRPCForwarder) WriteJFR(ctx context.Context, req *profilestorepb.WriteJFRRequest) (*profilestorepb.WriteJFRResponse, error) {
+	resp, err := s.client.WriteJFR(tenant.NewOutgoingContext(ctx), req)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to forward jfr recording", "err", err)
+	}
+	return resp, err
+}
+
+// WriteRawStream forwards the windows of the stream to the upstream Parca
+// instance and relays its acknowledgements back to the client.
+func (s *GRPCForwarder) WriteRawStream(stream profilestorepb.ProfileStoreService_WriteRawStreamServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	upstream, err := s.client.WriteRawStream(tenant.NewOutgoingContext(ctx))
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to open forwarding stream", "err", err)
+		return err
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				errc <- upstream.CloseSend()
+				return
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+			if err := upstream.Send(req); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		resp, err := upstream.Recv()
+		if errors.Is(err, io.EOF) {
+			// The upstream only closes its side once the client has.
+			return <-errc
+		}
+		if err != nil {
+			level.Warn(s.logger).Log("msg", "failed to forward profiles", "err", err)
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+// HATracker deduplicates the profiles written by the replicas of highly
+// available scrapers. Of every cluster of a tenant it elects the first
+// replica it sees and only accepts the profiles of the elected replica. Once
+// the elected replica hasn't written for the failover timeout, the next
+// replica to write is elected.
+type HATracker struct {
+	mtx      sync.Mutex
+	cfg      *config.HATracker
+	clusters map[haCluster]*haReplica
+
+	elections *prometheus.CounterVec
+	dropped   *prometheus.CounterVec
+	now       func() time.Time
+}
+
+type haCluster struct {
+	tenant  string
+	cluster string
+}
+
+type haReplica struct {
+	name     string
+	lastSeen time.Time
+}
+
+// NewHATracker returns a disabled HATracker.
+func NewHATracker(reg prometheus.Registerer) *HATracker {
+	return &HATracker{
+		clusters: map[haCluster]*haReplica{},
+		elections: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "profilestore_ha_elected_replica_changes_total",
+				Help: "Total number of times the elected replica of a cluster changed, partitioned by tenant and cluster.",
+			},
+			[]string{"tenant", "cluster"},
+		),
+		dropped: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "profilestore_ha_deduplicated_writes_total",
+				Help: "Total number of writes dropped because they were sent by a replica that is not elected, partitioned by tenant and cluster.",
+			},
+			[]string{"tenant", "cluster"},
+		),
+		now: time.Now,
+	}
+}
+
+// ApplyConfig replaces the configuration of the tracker, a nil configuration
+// disables deduplication. Elected replicas are kept as long as the labels
+// identifying them don't change.
+func (t *HATracker) ApplyConfig(cfg *config.HATracker) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if cfg == nil || t.cfg == nil || cfg.ClusterLabel != t.cfg.ClusterLabel || cfg.ReplicaLabel != t.cfg.ReplicaLabel {
+		t.clusters = map[haCluster]*haReplica{}
+	}
+	t.cfg = cfg
+	return nil
+}
+
+// Accept returns the labels a series written by the tenant is stored with,
+// that is without the replica label, or nil if the write was sent by a
+// replica that is not elected and must be dropped. Series without the replica
+// label are always accepted.
+func (t *HATracker) Accept(tenantID string, ls labels.Labels) labels.Labels {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.cfg == nil {
+		return ls
+	}
+
+	replica := ls.Get(t.cfg.ReplicaLabel)
+	if replica == "" {
+		return ls
+	}
+
+	key := haCluster{tenant: tenantID, cluster: ls.Get(t.cfg.ClusterLabel)}
+	now := t.now()
+	elected, ok := t.clusters[key]
+	switch {
+	case !ok:
+		t.clusters[key] = &haReplica{name: replica, lastSeen: now}
+	case elected.name == replica:
+		elected.lastSeen = now
+	case now.Sub(elected.lastSeen) > time.Duration(t.cfg.FailoverTimeout):
+		elected.name = replica
+		elected.lastSeen = now
+		t.elections.WithLabelValues(key.tenant, key.cluster).Inc()
+	default:
+		t.dropped.WithLabelValues(key.tenant, key.cluster).Inc()
+		return nil
+	}
+
+	return labels.NewBuilder(ls).Del(t.cfg.ReplicaLabel).Labels()
+}
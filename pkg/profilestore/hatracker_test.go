@@ -0,0 +1,113 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+func TestHATracker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	tr := NewHATracker(prometheus.NewRegistry())
+	tr.now = func() time.Time { return now }
+
+	replica := func(cluster, replica string) labels.Labels {
+		return labels.FromStrings("__name__", "memory", "cluster", cluster, "__replica__", replica)
+	}
+	stored := func(cluster string) labels.Labels {
+		return labels.FromStrings("__name__", "memory", "cluster", cluster)
+	}
+
+	// Disabled trackers accept everything as is.
+	require.Equal(t, replica("a", "1"), tr.Accept("default", replica("a", "1")))
+
+	require.NoError(t, tr.ApplyConfig(&config.HATracker{
+		ClusterLabel:    "cluster",
+		ReplicaLabel:    "__replica__",
+		FailoverTimeout: model.Duration(30 * time.Second),
+	}))
+
+	// The first replica of a cluster is elected.
+	require.Equal(t, stored("a"), tr.Accept("default", replica("a", "1")))
+	require.Nil(t, tr.Accept("default", replica("a", "2")))
+	require.Equal(t, 1.0, testutil.ToFloat64(tr.dropped.WithLabelValues("default", "a")))
+
+	// Clusters and tenants elect replicas independently.
+	require.Equal(t, stored("b"), tr.Accept("default", replica("b", "2")))
+	require.Equal(t, stored("a"), tr.Accept("team-a", replica("a", "2")))
+
+	// Series without a replica label are not deduplicated.
+	require.Equal(t, stored("a"), tr.Accept("default", stored("a")))
+
+	// Writes of the elected replica postpone the failover.
+	now = now.Add(20 * time.Second)
+	require.NotNil(t, tr.Accept("default", replica("a", "1")))
+	now = now.Add(20 * time.Second)
+	require.Nil(t, tr.Accept("default", replica("a", "2")))
+
+	// Once the elected replica stops writing, the next one takes over.
+	now = now.Add(31 * time.Second)
+	require.Equal(t, stored("a"), tr.Accept("default", replica("a", "2")))
+	require.Nil(t, tr.Accept("default", replica("a", "1")))
+	require.Equal(t, 1.0, testutil.ToFloat64(tr.elections.WithLabelValues("default", "a")))
+}
+
+func TestWriteRawHATracker(t *testing.T) {
+	t.Parallel()
+
+	raw, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	api := newTestProfileColumnStore(t, 0)
+	require.NoError(t, api.ApplyHATrackerConfig(&config.HATracker{
+		ClusterLabel:    "cluster",
+		ReplicaLabel:    "__replica__",
+		FailoverTimeout: model.Duration(time.Minute),
+	}))
+
+	write := func(replica string, raw []byte) error {
+		_, err := api.WriteRaw(context.Background(), &profilestorepb.WriteRawRequest{
+			Series: []*profilestorepb.RawProfileSeries{{
+				Labels: &profilestorepb.LabelSet{
+					Labels: []*profilestorepb.Label{
+						{Name: "__name__", Value: "memory"},
+						{Name: "__replica__", Value: replica},
+						{Name: "cluster", Value: "a"},
+					},
+				},
+				Samples: []*profilestorepb.RawSample{{RawProfile: raw}},
+			}},
+		})
+		return err
+	}
+
+	require.NoError(t, write("1", raw))
+	// The profiles of the other replica are dropped before they are parsed.
+	require.NoError(t, write("2", []byte("not a profile")))
+	require.Error(t, write("1", []byte("not a profile")))
+}
@@ -0,0 +1,280 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/model/labels"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+const (
+	limitMaxProfileSize       = "max_profile_size"
+	limitMaxSamplesPerProfile = "max_samples_per_profile"
+	limitMaxStackDepth        = "max_stack_depth"
+	limitMaxActiveSeries      = "max_active_series"
+	limitIngestionRate        = "ingestion_rate"
+
+	// activeSeriesPeriod is the time after which a series that hasn't been
+	// written to no longer counts towards the active series limit.
+	activeSeriesPeriod = time.Hour
+)
+
+// Limiter enforces the configured limits on the profiles written by each
+// tenant. Its limits can be replaced at runtime.
+type Limiter struct {
+	mtx       sync.Mutex
+	defaults  *config.Limits
+	overrides map[string]*config.Limits
+	tenants   map[string]*tenantLimiter
+
+	rejections *prometheus.CounterVec
+	now        func() time.Time
+}
+
+type tenantLimiter struct {
+	limits config.Limits
+	rate   *rate.Limiter
+
+	// series holds the time every active series was last written to.
+	series    map[uint64]time.Time
+	lastPurge time.Time
+	// pendingSeries is the number of reserved writes to series that are
+	// not active yet.
+	pendingSeries int
+}
+
+// NewLimiter returns a Limiter without any limits.
+func NewLimiter(reg prometheus.Registerer) *Limiter {
+	return &Limiter{
+		overrides: map[string]*config.Limits{},
+		tenants:   map[string]*tenantLimiter{},
+		rejections: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "profilestore_limit_rejections_total",
+				Help: "Total number of profiles rejected because they exceeded a limit, partitioned by tenant and limit.",
+			},
+			[]string{"tenant", "limit"},
+		),
+		now: time.Now,
+	}
+}
+
+// ApplyConfig replaces the default limits and the limits of individual
+// tenants.
+func (l *Limiter) ApplyConfig(defaults *config.Limits, overrides map[string]*config.Limits) error {
+	for id := range overrides {
+		if err := tenant.Validate(id); err != nil {
+			return fmt.Errorf("tenant limits: %w", err)
+		}
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.defaults = defaults
+	l.overrides = overrides
+	for id, t := range l.tenants {
+		t.setLimits(overrides[id].Merge(defaults))
+	}
+	return nil
+}
+
+func (l *Limiter) tenant(id string) *tenantLimiter {
+	t, ok := l.tenants[id]
+	if !ok {
+		t = &tenantLimiter{
+			series:    map[uint64]time.Time{},
+			lastPurge: l.now(),
+		}
+		t.setLimits(l.overrides[id].Merge(l.defaults))
+		l.tenants[id] = t
+	}
+	return t
+}
+
+func (t *tenantLimiter) setLimits(limits config.Limits) {
+	if t.rate != nil && limits.IngestionRateBytes == t.limits.IngestionRateBytes && limits.IngestionBurstBytes == t.limits.IngestionBurstBytes {
+		t.limits = limits
+		return
+	}
+
+	t.limits = limits
+	t.rate = nil
+	if limits.IngestionRateBytes > 0 {
+		burst := limits.IngestionBurstBytes
+		if burst == 0 {
+			burst = limits.IngestionRateBytes
+		}
+		t.rate = rate.NewLimiter(rate.Limit(limits.IngestionRateBytes), int(burst))
+	}
+}
+
+func (l *Limiter) reject(id, limit, format string, args ...interface{}) error {
+	l.rejections.WithLabelValues(id, limit).Inc()
+	return status.Errorf(codes.ResourceExhausted, "%s limit exceeded: %s", limit, fmt.Sprintf(format, args...))
+}
+
+// Reservation holds the share of the ingestion rate and active series limits
+// of a profile while it is written. It is committed once the profile is
+// stored and canceled otherwise, so rejected and failed writes don't count
+// towards the limits.
+type Reservation struct {
+	l      *Limiter
+	t      *tenantLimiter
+	rate   *rate.Reservation
+	series uint64
+	// newSeries is set if the series was not active when the profile was
+	// reserved.
+	newSeries bool
+	done      bool
+}
+
+// MaxProfileSize returns the maximum size in bytes of a profile of the tenant
+// as it was received, zero if it is not limited.
+func (l *Limiter) MaxProfileSize(id string) int64 {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return l.tenant(id).limits.MaxProfileSizeBytes
+}
+
+// Reserve checks a profile of size bytes, as it was received, of the series
+// against the size, ingestion rate and active series limits of the tenant
+// and reserves its share of them. Writes to series that are already active
+// are always accepted.
+func (l *Limiter) Reserve(id string, size int, ls labels.Labels) (*Reservation, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	t := l.tenant(id)
+	now := l.now()
+	if max := t.limits.MaxProfileSizeBytes; max > 0 && int64(size) > max {
+		return nil, l.reject(id, limitMaxProfileSize, "profile of %d bytes is larger than %d bytes", size, max)
+	}
+
+	if now.Sub(t.lastPurge) > activeSeriesPeriod/10 {
+		for hash, last := range t.series {
+			if now.Sub(last) > activeSeriesPeriod {
+				delete(t.series, hash)
+			}
+		}
+		t.lastPurge = now
+	}
+
+	r := &Reservation{l: l, t: t, series: ls.Hash()}
+	if _, ok := t.series[r.series]; !ok {
+		if max := t.limits.MaxActiveSeries; max > 0 && len(t.series)+t.pendingSeries >= max {
+			return nil, l.reject(id, limitMaxActiveSeries, "tenant has %d active series", len(t.series)+t.pendingSeries)
+		}
+		r.newSeries = true
+	}
+
+	if t.rate != nil {
+		r.rate = t.rate.ReserveN(now, size)
+		if !r.rate.OK() || r.rate.DelayFrom(now) > 0 {
+			r.rate.CancelAt(now)
+			return nil, l.reject(id, limitIngestionRate, "rate of %d bytes/s exceeded", t.limits.IngestionRateBytes)
+		}
+	}
+
+	if r.newSeries {
+		t.pendingSeries++
+	}
+	return r, nil
+}
+
+// Commit records the write of the profile. It has no effect if the
+// reservation was already committed or canceled.
+func (r *Reservation) Commit() {
+	r.l.mtx.Lock()
+	defer r.l.mtx.Unlock()
+
+	if r.done {
+		return
+	}
+	r.done = true
+	if r.newSeries {
+		r.t.pendingSeries--
+	}
+	r.t.series[r.series] = r.l.now()
+}
+
+// Cancel returns the reserved share of the limits. It has no effect if the
+// reservation was already committed or canceled.
+func (r *Reservation) Cancel() {
+	r.l.mtx.Lock()
+	defer r.l.mtx.Unlock()
+
+	if r.done {
+		return
+	}
+	r.done = true
+	if r.newSeries {
+		r.t.pendingSeries--
+	}
+	if r.rate != nil {
+		r.rate.CancelAt(r.l.now())
+	}
+}
+
+// CheckProfile checks the samples of a profile against the sample and stack
+// depth limits of the tenant.
+func (l *Limiter) CheckProfile(id string, p *pprofpb.Profile) error {
+	depth := 0
+	for _, s := range p.Sample {
+		if len(s.LocationId) > depth {
+			depth = len(s.LocationId)
+		}
+	}
+	return l.checkSamples(id, len(p.Sample), depth)
+}
+
+// CheckFolded checks folded stacks against the sample and stack depth limits
+// of the tenant.
+func (l *Limiter) CheckFolded(id string, stacks []parcacol.FoldedStack) error {
+	depth := 0
+	for _, s := range stacks {
+		if len(s.Frames) > depth {
+			depth = len(s.Frames)
+		}
+	}
+	return l.checkSamples(id, len(stacks), depth)
+}
+
+func (l *Limiter) checkSamples(id string, samples, depth int) error {
+	l.mtx.Lock()
+	limits := l.tenant(id).limits
+	l.mtx.Unlock()
+
+	if max := limits.MaxSamplesPerProfile; max > 0 && samples > max {
+		return l.reject(id, limitMaxSamplesPerProfile, "profile has %d samples, more than %d", samples, max)
+	}
+	if max := limits.MaxStackDepth; max > 0 && depth > max {
+		return l.reject(id, limitMaxStackDepth, "stack of depth %d is deeper than %d", depth, max)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+func requireRejected(t *testing.T, l *Limiter, err error, id, limit string) {
+	t.Helper()
+
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Contains(t, st.Message(), limit)
+	require.Equal(t, 1.0, testutil.ToFloat64(l.rejections.WithLabelValues(id, limit)))
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	l := NewLimiter(prometheus.NewRegistry())
+	l.now = func() time.Time { return now }
+
+	require.NoError(t, l.ApplyConfig(&config.Limits{
+		MaxProfileSizeBytes:  100,
+		MaxSamplesPerProfile: 2,
+		MaxStackDepth:        3,
+		MaxActiveSeries:      2,
+		IngestionRateBytes:   100,
+		IngestionBurstBytes:  150,
+	}, map[string]*config.Limits{
+		"team-b": {MaxProfileSizeBytes: 1000},
+	}))
+	require.Error(t, l.ApplyConfig(nil, map[string]*config.Limits{"team b": {}}))
+
+	reserve := func(id string, size int, name string) error {
+		r, err := l.Reserve(id, size, labels.FromStrings("__name__", name))
+		if err != nil {
+			return err
+		}
+		r.Commit()
+		return nil
+	}
+
+	// Size and rate.
+	requireRejected(t, l, reserve("team-a", 101, "a"), "team-a", limitMaxProfileSize)
+	require.NoError(t, reserve("team-a", 100, "a"))
+	requireRejected(t, l, reserve("team-a", 100, "a"), "team-a", limitIngestionRate)
+	now = now.Add(time.Second)
+	require.NoError(t, reserve("team-a", 100, "a"))
+
+	// Overrides fall back to the defaults for unset limits.
+	require.NoError(t, reserve("team-b", 120, "a"))
+	requireRejected(t, l, reserve("team-b", 1001, "a"), "team-b", limitMaxProfileSize)
+
+	// Samples and stack depth.
+	require.NoError(t, l.CheckProfile("team-a", &pprofpb.Profile{
+		Sample: []*pprofpb.Sample{{LocationId: []uint64{1, 2, 3}}},
+	}))
+	requireRejected(t, l, l.CheckProfile("team-a", &pprofpb.Profile{
+		Sample: []*pprofpb.Sample{{}, {}, {}},
+	}), "team-a", limitMaxSamplesPerProfile)
+	requireRejected(t, l, l.CheckProfile("team-a", &pprofpb.Profile{
+		Sample: []*pprofpb.Sample{{LocationId: []uint64{1, 2, 3, 4}}},
+	}), "team-a", limitMaxStackDepth)
+
+	requireRejected(t, l, l.CheckFolded("team-c", []parcacol.FoldedStack{
+		{Frames: []string{"a", "b", "c", "d"}},
+	}), "team-c", limitMaxStackDepth)
+
+	// Active series.
+	require.NoError(t, reserve("team-a", 0, "b"))
+	require.NoError(t, reserve("team-a", 0, "a"))
+	requireRejected(t, l, reserve("team-a", 0, "c"), "team-a", limitMaxActiveSeries)
+	require.NoError(t, reserve("team-b", 0, "c"))
+
+	// Series become inactive when they haven't been written to for a while.
+	now = now.Add(activeSeriesPeriod + time.Minute)
+	require.NoError(t, reserve("team-a", 0, "c"))
+
+	// Removing the limits takes effect immediately.
+	require.NoError(t, l.ApplyConfig(nil, nil))
+	require.NoError(t, reserve("team-a", 1000, "d"))
+	require.NoError(t, reserve("team-a", 1000, "e"))
+}
+
+func TestLimiterReservations(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	l := NewLimiter(prometheus.NewRegistry())
+	l.now = func() time.Time { return now }
+	require.NoError(t, l.ApplyConfig(&config.Limits{
+		MaxActiveSeries:    1,
+		IngestionRateBytes: 100,
+	}, nil))
+
+	a := labels.FromStrings("__name__", "a")
+	b := labels.FromStrings("__name__", "b")
+
+	// Reserved series count towards the active series limit.
+	r, err := l.Reserve("team-a", 100, a)
+	require.NoError(t, err)
+	_, err = l.Reserve("team-a", 0, b)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Canceling returns the share of the rate and of the series.
+	r.Cancel()
+	r, err = l.Reserve("team-a", 100, b)
+	require.NoError(t, err)
+	r.Commit()
+	r.Cancel()
+
+	_, err = l.Reserve("team-a", 0, a)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = l.Reserve("team-a", 1, b)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestWriteRawLimits(t *testing.T) {
+	t.Parallel()
+
+	api := newTestProfileColumnStore(t, 0)
+	require.NoError(t, api.ApplyLimits(&config.Limits{MaxSamplesPerProfile: 1}, nil))
+
+	raw, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	ctx := tenant.NewContext(context.Background(), "team-a")
+	_, err = api.WriteRaw(ctx, &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels: &profilestorepb.LabelSet{
+				Labels: []*profilestorepb.Label{{
+					Name:  "__name__",
+					Value: "memory",
+				}},
+			},
+			Samples: []*profilestorepb.RawSample{{
+				RawProfile: raw,
+			}},
+		}},
+	})
+	requireRejected(t, api.limiter, err, "team-a", limitMaxSamplesPerProfile)
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"github.com/parca-dev/parca/pkg/parcacol"
+)
+
+// Option configures optional behavior of a ProfileColumnStore.
+type Option func(*ProfileColumnStore)
+
+// WithNormalizerCache shares the cache between the normalizers of all writes.
+func WithNormalizerCache(c *parcacol.NormalizerCache) Option {
+	return func(s *ProfileColumnStore) {
+		s.normalizerCache = c
+	}
+}
+
+// WithSampleLabelFilter filters the pprof labels of the samples of written
+// profiles.
+func WithSampleLabelFilter(f *parcacol.SampleLabelFilter) Option {
+	return func(s *ProfileColumnStore) {
+		s.sampleLabels = f
+	}
+}
+
+// WithDebugValueLog writes every raw profile to the debug value log.
+func WithDebugValueLog(enabled bool) Option {
+	return func(s *ProfileColumnStore) {
+		s.debugValueLog = enabled
+	}
+}
+
+// WithMaxDecompressedSize limits the number of bytes a raw profile may
+// decompress to, zero disables the limit.
+func WithMaxDecompressedSize(size int64) Option {
+	return func(s *ProfileColumnStore) {
+		s.maxDecompressedSize = size
+	}
+}
+
+// WithRollups adds profiles written after their time was rolled up to the
+// rollups.
+func WithRollups(r *parcacol.Rollups) Option {
+	return func(s *ProfileColumnStore) {
+		s.rollups = r
+	}
+}
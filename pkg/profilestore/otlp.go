@@ -0,0 +1,221 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/prometheus/model/labels"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	otelcollectorpb "github.com/parca-dev/parca/gen/proto/go/opentelemetry/proto/collector/profiles/v1experimental"
+	otelpb "github.com/parca-dev/parca/gen/proto/go/opentelemetry/proto/profiles/v1experimental"
+	"github.com/parca-dev/parca/pkg/otlp"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+const (
+	// OTLPHTTPPath is the path OTLP/HTTP exporters send profiles to.
+	OTLPHTTPPath = "/v1experimental/profiles"
+
+	// otlpProfileName is the name profiles received via OTLP are stored
+	// with, they are told apart by their sample and period types.
+	otlpProfileName = "otlp"
+
+	// maxReportedErrors is the number of errors of rejected profiles that are
+	// included in the partial success of an export response.
+	maxReportedErrors = 5
+)
+
+// OTLPProfilesService receives OpenTelemetry profiles via gRPC and OTLP/HTTP.
+// Resource attributes become the labels of the series and sample attributes
+// the pprof labels of the samples. Profiles are written to the store like
+// any other write, subject to its relabeling, HA deduplication and limits.
+type OTLPProfilesService struct {
+	otelcollectorpb.UnimplementedProfilesServiceServer
+
+	logger log.Logger
+	tracer trace.Tracer
+	store  *ProfileColumnStore
+}
+
+var _ otelcollectorpb.ProfilesServiceServer = &OTLPProfilesService{}
+
+func NewOTLPProfilesService(
+	logger log.Logger,
+	tracer trace.Tracer,
+	store *ProfileColumnStore,
+) *OTLPProfilesService {
+	return &OTLPProfilesService{
+		logger: logger,
+		tracer: tracer,
+		store:  store,
+	}
+}
+
+func (s *OTLPProfilesService) Export(ctx context.Context, req *otelcollectorpb.ExportProfilesServiceRequest) (*otelcollectorpb.ExportProfilesServiceResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "otlp-export")
+	defer span.End()
+
+	ingester, err := s.store.ingester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		rejected int64
+		errs     []string
+	)
+	reject := func(err error) {
+		rejected++
+		if len(errs) < maxReportedErrors {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	for _, rp := range req.ResourceProfiles {
+		ls := append(otlp.LabelsFromAttributes(rp.GetResource().GetAttributes()), labels.Label{
+			Name:  labels.MetricName,
+			Value: otlpProfileName,
+		})
+		ls = s.store.seriesLabels(ctx, labels.New(ls...))
+		if ls == nil {
+			continue
+		}
+
+		for _, sp := range rp.ScopeProfiles {
+			for _, pc := range sp.Profiles {
+				if err := s.exportProfile(ctx, ingester, ls, pc); err != nil {
+					reject(err)
+				}
+			}
+		}
+	}
+
+	resp := &otelcollectorpb.ExportProfilesServiceResponse{}
+	if rejected > 0 {
+		level.Debug(s.logger).Log("msg", "rejected otlp profiles", "count", rejected, "errs", strings.Join(errs, "; "))
+		resp.PartialSuccess = &otelcollectorpb.ExportProfilesPartialSuccess{
+			RejectedProfiles: rejected,
+			ErrorMessage:     strings.Join(errs, "; "),
+		}
+	}
+
+	return resp, nil
+}
+
+// exportProfile converts a single OpenTelemetry profile and writes it to the
+// series ls.
+func (s *OTLPProfilesService) exportProfile(ctx context.Context, ingester *parcacol.Ingester, ls labels.Labels, pc *otelpb.ProfileContainer) error {
+	tenantID := tenant.FromContext(ctx)
+	res, err := s.store.limiter.Reserve(tenantID, pc.SizeVT(), ls)
+	if err != nil {
+		return err
+	}
+	defer res.Cancel()
+
+	p, err := otlp.ToPprof(pc.Profile, pc.StartTimeUnixNano, pc.EndTimeUnixNano)
+	if err != nil {
+		return fmt.Errorf("convert profile: %w", err)
+	}
+	if err := s.store.limiter.CheckProfile(tenantID, p); err != nil {
+		return err
+	}
+	s.store.sampleLabels.Filter(tenantID, p)
+
+	if err := ingester.Ingest(ctx, ls, p, false); err != nil {
+		return err
+	}
+	res.Commit()
+
+	return nil
+}
+
+// ServeHTTP implements the OTLP/HTTP protocol for profiles. Requests may be
+// encoded as binary protobuf or JSON and may be gzip or zstd compressed.
+func (s *OTLPProfilesService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (contentType != "application/x-protobuf" && contentType != "application/json") {
+		http.Error(w, "unsupported content type, expected application/x-protobuf or application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	// A request holds many profiles, so its size is bounded by the size it
+	// may decompress to rather than by the profile size limit.
+	body, err := readBody(w, r, s.store.maxDecompressedSize)
+	if err != nil {
+		writeBodyError(w, err)
+		return
+	}
+
+	switch r.Header.Get("Content-Encoding") {
+	case "", "identity", "gzip", "zstd":
+		// Compression is detected from the payload itself.
+		body, _, err = Decompress(body, s.store.maxDecompressedSize)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to decompress request body: %v", err), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "unsupported content encoding", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	req := &otelcollectorpb.ExportProfilesServiceRequest{}
+	if contentType == "application/json" {
+		err = protojson.Unmarshal(body, req)
+	} else {
+		err = req.UnmarshalVT(body)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.Export(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var out []byte
+	if contentType == "application/json" {
+		out, err = protojson.Marshal(resp)
+	} else {
+		out, err = resp.MarshalVT()
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode response: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(out); err != nil {
+		level.Debug(s.logger).Log("msg", "failed to write otlp response", "err", err)
+	}
+}
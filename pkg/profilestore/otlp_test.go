@@ -0,0 +1,182 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	otelcollectorpb "github.com/parca-dev/parca/gen/proto/go/opentelemetry/proto/collector/profiles/v1experimental"
+	otelpb "github.com/parca-dev/parca/gen/proto/go/opentelemetry/proto/profiles/v1experimental"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+func newTestOTLPProfilesService(t *testing.T) *OTLPProfilesService {
+	t.Helper()
+
+	store := newTestProfileColumnStore(t, 0)
+	return NewOTLPProfilesService(
+		log.NewNopLogger(),
+		trace.NewNoopTracerProvider().Tracer(""),
+		store,
+	)
+}
+
+func testExportRequest(profiles ...*otelpb.Profile) *otelcollectorpb.ExportProfilesServiceRequest {
+	containers := make([]*otelpb.ProfileContainer, 0, len(profiles))
+	for _, p := range profiles {
+		containers = append(containers, &otelpb.ProfileContainer{
+			StartTimeUnixNano: 1_000_000_000,
+			EndTimeUnixNano:   11_000_000_000,
+			Profile:           p,
+		})
+	}
+
+	return &otelcollectorpb.ExportProfilesServiceRequest{
+		ResourceProfiles: []*otelpb.ResourceProfiles{{
+			Resource: &resourcepb.Resource{
+				Attributes: []*commonpb.KeyValue{{
+					Key:   "service.name",
+					Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "checkout"}},
+				}},
+			},
+			ScopeProfiles: []*otelpb.ScopeProfiles{{
+				Profiles: containers,
+			}},
+		}},
+	}
+}
+
+func testOTLPProfile() *otelpb.Profile {
+	return &otelpb.Profile{
+		StringTable: []string{"", "samples", "count", "cpu", "nanoseconds", "main", "thread.name", "worker"},
+		SampleType:  []*otelpb.ValueType{{Type: 1, Unit: 2}},
+		PeriodType:  &otelpb.ValueType{Type: 3, Unit: 4},
+		Period:      10000000,
+		Function:    []*otelpb.Function{{Name: 5}},
+		Location: []*otelpb.Location{{
+			Line: []*otelpb.Line{{FunctionIndex: 0, Line: 1}},
+		}},
+		AttributeTable: []*commonpb.KeyValue{{
+			Key:   "thread.name",
+			Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "worker"}},
+		}},
+		Sample: []*otelpb.Sample{{
+			LocationIndex: []uint64{0},
+			Value:         []int64{5},
+			Attributes:    []uint64{0},
+		}},
+	}
+}
+
+func TestOTLPExport(t *testing.T) {
+	t.Parallel()
+
+	s := newTestOTLPProfilesService(t)
+
+	invalid := testOTLPProfile()
+	invalid.Sample[0].LocationIndex = []uint64{3}
+
+	resp, err := s.Export(context.Background(), testExportRequest(testOTLPProfile(), invalid))
+	require.NoError(t, err)
+	require.NotNil(t, resp.PartialSuccess)
+	require.Equal(t, int64(1), resp.PartialSuccess.RejectedProfiles)
+	require.Contains(t, resp.PartialSuccess.ErrorMessage, "unknown location 3")
+
+	resp, err = s.Export(context.Background(), testExportRequest(testOTLPProfile()))
+	require.NoError(t, err)
+	require.Nil(t, resp.PartialSuccess)
+
+	// Profiles are subject to the limits of the tenant.
+	require.NoError(t, s.store.ApplyLimits(&config.Limits{MaxProfileSizeBytes: 1}, nil))
+	resp, err = s.Export(context.Background(), testExportRequest(testOTLPProfile()))
+	require.NoError(t, err)
+	require.NotNil(t, resp.PartialSuccess)
+	require.Equal(t, int64(1), resp.PartialSuccess.RejectedProfiles)
+	require.Contains(t, resp.PartialSuccess.ErrorMessage, limitMaxProfileSize)
+
+	// Series dropped by the write relabeling rules aren't written at all.
+	require.NoError(t, s.store.ApplyWriteRelabelConfigs([]*relabel.Config{{
+		SourceLabels: model.LabelNames{labels.MetricName},
+		Regex:        relabel.MustNewRegexp(otlpProfileName),
+		Action:       relabel.Drop,
+	}}))
+	resp, err = s.Export(context.Background(), testExportRequest(testOTLPProfile()))
+	require.NoError(t, err)
+	require.Nil(t, resp.PartialSuccess)
+}
+
+func TestOTLPHTTP(t *testing.T) {
+	t.Parallel()
+
+	s := newTestOTLPProfilesService(t)
+
+	body, err := testExportRequest(testOTLPProfile()).MarshalVT()
+	require.NoError(t, err)
+
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, err = w.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, OTLPHTTPPath, &compressed)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/x-protobuf", rec.Header().Get("Content-Type"))
+
+	jsonBody, err := protojson.Marshal(testExportRequest(testOTLPProfile()))
+	require.NoError(t, err)
+
+	req = httptest.NewRequest(http.MethodPost, OTLPHTTPPath, bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, OTLPHTTPPath, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "text/plain")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+
+	// Bodies larger than requests may decompress to are not read.
+	s = NewOTLPProfilesService(
+		log.NewNopLogger(),
+		trace.NewNoopTracerProvider().Tracer(""),
+		newTestProfileColumnStore(t, int64(len(body)-1)),
+	)
+	req = httptest.NewRequest(http.MethodPost, OTLPHTTPPath, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
@@ -17,19 +17,23 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
-	"encoding/base64"
-	"fmt"
+	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
-	"github.com/polarsignals/frostdb"
 	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/prometheus/prometheus/model/timestamp"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
@@ -38,7 +42,12 @@ import (
 	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
 	metastorepb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/jfr"
 	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
+	"github.com/parca-dev/parca/pkg/valuelog"
 )
 
 type ProfileColumnStore struct {
@@ -47,9 +56,13 @@ type ProfileColumnStore struct {
 	logger    log.Logger
 	tracer    trace.Tracer
 	metastore metastorepb.MetastoreServiceClient
+	// normalizerCache is shared by the normalizers of all writes, it may
+	// be nil.
+	normalizerCache *parcacol.NormalizerCache
 
-	table  *frostdb.Table
-	schema *dynparquet.Schema
+	tables       *parcacol.Tables
+	schema       *dynparquet.Schema
+	sampleLabels *parcacol.SampleLabelFilter
 
 	// When the debug-value-log is enabled, every profile is first written to
 	// tmp/<labels>/<timestamp>.pb.gz before it's parsed and written to the
@@ -57,92 +70,518 @@ type ProfileColumnStore struct {
 	// reproducing situations in tests. This has huge overhead, do not enable
 	// unless you know what you're doing.
 	debugValueLog bool
+
+	// maxDecompressedSize is the maximum number of bytes a raw profile may
+	// decompress to. Zero disables the limit.
+	maxDecompressedSize int64
+
+	mtx                 sync.RWMutex
+	writeRelabelConfigs []*relabel.Config
+
+	limiter    *Limiter
+	haTracker  *HATracker
+	rollups    *parcacol.Rollups
+	rejections *prometheus.CounterVec
 }
 
+// The reasons of rejections of partial writes that aren't validation
+// failures of profiles.
+const (
+	rejectionReasonInvalidLabels     = "invalid_labels"
+	rejectionReasonInvalidArgument   = "invalid_argument"
+	rejectionReasonResourceExhausted = "resource_exhausted"
+	rejectionReasonInternal          = "internal"
+)
+
 var _ profilestorepb.ProfileStoreServiceServer = &ProfileColumnStore{}
 
 func NewProfileColumnStore(
 	logger log.Logger,
+	reg prometheus.Registerer,
 	tracer trace.Tracer,
 	metastore metastorepb.MetastoreServiceClient,
-	table *frostdb.Table,
+	tables *parcacol.Tables,
 	schema *dynparquet.Schema,
-	debugValueLog bool,
+	opts ...Option,
 ) *ProfileColumnStore {
-	return &ProfileColumnStore{
-		logger:        logger,
-		tracer:        tracer,
-		metastore:     metastore,
-		table:         table,
-		debugValueLog: debugValueLog,
-		schema:        schema,
+	s := &ProfileColumnStore{
+		logger:    logger,
+		tracer:    tracer,
+		metastore: metastore,
+		tables:    tables,
+		schema:    schema,
+		limiter:   NewLimiter(reg),
+		haTracker: NewHATracker(reg),
+		rejections: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "profilestore_write_raw_rejections_total",
+				Help: "Total number of series, profiles and samples skipped by partial writes, partitioned by reason.",
+			},
+			[]string{"reason"},
+		),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ApplyWriteRelabelConfigs replaces the relabeling rules applied to the
+// labels of written profiles.
+func (s *ProfileColumnStore) ApplyWriteRelabelConfigs(cfgs []*relabel.Config) error {
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			return errors.New("empty or null write relabeling rule")
+		}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.writeRelabelConfigs = cfgs
+	return nil
+}
+
+// ApplyLimits replaces the limits enforced on written profiles.
+func (s *ProfileColumnStore) ApplyLimits(defaults *config.Limits, overrides map[string]*config.Limits) error {
+	return s.limiter.ApplyConfig(defaults, overrides)
+}
+
+// ApplyHATrackerConfig replaces the configuration of the deduplication of
+// profiles written by replicated scrapers.
+func (s *ProfileColumnStore) ApplyHATrackerConfig(cfg *config.HATracker) error {
+	return s.haTracker.ApplyConfig(cfg)
+}
+
+// seriesLabels returns the labels a series written by the tenant of the
+// context is stored with, after deduplicating the writes of HA replicas and
+// applying the write relabeling rules. It returns nil if the series is
+// dropped.
+func (s *ProfileColumnStore) seriesLabels(ctx context.Context, ls labels.Labels) labels.Labels {
+	ls = s.haTracker.Accept(tenant.FromContext(ctx), ls)
+	if ls == nil {
+		return nil
 	}
+	return s.relabel(ls)
+}
+
+// relabel applies the write relabeling rules to ls. It returns nil if the
+// series is dropped.
+func (s *ProfileColumnStore) relabel(ls labels.Labels) labels.Labels {
+	s.mtx.RLock()
+	cfgs := s.writeRelabelConfigs
+	s.mtx.RUnlock()
+
+	if len(cfgs) == 0 {
+		return ls
+	}
+
+	return relabel.Process(ls, cfgs...)
 }
 
 func (s *ProfileColumnStore) WriteRaw(ctx context.Context, req *profilestorepb.WriteRawRequest) (*profilestorepb.WriteRawResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "write-raw")
 	defer span.End()
 
-	ingester := parcacol.NewIngester(
-		s.logger,
-		parcacol.NewNormalizer(s.metastore),
-		s.table,
-		s.schema,
-	)
+	ingester, err := s.ingester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &profilestorepb.WriteRawResponse{}
+	for i, series := range req.Series {
+		if req.Partial {
+			resp.Rejections = append(resp.Rejections, s.writeRawSeriesPartial(ctx, ingester, uint64(i), series, req.Normalized)...)
+			continue
+		}
+		if err := s.writeRawSeries(ctx, ingester, series, req.Normalized); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// WriteRawStream ingests the windows of series sent on the stream and
+// acknowledges every window once all of its series have been processed. A
+// series that fails to be stored is reported in the acknowledgement of its
+// window instead of failing the stream.
+func (s *ProfileColumnStore) WriteRawStream(stream profilestorepb.ProfileStoreService_WriteRawStreamServer) error {
+	ctx, span := s.tracer.Start(stream.Context(), "write-raw-stream")
+	defer span.End()
+
+	ingester, err := s.ingester(ctx)
+	if err != nil {
+		return err
+	}
+
+	for window := uint64(0); ; window++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
 
-	for _, series := range req.Series {
-		ls := make(labels.Labels, 0, len(series.Labels.Labels))
-		for _, l := range series.Labels.Labels {
-			if valid := model.LabelName(l.Name).IsValid(); !valid {
-				return nil, status.Errorf(codes.InvalidArgument, "invalid label name: %v", l.Name)
+		resp := &profilestorepb.WriteRawStreamResponse{Window: window}
+		for i, series := range req.Series {
+			if err := s.writeRawSeries(ctx, ingester, series, req.Normalized); err != nil {
+				st, _ := status.FromError(err)
+				resp.Errors = append(resp.Errors, &profilestorepb.SeriesError{
+					Index:   uint64(i),
+					Code:    int32(st.Code()),
+					Message: st.Message(),
+				})
+				continue
 			}
+			resp.Accepted++
+		}
 
-			ls = append(ls, labels.Label{
-				Name:  l.Name,
-				Value: l.Value,
-			})
+		if err := stream.Send(resp); err != nil {
+			return err
 		}
+	}
+}
 
-		// Must ensure label-set is sorted and HasDuplicateLabelNames also required a sorted label-set
-		sort.Sort(ls)
-		if name, has := ls.HasDuplicateLabelNames(); has {
-			return nil, status.Errorf(codes.InvalidArgument, "duplicate label names: %v", name)
+func (s *ProfileColumnStore) writeRawSeries(ctx context.Context, ingester *parcacol.Ingester, series *profilestorepb.RawProfileSeries, normalized bool) error {
+	ls, err := labelsFromLabelSet(series.Labels)
+	if err != nil {
+		return err
+	}
+
+	for _, sample := range series.Samples {
+		if _, err := s.ingestRaw(ctx, ingester, ls, sample.RawProfile, normalized, time.Time{}, false); err != nil {
+			return err
 		}
+	}
 
-		for _, sample := range series.Samples {
-			r, err := gzip.NewReader(bytes.NewBuffer(sample.RawProfile))
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create gzip reader: %v", err)
-			}
+	return nil
+}
+
+// writeRawSeriesPartial stores the valid samples of the series at the given
+// index of a request and returns the rejections of the invalid ones.
+func (s *ProfileColumnStore) writeRawSeriesPartial(ctx context.Context, ingester *parcacol.Ingester, index uint64, series *profilestorepb.RawProfileSeries, normalized bool) []*profilestorepb.WriteRawRejection {
+	ls, err := labelsFromLabelSet(series.Labels)
+	if err != nil {
+		return []*profilestorepb.WriteRawRejection{s.reject(index, -1, -1, rejectionReasonInvalidLabels, err)}
+	}
+
+	var rejections []*profilestorepb.WriteRawRejection
+	for i, sample := range series.Samples {
+		skipped, err := s.ingestRaw(ctx, ingester, ls, sample.RawProfile, normalized, time.Time{}, true)
+		if err != nil {
+			rejections = append(rejections, s.reject(index, int64(i), -1, rejectionReason(err), err))
+			continue
+		}
+		for _, verr := range skipped {
+			rejections = append(rejections, s.reject(index, int64(i), int64(verr.Sample), verr.Reason, status.Error(codes.InvalidArgument, verr.Error())))
+		}
+	}
+
+	return rejections
+}
 
-			content, err := io.ReadAll(r)
+// reject counts a rejection by its reason and returns its description.
+func (s *ProfileColumnStore) reject(series uint64, sample, pprofSample int64, reason string, err error) *profilestorepb.WriteRawRejection {
+	s.rejections.WithLabelValues(reason).Inc()
+
+	st, _ := status.FromError(err)
+	return &profilestorepb.WriteRawRejection{
+		SeriesIndex:      series,
+		SampleIndex:      sample,
+		PprofSampleIndex: pprofSample,
+		Code:             int32(st.Code()),
+		Reason:           reason,
+		Message:          st.Message(),
+	}
+}
+
+// rejectionReason returns the reason of a rejection that is not a validation
+// failure, derived from its status code.
+func rejectionReason(err error) string {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return rejectionReasonInvalidArgument
+	case codes.ResourceExhausted:
+		return rejectionReasonResourceExhausted
+	default:
+		return rejectionReasonInternal
+	}
+}
+
+// ingestRaw decompresses, parses and ingests a single raw pprof profile. If
+// ts is not zero it overrides the time the profile was taken at. If partial
+// is set, samples that fail validation are skipped and the valid ones are
+// ingested, and validation failures are returned instead of failing.
+func (s *ProfileColumnStore) ingestRaw(
+	ctx context.Context,
+	ingester *parcacol.Ingester,
+	ls labels.Labels,
+	raw []byte,
+	normalized bool,
+	ts time.Time,
+	partial bool,
+) ([]*parcacol.ValidationError, error) {
+	// The debug value log records the labels as they were received, as
+	// replaying it relabels them again.
+	received := ls
+	ls = s.seriesLabels(ctx, ls)
+	if ls == nil {
+		return nil, nil
+	}
+
+	tenantID := tenant.FromContext(ctx)
+	res, err := s.limiter.Reserve(tenantID, len(raw), ls)
+	if err != nil {
+		return nil, err
+	}
+	// Canceling has no effect once the profile is stored and the
+	// reservation committed.
+	defer res.Cancel()
+
+	content, enc, err := Decompress(raw, s.maxDecompressedSize)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decompress profile: %v", err)
+	}
+
+	p := &pprofpb.Profile{}
+	if err := p.UnmarshalVT(content); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse profile: %v", err)
+	}
+
+	var skipped []*parcacol.ValidationError
+	if partial {
+		skipped = parcacol.RemoveInvalidSamples(p)
+		if len(skipped) == 1 && skipped[0].Sample == -1 {
+			return skipped, nil
+		}
+	}
+
+	if err := s.limiter.CheckProfile(tenantID, p); err != nil {
+		return nil, err
+	}
+	s.sampleLabels.Filter(tenantID, p)
+
+	if !ts.IsZero() {
+		p.TimeNanos = ts.UnixNano()
+	}
+
+	if s.debugValueLog {
+		path := valuelog.Path(valuelog.Dir, tenantID, received, timestamp.FromTime(time.Now()), p.TimeNanos)
+		err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		if err != nil {
+			level.Error(s.logger).Log("msg", "failed to create debug-value-log directory", "err", err)
+		} else {
+			err := writeDebugValueLog(path, raw, content, enc)
 			if err != nil {
-				return nil, status.Errorf(codes.InvalidArgument, "failed to decompress profile: %v", err)
+				level.Error(s.logger).Log("msg", "failed to write debug-value-log", "err", err)
 			}
+		}
+	}
 
-			p := &pprofpb.Profile{}
-			if err := p.UnmarshalVT(content); err != nil {
-				return nil, status.Errorf(codes.InvalidArgument, "failed to parse profile: %v", err)
+	if err := ingester.Ingest(ctx, ls, p, normalized); err != nil {
+		var verr *parcacol.ValidationError
+		if errors.As(err, &verr) {
+			if partial {
+				return append(skipped, verr), nil
 			}
+			return nil, status.Errorf(codes.InvalidArgument, "invalid profile: %v", err)
+		}
+		if errors.Is(err, parcacol.ErrMissingNameLabel) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to ingest profile: %v", err)
+	}
+	res.Commit()
 
-			if s.debugValueLog {
-				dir := fmt.Sprintf("tmp/%s", base64.URLEncoding.EncodeToString([]byte(ls.String())))
-				err := os.MkdirAll(dir, os.ModePerm)
-				if err != nil {
-					level.Error(s.logger).Log("msg", "failed to create debug-value-log directory", "err", err)
-				} else {
-					err := os.WriteFile(fmt.Sprintf("%s/%d.pb.gz", dir, timestamp.FromTime(time.Now())), sample.RawProfile, 0o644)
-					if err != nil {
-						level.Error(s.logger).Log("msg", "failed to write debug-value-log", "err", err)
-					}
-				}
-			}
+	return skipped, nil
+}
 
-			if err := ingester.Ingest(ctx, ls, p, req.Normalized); err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to ingest profile: %v", err)
-			}
+func (s *ProfileColumnStore) WriteFolded(ctx context.Context, req *profilestorepb.WriteFoldedRequest) (*profilestorepb.WriteFoldedResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "write-folded")
+	defer span.End()
+
+	ls, err := labelsFromLabelSet(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	ls = s.seriesLabels(ctx, ls)
+	if ls == nil {
+		return &profilestorepb.WriteFoldedResponse{}, nil
+	}
+
+	stacks, err := parcacol.ParseFoldedStacks(strings.NewReader(req.Folded))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse folded stacks: %v", err)
+	}
+
+	meta := profile.Meta{
+		SampleType: profile.ValueType{Type: req.SampleType, Unit: req.SampleUnit},
+		PeriodType: profile.ValueType{Type: req.PeriodType, Unit: req.PeriodUnit},
+		Period:     req.Period,
+		Timestamp:  timestamp.FromTime(time.Now()),
+	}
+	if meta.SampleType.Type == "" {
+		meta.SampleType.Type = "samples"
+	}
+	if meta.SampleType.Unit == "" {
+		meta.SampleType.Unit = "count"
+	}
+	if req.Timestamp != nil {
+		meta.Timestamp = timestamp.FromTime(req.Timestamp.AsTime())
+	}
+	if req.Duration != nil {
+		meta.Duration = req.Duration.AsDuration().Nanoseconds()
+	}
+
+	ingester, err := s.ingester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID := tenant.FromContext(ctx)
+	res, err := s.limiter.Reserve(tenantID, len(req.Folded), ls)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Cancel()
+	if err := s.limiter.CheckFolded(tenantID, stacks); err != nil {
+		return nil, err
+	}
+
+	if err := ingester.IngestFolded(ctx, ls, meta, stacks); err != nil {
+		if errors.Is(err, parcacol.ErrMissingNameLabel) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
+		return nil, status.Errorf(codes.Internal, "failed to ingest profile: %v", err)
+	}
+	res.Commit()
+
+	return &profilestorepb.WriteFoldedResponse{}, nil
+}
+
+func (s *ProfileColumnStore) WriteJFR(ctx context.Context, req *profilestorepb.WriteJFRRequest) (*profilestorepb.WriteJFRResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "write-jfr")
+	defer span.End()
+
+	ls, err := labelsFromLabelSet(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	name := ls.Get(labels.MetricName)
+	if name == "" {
+		name = "jfr"
+	}
+
+	content, _, err := Decompress(req.Jfr, s.maxDecompressedSize)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decompress recording: %v", err)
+	}
+
+	profiles, err := jfr.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse recording: %v", err)
+	}
+
+	ingester, err := s.ingester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The size of the recording counts towards the ingestion rate limit
+	// once, with its first profile that is stored.
+	tenantID := tenant.FromContext(ctx)
+	size := len(req.Jfr)
+	for _, p := range profiles {
+		pls := s.seriesLabels(ctx, labels.NewBuilder(ls).Set(labels.MetricName, name+"_"+p.Kind).Labels())
+		if pls == nil {
+			continue
+		}
+		if err := s.ingestJFRProfile(ctx, ingester, tenantID, size, pls, p); err != nil {
+			return nil, err
+		}
+		size = 0
+	}
+
+	return &profilestorepb.WriteJFRResponse{}, nil
+}
+
+func (s *ProfileColumnStore) ingestJFRProfile(ctx context.Context, ingester *parcacol.Ingester, tenantID string, size int, ls labels.Labels, p jfr.Profile) error {
+	res, err := s.limiter.Reserve(tenantID, size, ls)
+	if err != nil {
+		return err
+	}
+	defer res.Cancel()
+	if err := s.limiter.CheckProfile(tenantID, p.Profile); err != nil {
+		return err
+	}
+
+	s.sampleLabels.Filter(tenantID, p.Profile)
+	if err := ingester.Ingest(ctx, ls, p.Profile, true); err != nil {
+		return status.Errorf(codes.Internal, "failed to ingest %s profile: %v", p.Kind, err)
+	}
+	res.Commit()
+	return nil
+}
+
+// ingester returns an Ingester writing to the table of the tenant of the
+// context.
+func (s *ProfileColumnStore) ingester(ctx context.Context) (*parcacol.Ingester, error) {
+	table, err := s.tables.Table(tenant.FromContext(ctx))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get table: %v", err)
+	}
+
+	return parcacol.NewIngester(
+		s.logger,
+		parcacol.NewNormalizer(s.metastore, s.normalizerCache),
+		table,
+		s.schema,
+	).WithRollups(s.rollups), nil
+}
+
+// labelsFromLabelSet converts and validates the label-set of a write request.
+func labelsFromLabelSet(set *profilestorepb.LabelSet) (labels.Labels, error) {
+	ls := make(labels.Labels, 0, len(set.GetLabels()))
+	for _, l := range set.GetLabels() {
+		if valid := model.LabelName(l.Name).IsValid(); !valid {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid label name: %v", l.Name)
+		}
+
+		ls = append(ls, labels.Label{
+			Name:  l.Name,
+			Value: l.Value,
+		})
+	}
+
+	// Must ensure label-set is sorted and HasDuplicateLabelNames also required a sorted label-set
+	sort.Sort(ls)
+	if name, has := ls.HasDuplicateLabelNames(); has {
+		return nil, status.Errorf(codes.InvalidArgument, "duplicate label names: %v", name)
+	}
+
+	return ls, nil
+}
+
+// writeDebugValueLog writes a profile to the debug-value-log. Profiles are
+// always stored gzip compressed, so they can be replayed regardless of the
+// encoding they were sent with.
+func writeDebugValueLog(path string, raw, content []byte, enc Encoding) error {
+	if enc == EncodingGzip {
+		return os.WriteFile(path, raw, 0o644)
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(content); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
 	}
 
-	return &profilestorepb.WriteRawResponse{}, nil
+	return os.WriteFile(path, buf.Bytes(), 0o644)
 }
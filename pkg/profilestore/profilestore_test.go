@@ -14,27 +14,37 @@
 package profilestore
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/go-kit/log"
+	"github.com/klauspost/compress/zstd"
 	"github.com/polarsignals/frostdb"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/metastoretest"
 	"github.com/parca-dev/parca/pkg/parcacol"
 )
 
-func Test_LabelName_Error(t *testing.T) {
-	t.Parallel()
+func newTestProfileColumnStore(t *testing.T, maxDecompressedSize int64) *ProfileColumnStore {
+	t.Helper()
 
-	ctx := context.Background()
 	logger := log.NewNopLogger()
 	reg := prometheus.NewRegistry()
 	tracer := trace.NewNoopTracerProvider().Tracer("")
@@ -46,11 +56,6 @@ func Test_LabelName_Error(t *testing.T) {
 	schema, err := parcacol.Schema()
 	require.NoError(t, err)
 
-	table, err := colDB.Table(
-		"stacktraces",
-		frostdb.NewTableConfig(schema),
-	)
-	require.NoError(t, err)
 	m := metastoretest.NewTestMetastore(
 		t,
 		logger,
@@ -58,14 +63,22 @@ func Test_LabelName_Error(t *testing.T) {
 		tracer,
 	)
 
-	api := NewProfileColumnStore(
+	return NewProfileColumnStore(
 		logger,
+		reg,
 		tracer,
 		metastore.NewInProcessClient(m),
-		table,
+		parcacol.NewTables(colDB, schema, "stacktraces", nil),
 		schema,
-		false,
+		WithMaxDecompressedSize(maxDecompressedSize),
 	)
+}
+
+func Test_LabelName_Error(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, 0)
 
 	cases := []struct {
 		name   string
@@ -105,10 +118,343 @@ func Test_LabelName_Error(t *testing.T) {
 				}},
 			}
 
-			_, err = api.WriteRaw(ctx, req)
+			_, err := api.WriteRaw(ctx, req)
 			st, _ := status.FromError(err)
 
 			require.Equal(t, codes.InvalidArgument, st.Code())
 		})
 	}
 }
+
+func TestWriteRawEncodings(t *testing.T) {
+	t.Parallel()
+
+	gzipped, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	r, err := gzip.NewReader(bytes.NewReader(gzipped))
+	require.NoError(t, err)
+	plain, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	zstdCompressed := enc.EncodeAll(plain, nil)
+	require.NoError(t, enc.Close())
+
+	writeRaw := func(api *ProfileColumnStore, raw []byte) error {
+		_, err := api.WriteRaw(context.Background(), &profilestorepb.WriteRawRequest{
+			Series: []*profilestorepb.RawProfileSeries{{
+				Labels: &profilestorepb.LabelSet{
+					Labels: []*profilestorepb.Label{{
+						Name:  "__name__",
+						Value: "memory",
+					}},
+				},
+				Samples: []*profilestorepb.RawSample{{
+					RawProfile: raw,
+				}},
+			}},
+		})
+		return err
+	}
+
+	cases := []struct {
+		name     string
+		raw      []byte
+		encoding Encoding
+	}{
+		{name: "gzip", raw: gzipped, encoding: EncodingGzip},
+		{name: "zstd", raw: zstdCompressed, encoding: EncodingZstd},
+		{name: "uncompressed", raw: plain, encoding: EncodingNone},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, c.encoding, DetectEncoding(c.raw))
+			require.NoError(t, writeRaw(newTestProfileColumnStore(t, int64(len(plain))), c.raw))
+
+			err := writeRaw(newTestProfileColumnStore(t, int64(len(plain)-1)), c.raw)
+			st, _ := status.FromError(err)
+			require.Equal(t, codes.InvalidArgument, st.Code())
+			require.Contains(t, st.Message(), ErrDecompressedSizeExceeded.Error())
+		})
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, 0)
+
+	_, err := api.WriteFolded(ctx, &profilestorepb.WriteFoldedRequest{
+		Labels: &profilestorepb.LabelSet{
+			Labels: []*profilestorepb.Label{{
+				Name:  "__name__",
+				Value: "process_cpu",
+			}, {
+				Name:  "job",
+				Value: "py-spy",
+			}},
+		},
+		Folded: "main;handler;json.dumps 10\nmain;idle 3\n",
+	})
+	require.NoError(t, err)
+
+	_, err = api.WriteFolded(ctx, &profilestorepb.WriteFoldedRequest{
+		Labels: &profilestorepb.LabelSet{
+			Labels: []*profilestorepb.Label{{
+				Name:  "job",
+				Value: "py-spy",
+			}},
+		},
+		Folded: "main;idle 3\n",
+	})
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	_, err = api.WriteFolded(ctx, &profilestorepb.WriteFoldedRequest{
+		Labels: &profilestorepb.LabelSet{
+			Labels: []*profilestorepb.Label{{
+				Name:  "__name__",
+				Value: "process_cpu",
+			}},
+		},
+		Folded: "main;idle three\n",
+	})
+	st, _ = status.FromError(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestWriteJFR(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, 0)
+
+	recording, err := os.ReadFile("../jfr/testdata/example.jfr.gz")
+	require.NoError(t, err)
+
+	_, err = api.WriteJFR(ctx, &profilestorepb.WriteJFRRequest{
+		Labels: &profilestorepb.LabelSet{
+			Labels: []*profilestorepb.Label{{
+				Name:  "__name__",
+				Value: "java",
+			}, {
+				Name:  "job",
+				Value: "async-profiler",
+			}},
+		},
+		Jfr: recording,
+	})
+	require.NoError(t, err)
+
+	_, err = api.WriteJFR(ctx, &profilestorepb.WriteJFRRequest{
+		Jfr: []byte("not a recording"),
+	})
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+type fakeWriteRawStream struct {
+	grpc.ServerStream
+
+	ctx       context.Context
+	requests  []*profilestorepb.WriteRawStreamRequest
+	responses []*profilestorepb.WriteRawStreamResponse
+}
+
+func (s *fakeWriteRawStream) Context() context.Context { return s.ctx }
+
+func (s *fakeWriteRawStream) Recv() (*profilestorepb.WriteRawStreamRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *fakeWriteRawStream) Send(resp *profilestorepb.WriteRawStreamResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func TestWriteRawStream(t *testing.T) {
+	t.Parallel()
+
+	raw, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	series := func(name string, raw []byte) *profilestorepb.RawProfileSeries {
+		s := &profilestorepb.RawProfileSeries{
+			Labels:  &profilestorepb.LabelSet{},
+			Samples: []*profilestorepb.RawSample{{RawProfile: raw}},
+		}
+		if name != "" {
+			s.Labels.Labels = []*profilestorepb.Label{{Name: "__name__", Value: name}}
+		}
+		return s
+	}
+
+	stream := &fakeWriteRawStream{
+		ctx: context.Background(),
+		requests: []*profilestorepb.WriteRawStreamRequest{{
+			Series: []*profilestorepb.RawProfileSeries{
+				series("memory", raw),
+				series("memory", []byte("not a profile")),
+				series("", raw),
+			},
+		}, {
+			Series: []*profilestorepb.RawProfileSeries{
+				series("memory", raw),
+			},
+		}},
+	}
+
+	require.NoError(t, newTestProfileColumnStore(t, 0).WriteRawStream(stream))
+	require.Len(t, stream.responses, 2)
+
+	first := stream.responses[0]
+	require.Equal(t, uint64(0), first.Window)
+	require.Equal(t, uint64(1), first.Accepted)
+	require.Len(t, first.Errors, 2)
+	require.Equal(t, uint64(1), first.Errors[0].Index)
+	require.Equal(t, int32(codes.InvalidArgument), first.Errors[0].Code)
+	require.Equal(t, uint64(2), first.Errors[1].Index)
+	require.Equal(t, int32(codes.InvalidArgument), first.Errors[1].Code)
+
+	second := stream.responses[1]
+	require.Equal(t, uint64(1), second.Window)
+	require.Equal(t, uint64(1), second.Accepted)
+	require.Empty(t, second.Errors)
+}
+
+func TestWriteRelabelConfigs(t *testing.T) {
+	t.Parallel()
+
+	api := newTestProfileColumnStore(t, 0)
+	require.Error(t, api.ApplyWriteRelabelConfigs([]*relabel.Config{nil}))
+
+	cfgs := []*relabel.Config{{
+		Action: relabel.LabelDrop,
+		Regex:  relabel.MustNewRegexp("pod_template_hash"),
+	}, {
+		SourceLabels: model.LabelNames{"namespace"},
+		Regex:        relabel.MustNewRegexp("kube-(.*)"),
+		TargetLabel:  "namespace",
+		Replacement:  "$1",
+		Action:       relabel.Replace,
+	}, {
+		SourceLabels: model.LabelNames{"__name__"},
+		Regex:        relabel.MustNewRegexp("goroutine"),
+		Action:       relabel.Drop,
+	}}
+	require.NoError(t, api.ApplyWriteRelabelConfigs(cfgs))
+
+	require.Equal(t,
+		labels.FromStrings("__name__", "memory", "namespace", "system"),
+		api.relabel(labels.FromStrings("__name__", "memory", "namespace", "kube-system", "pod_template_hash", "abc")),
+	)
+	require.Nil(t, api.relabel(labels.FromStrings("__name__", "goroutine")))
+
+	// Dropped series are discarded before their profile is parsed.
+	_, err := api.WriteRaw(context.Background(), &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels: &profilestorepb.LabelSet{
+				Labels: []*profilestorepb.Label{{
+					Name:  "__name__",
+					Value: "goroutine",
+				}},
+			},
+			Samples: []*profilestorepb.RawSample{{
+				RawProfile: []byte("not a profile"),
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	// Relabeling is disabled by applying an empty set of rules.
+	require.NoError(t, api.ApplyWriteRelabelConfigs(nil))
+	require.Equal(t,
+		labels.FromStrings("__name__", "goroutine"),
+		api.relabel(labels.FromStrings("__name__", "goroutine")),
+	)
+}
+
+func TestWriteRawPartial(t *testing.T) {
+	t.Parallel()
+
+	p := &pprofpb.Profile{
+		StringTable: []string{"", "samples", "count", "main", "handler"},
+		SampleType:  []*pprofpb.ValueType{{Type: 1, Unit: 2}},
+		Function:    []*pprofpb.Function{{Id: 1, Name: 3}},
+		Location:    []*pprofpb.Location{{Id: 1, Line: []*pprofpb.Line{{FunctionId: 1}}}},
+		Sample: []*pprofpb.Sample{
+			{LocationId: []uint64{1}, Value: []int64{1}},
+			{LocationId: []uint64{2}, Value: []int64{1}},
+			{LocationId: []uint64{1}, Value: []int64{1}, Label: []*pprofpb.Label{{Key: 4, Str: 9}}},
+			{LocationId: []uint64{1}, Value: []int64{1, 2}},
+		},
+	}
+	raw, err := p.MarshalVT()
+	require.NoError(t, err)
+
+	p.Location[0].MappingId = 1
+	invalid, err := p.MarshalVT()
+	require.NoError(t, err)
+
+	req := &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels: &profilestorepb.LabelSet{
+				Labels: []*profilestorepb.Label{{Name: "__name__", Value: "cpu"}},
+			},
+			Samples: []*profilestorepb.RawSample{
+				{RawProfile: raw},
+				{RawProfile: []byte("not a profile")},
+				{RawProfile: invalid},
+			},
+		}, {
+			Labels: &profilestorepb.LabelSet{
+				Labels: []*profilestorepb.Label{{Name: "0cpu", Value: "cpu"}},
+			},
+			Samples: []*profilestorepb.RawSample{{RawProfile: raw}},
+		}},
+	}
+
+	api := newTestProfileColumnStore(t, 0)
+
+	// Without partial acceptance the first invalid sample fails the request.
+	_, err = api.WriteRaw(context.Background(), req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	req.Partial = true
+	resp, err := api.WriteRaw(context.Background(), req)
+	require.NoError(t, err)
+
+	type rejection struct {
+		series, sample, pprofSample int64
+		code                        codes.Code
+		reason                      string
+	}
+	rejections := make([]rejection, 0, len(resp.Rejections))
+	for _, r := range resp.Rejections {
+		require.NotEmpty(t, r.Message)
+		rejections = append(rejections, rejection{int64(r.SeriesIndex), r.SampleIndex, r.PprofSampleIndex, codes.Code(r.Code), r.Reason})
+	}
+	require.Equal(t, []rejection{
+		{0, 0, 1, codes.InvalidArgument, parcacol.ReasonInvalidLocationID},
+		{0, 0, 2, codes.InvalidArgument, parcacol.ReasonInvalidLabel},
+		{0, 0, 3, codes.InvalidArgument, parcacol.ReasonSampleValueMismatch},
+		{0, 1, -1, codes.InvalidArgument, rejectionReasonInvalidArgument},
+		{0, 2, -1, codes.InvalidArgument, parcacol.ReasonInvalidLocation},
+		{1, -1, -1, codes.InvalidArgument, rejectionReasonInvalidLabels},
+	}, rejections)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(api.rejections.WithLabelValues(parcacol.ReasonInvalidLocationID)))
+	require.Equal(t, 1.0, testutil.ToFloat64(api.rejections.WithLabelValues(rejectionReasonInvalidLabels)))
+}
@@ -0,0 +1,220 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/prometheus/model/labels"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// PushPath is the path pprof files can be pushed to as plain HTTP requests.
+const PushPath = "/profiles/push"
+
+// maxMultipartMemory is the amount of a multipart request that is kept in
+// memory, the rest is stored in temporary files.
+const maxMultipartMemory = 32 << 20
+
+const (
+	// pushNameParam is the query parameter the __name__ label is taken from.
+	pushNameParam = "name"
+	// pushTimestampParam is the query parameter that overrides the time a
+	// pushed profile was taken at. It is given in milliseconds since the
+	// epoch or in RFC 3339 format.
+	pushTimestampParam = "timestamp"
+)
+
+// ServePush ingests a pprof file sent as the request body or as the profile
+// field of a multipart form, for example:
+//
+//	curl --data-binary @cpu.pprof 'http://localhost:7070/api/profiles/push?name=process_cpu&job=ci'
+//
+// The name parameter becomes the __name__ label and all other query
+// parameters, except for timestamp, become labels of the profile.
+func (s *ProfileColumnStore) ServePush(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.tracer.Start(r.Context(), "push")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ls, err := pushLabels(r)
+	if err != nil {
+		writePushError(w, err)
+		return
+	}
+
+	var ts time.Time
+	if v := r.URL.Query().Get(pushTimestampParam); v != "" {
+		ts, err = parsePushTimestamp(v)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	raw, err := readProfileBody(w, r, s.maxProfileSize(tenant.FromContext(ctx)))
+	if err != nil {
+		writeBodyError(w, err)
+		return
+	}
+
+	ingester, err := s.ingester(ctx)
+	if err != nil {
+		writePushError(w, err)
+		return
+	}
+
+	if _, err := s.ingestRaw(ctx, ingester, ls, raw, false, ts, false); err != nil {
+		writePushError(w, err)
+		return
+	}
+}
+
+func pushLabels(r *http.Request) (labels.Labels, error) {
+	q := r.URL.Query()
+
+	names := make([]string, 0, len(q))
+	for name := range q {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	set := &profilestorepb.LabelSet{}
+	for _, name := range names {
+		if name == pushTimestampParam {
+			continue
+		}
+
+		labelName := name
+		if name == pushNameParam {
+			labelName = labels.MetricName
+		}
+		for _, value := range q[name] {
+			set.Labels = append(set.Labels, &profilestorepb.Label{
+				Name:  labelName,
+				Value: value,
+			})
+		}
+	}
+
+	return labelsFromLabelSet(set)
+}
+
+func parsePushTimestamp(v string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q, expected milliseconds since the epoch or RFC 3339", v)
+	}
+
+	return ts, nil
+}
+
+// errBodyTooLarge is returned for request bodies larger than allowed.
+var errBodyTooLarge = errors.New("request body too large")
+
+// maxProfileSize returns the maximum size in bytes of a profile of the tenant
+// as it is received, which is its profile size limit or otherwise the size
+// profiles may decompress to. Zero means unlimited.
+func (s *ProfileColumnStore) maxProfileSize(tenantID string) int64 {
+	if max := s.limiter.MaxProfileSize(tenantID); max > 0 {
+		return max
+	}
+	return s.maxDecompressedSize
+}
+
+// readBody reads the request body. If max is greater than zero, bodies of
+// more than max bytes are not read to the end and fail with errBodyTooLarge.
+func readBody(w http.ResponseWriter, r *http.Request, max int64) ([]byte, error) {
+	if max > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, max)
+	}
+
+	b, err := io.ReadAll(r.Body)
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return nil, fmt.Errorf("%w, the limit is %d bytes", errBodyTooLarge, max)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	return b, nil
+}
+
+// writeBodyError writes an error of reading a profile from the request body.
+func writeBodyError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	if errors.Is(err, errBodyTooLarge) {
+		code = http.StatusRequestEntityTooLarge
+	}
+	http.Error(w, err.Error(), code)
+}
+
+// readProfileBody reads a profile either from the request body or from the
+// profile field of a multipart form. The whole body may be at most max bytes,
+// see readBody.
+func readProfileBody(w http.ResponseWriter, r *http.Request, max int64) ([]byte, error) {
+	body, err := readBody(w, r, max)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return body, nil
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+		return nil, fmt.Errorf("failed to parse multipart form: %w", err)
+	}
+	f, _, err := r.FormFile("profile")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile field: %w", err)
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	return b, nil
+}
+
+// writePushError writes the error using the HTTP status corresponding to the
+// gRPC status of the error.
+func writePushError(w http.ResponseWriter, err error) {
+	st, _ := status.FromError(err)
+	http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+func TestServePush(t *testing.T) {
+	t.Parallel()
+
+	s := newTestProfileColumnStore(t, 0)
+
+	raw, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	push := func(query, contentType string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, PushPath+"?"+query, bytes.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		s.ServePush(rec, req)
+		return rec
+	}
+
+	rec := push("name=memory&job=ci&timestamp=1660000000000", "", raw)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var form bytes.Buffer
+	w := multipart.NewWriter(&form)
+	fw, err := w.CreateFormFile("profile", "memory.pb.gz")
+	require.NoError(t, err)
+	_, err = fw.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	rec = push("name=memory&job=ci&timestamp=2022-08-08T23:06:40Z", w.FormDataContentType(), form.Bytes())
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Missing name.
+	rec = push("job=ci", "", raw)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Invalid label name.
+	rec = push("name=memory&"+url.QueryEscape("job.name")+"=ci", "", raw)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Invalid timestamp.
+	rec = push("name=memory&timestamp=yesterday", "", raw)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Not a profile.
+	rec = push("name=memory", "", []byte("not a profile"))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServePushTooLarge(t *testing.T) {
+	t.Parallel()
+
+	raw, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	push := func(s *ProfileColumnStore, contentType string, body []byte) int {
+		req := httptest.NewRequest(http.MethodPost, PushPath+"?name=memory", bytes.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		s.ServePush(rec, req)
+		return rec.Code
+	}
+
+	var form bytes.Buffer
+	w := multipart.NewWriter(&form)
+	fw, err := w.CreateFormFile("profile", "memory.pb.gz")
+	require.NoError(t, err)
+	_, err = fw.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// Bodies are limited to the size profiles may decompress to.
+	s := newTestProfileColumnStore(t, int64(len(raw)-1))
+	require.Equal(t, http.StatusRequestEntityTooLarge, push(s, "", raw))
+	require.Equal(t, http.StatusRequestEntityTooLarge, push(s, w.FormDataContentType(), form.Bytes()))
+
+	// The profile size limit of the tenant takes precedence.
+	s = newTestProfileColumnStore(t, 0)
+	require.NoError(t, s.ApplyLimits(&config.Limits{MaxProfileSizeBytes: int64(len(raw) - 1)}, nil))
+	require.Equal(t, http.StatusRequestEntityTooLarge, push(s, "", raw))
+	require.NoError(t, s.ApplyLimits(&config.Limits{MaxProfileSizeBytes: int64(len(raw))}, nil))
+	require.Equal(t, http.StatusOK, push(s, "", raw))
+}
+
+func TestPushLabels(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, PushPath+"?name=process_cpu&job=ci&timestamp=1", nil)
+	ls, err := pushLabels(req)
+	require.NoError(t, err)
+	require.Equal(t, labels.FromStrings("__name__", "process_cpu", "job", "ci"), ls)
+
+	ts, err := parsePushTimestamp("1660000000000")
+	require.NoError(t, err)
+	require.True(t, time.UnixMilli(1660000000000).Equal(ts))
+}
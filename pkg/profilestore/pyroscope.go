@@ -0,0 +1,381 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/prometheus/prometheus/util/strutil"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// PyroscopeIngestPath is the path Pyroscope clients push profiles to.
+const PyroscopeIngestPath = "/ingest"
+
+// pyroscopeUnits maps the units of Pyroscope's ingest API to sample types.
+var pyroscopeUnits = map[string]profile.ValueType{
+	"samples":          {Type: "samples", Unit: "count"},
+	"objects":          {Type: "objects", Unit: "count"},
+	"bytes":            {Type: "space", Unit: "bytes"},
+	"goroutines":       {Type: "goroutines", Unit: "count"},
+	"lock_samples":     {Type: "contentions", Unit: "count"},
+	"lock_nanoseconds": {Type: "delay", Unit: "nanoseconds"},
+}
+
+// ServePyroscopeIngest implements Pyroscope's /ingest API, so applications
+// using Pyroscope client SDKs can push to Parca. The name parameter has the
+// form <app>.<profile-type>{<label>=<value>,...}, the profile type becomes
+// the name of the profile and the application the service_name label.
+// Profiles may be sent in the pprof, folded, lines or trie format.
+func (s *ProfileColumnStore) ServePyroscopeIngest(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.tracer.Start(r.Context(), "pyroscope-ingest")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+
+	ls, err := parsePyroscopeName(q.Get("name"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid name: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	from, until, err := parsePyroscopeTimeRange(q.Get("from"), q.Get("until"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ls = s.seriesLabels(ctx, ls)
+	if ls == nil {
+		return
+	}
+
+	ingester, err := s.ingester(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tenantID := tenant.FromContext(ctx)
+	format := q.Get("format")
+	if format == "pprof" {
+		p, size, err := s.readPyroscopePprof(w, r, tenantID)
+		if err != nil {
+			writeBodyError(w, err)
+			return
+		}
+		if p.TimeNanos == 0 {
+			p.TimeNanos = from.UnixNano()
+			p.DurationNanos = until.Sub(from).Nanoseconds()
+		}
+
+		res, err := s.limiter.Reserve(tenantID, size, ls)
+		if err != nil {
+			writePushError(w, err)
+			return
+		}
+		defer res.Cancel()
+		if err := s.limiter.CheckProfile(tenantID, p); err != nil {
+			writePushError(w, err)
+			return
+		}
+		s.sampleLabels.Filter(tenantID, p)
+
+		if err := ingester.Ingest(ctx, ls, p, false); err != nil {
+			level.Debug(s.logger).Log("msg", "failed to ingest pyroscope profile", "err", err)
+			http.Error(w, fmt.Sprintf("failed to ingest profile: %v", err), http.StatusInternalServerError)
+			return
+		}
+		res.Commit()
+		return
+	}
+
+	raw, err := readBody(w, r, s.maxProfileSize(tenantID))
+	if err != nil {
+		writeBodyError(w, err)
+		return
+	}
+	res, err := s.limiter.Reserve(tenantID, len(raw), ls)
+	if err != nil {
+		writePushError(w, err)
+		return
+	}
+	defer res.Cancel()
+
+	body, _, err := Decompress(raw, s.maxDecompressedSize)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to decompress request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var stacks []parcacol.FoldedStack
+	switch format {
+	case "", "folded":
+		stacks, err = parcacol.ParseFoldedStacks(bytes.NewReader(body))
+	case "lines":
+		stacks, err = parsePyroscopeLines(bytes.NewReader(body))
+	case "trie":
+		stacks, err = parsePyroscopeTrie(body)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse %s profile: %v", format, err), http.StatusBadRequest)
+		return
+	}
+
+	meta := profile.Meta{
+		SampleType: profile.ValueType{Type: "samples", Unit: "count"},
+		Timestamp:  timestamp.FromTime(from),
+		Duration:   until.Sub(from).Nanoseconds(),
+	}
+	if units := q.Get("units"); units != "" {
+		sampleType, ok := pyroscopeUnits[units]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unsupported units %q", units), http.StatusBadRequest)
+			return
+		}
+		meta.SampleType = sampleType
+	}
+	if rate, err := strconv.ParseInt(q.Get("sampleRate"), 10, 64); err == nil && rate > 0 && meta.SampleType.Type == "samples" {
+		meta.PeriodType = profile.ValueType{Type: "cpu", Unit: "nanoseconds"}
+		meta.Period = time.Second.Nanoseconds() / rate
+	}
+
+	if err := s.limiter.CheckFolded(tenantID, stacks); err != nil {
+		writePushError(w, err)
+		return
+	}
+
+	if err := ingester.IngestFolded(ctx, ls, meta, stacks); err != nil {
+		level.Debug(s.logger).Log("msg", "failed to ingest pyroscope profile", "err", err)
+		http.Error(w, fmt.Sprintf("failed to ingest profile: %v", err), http.StatusInternalServerError)
+		return
+	}
+	res.Commit()
+}
+
+// readPyroscopePprof reads a pprof profile either from the request body or
+// from the profile field of a multipart form and returns it with its size as
+// it was received. The previous profile and sample type configuration sent by
+// some clients are ignored.
+func (s *ProfileColumnStore) readPyroscopePprof(w http.ResponseWriter, r *http.Request, tenantID string) (*pprofpb.Profile, int, error) {
+	raw, err := readProfileBody(w, r, s.maxProfileSize(tenantID))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	content, _, err := Decompress(raw, s.maxDecompressedSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decompress profile: %w", err)
+	}
+
+	p := &pprofpb.Profile{}
+	if err := p.UnmarshalVT(content); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse profile: %w", err)
+	}
+
+	return p, len(raw), nil
+}
+
+// parsePyroscopeName parses a name of the form
+// <app>.<profile-type>{<label>=<value>,...} into a label-set. The profile type
+// defaults to cpu if the application name does not contain one.
+func parsePyroscopeName(name string) (labels.Labels, error) {
+	app, rest := name, ""
+	if i := strings.IndexByte(name, '{'); i != -1 {
+		if !strings.HasSuffix(name, "}") {
+			return nil, errors.New("missing closing brace")
+		}
+		app, rest = name[:i], name[i+1:len(name)-1]
+	}
+
+	app = strings.TrimSpace(app)
+	if app == "" {
+		return nil, errors.New("missing application name")
+	}
+
+	profileType := "cpu"
+	if i := strings.LastIndexByte(app, '.'); i != -1 && i < len(app)-1 {
+		app, profileType = app[:i], app[i+1:]
+	}
+
+	b := labels.NewBuilder(nil)
+	for _, pair := range strings.Split(rest, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid label %q", pair)
+		}
+
+		k = strutil.SanitizeLabelName(strings.TrimSpace(k))
+		if !model.LabelName(k).IsValid() || k == labels.MetricName {
+			return nil, fmt.Errorf("invalid label name %q", k)
+		}
+		b.Set(k, strings.TrimSpace(v))
+	}
+
+	b.Set("service_name", app)
+	b.Set(labels.MetricName, strutil.SanitizeLabelName(profileType))
+
+	return b.Labels(), nil
+}
+
+// parsePyroscopeTimeRange parses the from and until parameters, given in
+// seconds since the epoch. Missing values default to the current time.
+func parsePyroscopeTimeRange(fromParam, untilParam string) (time.Time, time.Time, error) {
+	now := time.Now()
+
+	parse := func(param, value string) (time.Time, error) {
+		if value == "" {
+			return now, nil
+		}
+		sec, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s parameter %q", param, value)
+		}
+		return time.Unix(sec, 0), nil
+	}
+
+	from, err := parse("from", fromParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	until, err := parse("until", untilParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if until.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("until must not be before from")
+	}
+
+	return from, until, nil
+}
+
+// parsePyroscopeLines parses the lines format, in which every line is a
+// stack that was observed once.
+func parsePyroscopeLines(r io.Reader) ([]parcacol.FoldedStack, error) {
+	var b strings.Builder
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString(" 1\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return parcacol.ParseFoldedStacks(strings.NewReader(b.String()))
+}
+
+// parsePyroscopeTrie parses Pyroscope's serialized trie. Nodes are written
+// depth-first, each as the length-prefixed fragment of the stack it adds,
+// its value and the number of its children, all as unsigned varints. The
+// concatenation of the fragments from the root to a node is a stack in the
+// folded format.
+func parsePyroscopeTrie(b []byte) ([]parcacol.FoldedStack, error) {
+	r := bytes.NewReader(b)
+
+	type pending struct {
+		prefix   string
+		children uint64
+	}
+
+	var (
+		stacks []parcacol.FoldedStack
+		path   []pending
+	)
+	for r.Len() > 0 {
+		nameLen, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("read name length: %w", err)
+		}
+		if nameLen > uint64(r.Len()) {
+			return nil, fmt.Errorf("name length %d exceeds remaining input", nameLen)
+		}
+		name := make([]byte, nameLen)
+		if _, err := io.ReadFull(r, name); err != nil {
+			return nil, fmt.Errorf("read name: %w", err)
+		}
+		value, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("read value: %w", err)
+		}
+		children, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("read children count: %w", err)
+		}
+
+		prefix := string(name)
+		if len(path) > 0 {
+			prefix = path[len(path)-1].prefix + prefix
+			path[len(path)-1].children--
+		}
+
+		if value > 0 && prefix != "" {
+			stacks = append(stacks, parcacol.FoldedStack{
+				Frames: reverseFrames(strings.Split(prefix, ";")),
+				Value:  int64(value),
+			})
+		}
+
+		path = append(path, pending{prefix: prefix, children: children})
+		for len(path) > 0 && path[len(path)-1].children == 0 {
+			path = path[:len(path)-1]
+		}
+	}
+	if len(path) > 0 {
+		return nil, errors.New("unexpected end of trie")
+	}
+
+	return stacks, nil
+}
+
+func reverseFrames(frames []string) []string {
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
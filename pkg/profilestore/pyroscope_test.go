@@ -0,0 +1,171 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/parcacol"
+)
+
+func TestParsePyroscopeName(t *testing.T) {
+	ls, err := parsePyroscopeName("checkout.alloc_objects{env=staging, region=eu-west-1}")
+	require.NoError(t, err)
+	require.Equal(t, labels.FromStrings(
+		"__name__", "alloc_objects",
+		"env", "staging",
+		"region", "eu-west-1",
+		"service_name", "checkout",
+	), ls)
+
+	ls, err = parsePyroscopeName("checkout")
+	require.NoError(t, err)
+	require.Equal(t, labels.FromStrings(
+		"__name__", "cpu",
+		"service_name", "checkout",
+	), ls)
+
+	for _, name := range []string{
+		"",
+		"{env=staging}",
+		"checkout.cpu{env=staging",
+		"checkout.cpu{env}",
+		"checkout.cpu{__name__=foo}",
+	} {
+		_, err := parsePyroscopeName(name)
+		require.Error(t, err, name)
+	}
+}
+
+type testTrieNode struct {
+	name     string
+	value    uint64
+	children []testTrieNode
+}
+
+func (n testTrieNode) serialize(buf *bytes.Buffer) {
+	b := make([]byte, binary.MaxVarintLen64)
+	buf.Write(b[:binary.PutUvarint(b, uint64(len(n.name)))])
+	buf.WriteString(n.name)
+	buf.Write(b[:binary.PutUvarint(b, n.value)])
+	buf.Write(b[:binary.PutUvarint(b, uint64(len(n.children)))])
+	for _, c := range n.children {
+		c.serialize(buf)
+	}
+}
+
+func TestParsePyroscopeTrie(t *testing.T) {
+	var buf bytes.Buffer
+	testTrieNode{children: []testTrieNode{{
+		name: "main;",
+		children: []testTrieNode{{
+			name:  "work",
+			value: 3,
+			children: []testTrieNode{{
+				name:  ";json.Marshal",
+				value: 2,
+			}},
+		}, {
+			name:  "idle",
+			value: 1,
+		}},
+	}}}.serialize(&buf)
+
+	stacks, err := parsePyroscopeTrie(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []parcacol.FoldedStack{{
+		Frames: []string{"work", "main"},
+		Value:  3,
+	}, {
+		Frames: []string{"json.Marshal", "work", "main"},
+		Value:  2,
+	}, {
+		Frames: []string{"idle", "main"},
+		Value:  1,
+	}}, stacks)
+
+	_, err = parsePyroscopeTrie(buf.Bytes()[:buf.Len()-3])
+	require.Error(t, err)
+}
+
+func TestServePyroscopeIngest(t *testing.T) {
+	t.Parallel()
+
+	s := newTestProfileColumnStore(t, 0)
+
+	ingest := func(url, contentType string, body []byte) int {
+		req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		s.ServePyroscopeIngest(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, ingest(
+		"/ingest?name=checkout.cpu%7Benv%3Dstaging%7D&from=1660000000&until=1660000010&sampleRate=100&spyName=pyspy",
+		"",
+		[]byte("main;work 3\nmain;idle 1\n"),
+	))
+	require.Equal(t, http.StatusOK, ingest(
+		"/ingest?name=checkout.cpu&format=lines",
+		"",
+		[]byte("main;work\nmain;work\n"),
+	))
+
+	raw, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	var form bytes.Buffer
+	w := multipart.NewWriter(&form)
+	fw, err := w.CreateFormFile("profile", "profile.pprof")
+	require.NoError(t, err)
+	_, err = fw.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.Equal(t, http.StatusOK, ingest(
+		"/ingest?name=checkout.alloc_objects&format=pprof",
+		w.FormDataContentType(),
+		form.Bytes(),
+	))
+	require.Equal(t, http.StatusOK, ingest(
+		"/ingest?name=checkout.alloc_objects&format=pprof",
+		"application/octet-stream",
+		raw,
+	))
+
+	require.Equal(t, http.StatusBadRequest, ingest("/ingest?name=", "", []byte("main 1\n")))
+	require.Equal(t, http.StatusBadRequest, ingest("/ingest?name=checkout&format=jfr", "", []byte("main 1\n")))
+	require.Equal(t, http.StatusBadRequest, ingest("/ingest?name=checkout&units=parsecs", "", []byte("main 1\n")))
+	require.Equal(t, http.StatusBadRequest, ingest("/ingest?name=checkout&from=yesterday", "", []byte("main 1\n")))
+	require.Equal(t, http.StatusBadRequest, ingest("/ingest?name=checkout", "", []byte(strings.Repeat("main", 3))))
+
+	// Bodies larger than the profile size limit are not read.
+	require.NoError(t, s.ApplyLimits(&config.Limits{MaxProfileSizeBytes: 10}, nil))
+	require.Equal(t, http.StatusRequestEntityTooLarge, ingest("/ingest?name=checkout.cpu", "", []byte("main;work 3\nmain;idle 1\n")))
+	require.Equal(t, http.StatusRequestEntityTooLarge, ingest("/ingest?name=checkout.alloc_objects&format=pprof", "application/octet-stream", raw))
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	metastorepb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	t.Parallel()
+
+	store := newTestProfileColumnStore(t, 0)
+
+	raw, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	teamA := tenant.NewContext(context.Background(), "team-a")
+	_, err = store.WriteRaw(teamA, &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels: &profilestorepb.LabelSet{
+				Labels: []*profilestorepb.Label{{
+					Name:  "__name__",
+					Value: "memory",
+				}},
+			},
+			Samples: []*profilestorepb.RawSample{{
+				RawProfile: raw,
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	tenants, err := store.tables.Tenants(teamA)
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-a"}, tenants)
+
+	q := parcacol.NewQuerier(
+		trace.NewNoopTracerProvider().Tracer(""),
+		query.NewEngine(memory.DefaultAllocator, store.tables),
+		"stacktraces",
+		store.metastore,
+	)
+
+	types, err := q.ProfileTypes(teamA)
+	require.NoError(t, err)
+	require.NotEmpty(t, types)
+
+	p, err := q.QueryMerge(teamA, `memory:alloc_objects:count:space:bytes`, time.UnixMilli(0), time.UnixMilli(math.MaxInt64))
+	require.NoError(t, err)
+	require.NotEmpty(t, p.Samples)
+
+	// Neither other tenants nor requests without a tenant see the profile.
+	for _, ctx := range []context.Context{
+		tenant.NewContext(context.Background(), "team-b"),
+		context.Background(),
+	} {
+		types, err := q.ProfileTypes(ctx)
+		require.NoError(t, err)
+		require.Empty(t, types)
+	}
+	// Queries don't create tables.
+	tenants, err = store.tables.Tenants(teamA)
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-a"}, tenants)
+
+	// The metadata of a tenant is stored under its own prefix.
+	req := &metastorepb.LocationsRequest{LocationIds: []string{p.Samples[0].Locations[0].ID}}
+	_, err = store.metastore.Locations(teamA, req)
+	require.NoError(t, err)
+	_, err = store.metastore.Locations(tenant.NewContext(context.Background(), "team-b"), req)
+	require.Error(t, err)
+}
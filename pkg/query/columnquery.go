@@ -17,9 +17,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
@@ -27,16 +31,19 @@ import (
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	sharepb "github.com/parca-dev/parca/gen/proto/go/share"
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profile"
 )
 
 type Querier interface {
 	Labels(ctx context.Context, match []string, start, end time.Time) ([]string, error)
 	Values(ctx context.Context, labelName string, match []string, start, end time.Time) ([]string, error)
-	QueryRange(ctx context.Context, query string, startTime, endTime time.Time, limit uint32) ([]*pb.MetricsSeries, error)
+	QueryRange(ctx context.Context, query string, startTime, endTime time.Time, step time.Duration, limit uint32) ([]*pb.MetricsSeries, error)
 	ProfileTypes(ctx context.Context) ([]*pb.ProfileType, error)
 	QuerySingle(ctx context.Context, query string, time time.Time) (*profile.Profile, error)
 	QueryMerge(ctx context.Context, query string, start, end time.Time) (*profile.Profile, error)
+	QueryTrace(ctx context.Context, query string, start, end time.Time, traceID, spanID string) (*profile.Profile, error)
 }
 
 // ColumnQueryAPI is the read api interface for parca
@@ -48,6 +55,7 @@ type ColumnQueryAPI struct {
 	tracer      trace.Tracer
 	shareClient sharepb.ShareClient
 	querier     Querier
+	folder      *StackFolder
 }
 
 func NewColumnQueryAPI(
@@ -61,9 +69,16 @@ func NewColumnQueryAPI(
 		tracer:      tracer,
 		shareClient: shareClient,
 		querier:     querier,
+		folder:      NewStackFolder(),
 	}
 }
 
+// ApplyStackFoldingRules replaces the rules used to fold the stacks of
+// queried profiles.
+func (q *ColumnQueryAPI) ApplyStackFoldingRules(rules []*config.StackFoldingRule) error {
+	return q.folder.ApplyConfig(rules)
+}
+
 // Labels issues a labels request against the storage.
 func (q *ColumnQueryAPI) Labels(ctx context.Context, req *pb.LabelsRequest) (*pb.LabelsResponse, error) {
 	vals, err := q.querier.Labels(ctx, req.Match, req.Start.AsTime(), req.End.AsTime())
@@ -94,13 +109,17 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	res, err := q.querier.QueryRange(ctx, req.Query, req.Start.AsTime(), req.End.AsTime(), req.Limit)
+	stats := &parcacol.QueryStats{}
+	ctx = parcacol.ContextWithQueryStats(ctx, stats)
+
+	res, err := q.querier.QueryRange(ctx, req.Query, req.Start.AsTime(), req.End.AsTime(), req.Step.AsDuration(), req.Limit)
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.QueryRangeResponse{
 		Series: res,
+		Stats:  stats.Proto(),
 	}, nil
 }
 
@@ -122,6 +141,9 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	stats := &parcacol.QueryStats{}
+	ctx = parcacol.ContextWithQueryStats(ctx, stats)
+
 	var (
 		p   *profile.Profile
 		err error
@@ -134,6 +156,12 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 		p, err = q.selectMerge(ctx, req.GetMerge())
 	case pb.QueryRequest_MODE_DIFF:
 		p, err = q.selectDiff(ctx, req.GetDiff())
+	case pb.QueryRequest_MODE_LABEL_DIFF:
+		p, err = q.selectLabelDiff(ctx, req.GetLabelDiff())
+	case pb.QueryRequest_MODE_RATIO:
+		p, err = q.selectRatio(ctx, req.GetRatio())
+	case pb.QueryRequest_MODE_TRACE:
+		p, err = q.selectTrace(ctx, req.GetTrace())
 	default:
 		return nil, status.Error(codes.InvalidArgument, "unknown query mode")
 	}
@@ -142,7 +170,17 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 		return nil, err
 	}
 
-	return q.renderReport(ctx, p, req.GetReportType())
+	p = q.folder.Fold(p)
+
+	start := time.Now()
+	resp, err := q.renderReport(ctx, p, req.GetReportType())
+	if err != nil {
+		return nil, err
+	}
+	stats.ObserveRender(start)
+
+	resp.Stats = stats.Proto()
+	return resp, nil
 }
 
 func (q *ColumnQueryAPI) renderReport(ctx context.Context, p *profile.Profile, typ pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
@@ -224,6 +262,22 @@ func (q *ColumnQueryAPI) selectMerge(ctx context.Context, m *pb.MergeProfile) (*
 	return p, nil
 }
 
+// selectTrace merges the samples recorded in the requested trace or span.
+func (q *ColumnQueryAPI) selectTrace(ctx context.Context, t *pb.TraceProfile) (*profile.Profile, error) {
+	if t == nil {
+		return nil, status.Error(codes.InvalidArgument, "requested trace mode, but did not provide parameters for trace")
+	}
+
+	return q.querier.QueryTrace(
+		ctx,
+		t.Query,
+		t.Start.AsTime(),
+		t.End.AsTime(),
+		t.TraceId,
+		t.SpanId,
+	)
+}
+
 func (q *ColumnQueryAPI) selectDiff(ctx context.Context, d *pb.DiffProfile) (*profile.Profile, error) {
 	ctx, span := q.tracer.Start(ctx, "diffRequest")
 	defer span.End()
@@ -242,6 +296,204 @@ func (q *ColumnQueryAPI) selectDiff(ctx context.Context, d *pb.DiffProfile) (*pr
 		return nil, fmt.Errorf("reading compared profile: %w", err)
 	}
 
+	return diffProfiles(base, compare), nil
+}
+
+// selectLabelDiff merges the profiles matching the query for each of the two
+// label values and diffs the results.
+func (q *ColumnQueryAPI) selectLabelDiff(ctx context.Context, d *pb.LabelDiffProfile) (*profile.Profile, error) {
+	ctx, span := q.tracer.Start(ctx, "labelDiffRequest")
+	defer span.End()
+
+	if d == nil {
+		return nil, status.Error(codes.InvalidArgument, "requested label diff mode, but did not provide parameters for diff")
+	}
+
+	selector, filters, err := parcacol.SplitQuery(d.Query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse query: %v", err)
+	}
+	matchers, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "failed to parse query")
+	}
+	for _, m := range matchers {
+		if m.Name == d.LabelName {
+			return nil, status.Errorf(codes.InvalidArgument, "query must not select the diffed label %q", d.LabelName)
+		}
+	}
+
+	base, err := q.selectMerge(ctx, &pb.MergeProfile{
+		Query: selectorWithLabel(matchers, d.LabelName, d.A) + joinFilters(filters),
+		Start: d.Start,
+		End:   d.End,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading base profile: %w", err)
+	}
+
+	compare, err := q.selectMerge(ctx, &pb.MergeProfile{
+		Query: selectorWithLabel(matchers, d.LabelName, d.B) + joinFilters(filters),
+		Start: d.Start,
+		End:   d.End,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading compared profile: %w", err)
+	}
+
+	return diffProfiles(base, compare), nil
+}
+
+// selectorString returns the selector of the matchers.
+func selectorString(matchers []*labels.Matcher) string {
+	parts := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		parts = append(parts, m.String())
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// selectorWithLabel returns a selector of the matchers and an additional
+// equality matcher of the label.
+func selectorWithLabel(matchers []*labels.Matcher, name, value string) string {
+	all := make([]*labels.Matcher, 0, len(matchers)+1)
+	all = append(all, matchers...)
+	all = append(all, labels.MustNewMatcher(labels.MatchEqual, name, value))
+
+	return selectorString(all)
+}
+
+// joinFilters returns the numeric label filters to append to a selector.
+func joinFilters(filters []string) string {
+	var b strings.Builder
+	for _, f := range filters {
+		b.WriteString(" | ")
+		b.WriteString(f)
+	}
+	return b.String()
+}
+
+// defaultRatioScale is the scale of ratios of requests that don't set one.
+// Ratios of different units are often far below one, for example bytes
+// allocated per nanosecond of CPU time, so they are reported in millionths.
+const defaultRatioScale = 1e6
+
+// selectRatio merges both profile types over the same selector and window and
+// returns a profile of the ratio of their values per stacktrace. Stacktraces
+// that are missing from the denominator are left out, as their ratio is not
+// defined.
+func (q *ColumnQueryAPI) selectRatio(ctx context.Context, r *pb.RatioProfile) (*profile.Profile, error) {
+	ctx, span := q.tracer.Start(ctx, "ratioRequest")
+	defer span.End()
+
+	if r == nil {
+		return nil, status.Error(codes.InvalidArgument, "requested ratio mode, but did not provide parameters for ratio")
+	}
+
+	matchers, err := ratioMatchers(r.Selector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid selector: %v", err)
+	}
+	query := func(profileType string) string {
+		return selectorWithLabel(matchers, labels.MetricName, profileType)
+	}
+
+	numerator, err := q.selectMerge(ctx, &pb.MergeProfile{
+		Query: query(r.Numerator),
+		Start: r.Start,
+		End:   r.End,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading numerator profile: %w", err)
+	}
+
+	denominator, err := q.selectMerge(ctx, &pb.MergeProfile{
+		Query: query(r.Denominator),
+		Start: r.Start,
+		End:   r.End,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading denominator profile: %w", err)
+	}
+
+	scale := r.Scale
+	if scale == 0 {
+		scale = defaultRatioScale
+	}
+
+	return ratioProfiles(numerator, denominator, scale), nil
+}
+
+// ratioMatchers returns the matchers of the selector of a ratio query, which
+// may be empty but must not select a profile type.
+func ratioMatchers(selector string) ([]*labels.Matcher, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" || selector == "{}" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(selector, "{") {
+		return nil, fmt.Errorf("must be a set of label matchers in braces")
+	}
+
+	matchers, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range matchers {
+		if m.Name == labels.MetricName {
+			return nil, fmt.Errorf("must not select the profile type")
+		}
+	}
+	return matchers, nil
+}
+
+// stacktraceKey identifies the stacktrace of a sample by its locations.
+func stacktraceKey(s *profile.SymbolizedSample) string {
+	ids := make([]string, 0, len(s.Locations))
+	for _, l := range s.Locations {
+		ids = append(ids, l.ID)
+	}
+	return strings.Join(ids, "/")
+}
+
+func ratioProfiles(numerator, denominator *profile.Profile, scale float64) *profile.Profile {
+	denominators := make(map[string]int64, len(denominator.Samples))
+	for _, s := range denominator.Samples {
+		denominators[stacktraceKey(s)] += s.Value
+	}
+
+	ratio := &profile.Profile{
+		Meta: profile.Meta{
+			Name: numerator.Meta.Name + "/" + denominator.Meta.Name,
+			SampleType: profile.ValueType{
+				Type: numerator.Meta.SampleType.Type + "/" + denominator.Meta.SampleType.Type,
+				Unit: numerator.Meta.SampleType.Unit + "/" + denominator.Meta.SampleType.Unit,
+			},
+			PeriodType: numerator.Meta.PeriodType,
+			Timestamp:  numerator.Meta.Timestamp,
+		},
+	}
+
+	for _, s := range numerator.Samples {
+		d := denominators[stacktraceKey(s)]
+		if d == 0 {
+			continue
+		}
+
+		ratio.Samples = append(ratio.Samples, &profile.SymbolizedSample{
+			Locations: s.Locations,
+			Value:     int64(math.Round(float64(s.Value) / float64(d) * scale)),
+			Label:     s.Label,
+			NumLabel:  s.NumLabel,
+			NumUnit:   s.NumUnit,
+		})
+	}
+
+	return ratio
+}
+
+func diffProfiles(base, compare *profile.Profile) *profile.Profile {
 	// TODO: This is cheating a bit. This should be done with a sub-query in the columnstore.
 	diff := &profile.Profile{}
 
@@ -253,6 +505,7 @@ func (q *ColumnQueryAPI) selectDiff(ctx context.Context, d *pb.DiffProfile) (*pr
 			DiffValue: compare.Samples[i].Value,
 			Label:     compare.Samples[i].Label,
 			NumLabel:  compare.Samples[i].NumLabel,
+			NumUnit:   compare.Samples[i].NumUnit,
 		})
 	}
 
@@ -262,10 +515,11 @@ func (q *ColumnQueryAPI) selectDiff(ctx context.Context, d *pb.DiffProfile) (*pr
 			DiffValue: -base.Samples[i].Value,
 			Label:     base.Samples[i].Label,
 			NumLabel:  base.Samples[i].NumLabel,
+			NumUnit:   base.Samples[i].NumUnit,
 		})
 	}
 
-	return diff, nil
+	return diff
 }
 
 func (q *ColumnQueryAPI) selectProfileForDiff(ctx context.Context, s *pb.ProfileDiffSelection) (*profile.Profile, error) {
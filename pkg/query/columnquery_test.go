@@ -18,8 +18,11 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/tls"
+	"fmt"
 	"io"
+	"math"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +31,7 @@ import (
 	columnstore "github.com/polarsignals/frostdb"
 	"github.com/polarsignals/frostdb/query"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/stretchr/testify/require"
@@ -37,6 +41,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
@@ -44,10 +49,12 @@ import (
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	"github.com/parca-dev/parca/gen/proto/go/share"
 	sharepb "github.com/parca-dev/parca/gen/proto/go/share"
+	"github.com/parca-dev/parca/pkg/config"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/metastoretest"
 	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 func getShareServerConn(t Testing) share.ShareClient {
@@ -167,7 +174,7 @@ func TestColumnQueryAPIQueryRange(t *testing.T) {
 	require.NoError(t, err)
 
 	metastore := metastore.NewInProcessClient(m)
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
 
 	for _, f := range files {
@@ -243,7 +250,7 @@ func TestColumnQueryAPIQuerySingle(t *testing.T) {
 	require.NoError(t, err)
 
 	metastore := metastore.NewInProcessClient(m)
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
 
 	err = ingester.Ingest(ctx, labels.Labels{{
@@ -297,6 +304,655 @@ func TestColumnQueryAPIQuerySingle(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestQuerierStacktraceIDs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col, err := columnstore.New()
+	require.NoError(t, err)
+	colDB, err := col.DB(context.Background(), "parca")
+	require.NoError(t, err)
+
+	schema, err := parcacol.Schema()
+	require.NoError(t, err)
+
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(schema),
+	)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+
+	p := &pprofpb.Profile{}
+	require.NoError(t, p.UnmarshalVT(MustReadAllGzip(t, "testdata/alloc_objects.pb.gz")))
+
+	metastore := metastore.NewInProcessClient(m)
+	ingester := parcacol.NewIngester(logger, parcacol.NewNormalizer(metastore, nil), table, schema)
+	require.NoError(t, ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}}, p, false))
+
+	querier := parcacol.NewQuerier(
+		tracer,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		"stacktraces",
+		metastore,
+	)
+
+	ids := map[string]struct{}{}
+	require.NoError(t, querier.StacktraceIDs(ctx, func(id string) {
+		ids[id] = struct{}{}
+	}))
+	require.NotEmpty(t, ids)
+
+	req := &metastorepb.StacktracesRequest{}
+	for id := range ids {
+		req.StacktraceIds = append(req.StacktraceIds, id)
+	}
+	res, err := metastore.Stacktraces(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, res.Stacktraces, len(ids))
+}
+
+func TestColumnQueryAPIQueryNumLabels(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col, err := columnstore.New()
+	require.NoError(t, err)
+	colDB, err := col.DB(context.Background(), "parca")
+	require.NoError(t, err)
+
+	schema, err := parcacol.Schema()
+	require.NoError(t, err)
+
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(schema),
+	)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+
+	// The samples of heap profiles have a bytes label without a unit.
+	fileContent := MustReadAllGzip(t, "testdata/alloc_objects.pb.gz")
+	p := &pprofpb.Profile{}
+	err = p.UnmarshalVT(fileContent)
+	require.NoError(t, err)
+
+	metastore := metastore.NewInProcessClient(m)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
+	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
+
+	err = ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "default",
+	}}, p, false)
+	require.NoError(t, err)
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		getShareServerConn(t),
+		parcacol.NewQuerier(
+			tracer,
+			query.NewEngine(
+				memory.DefaultAllocator,
+				colDB.TableProvider(),
+			),
+			"stacktraces",
+			metastore,
+		),
+	)
+	ts := timestamppb.New(timestamp.Time(p.TimeNanos / time.Millisecond.Nanoseconds()))
+	queryPprof := func(query string) *pprofpb.Profile {
+		res, err := api.Query(ctx, &pb.QueryRequest{
+			ReportType: pb.QueryRequest_REPORT_TYPE_PPROF,
+			Options: &pb.QueryRequest_Single{
+				Single: &pb.SingleProfile{
+					Query: query,
+					Time:  ts,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		p := &pprofpb.Profile{}
+		require.NoError(t, p.UnmarshalVT(MustDecompressGzip(t, res.Report.(*pb.QueryResponse_Pprof).Pprof)))
+		return p
+	}
+	bytesLabels := func(p *pprofpb.Profile) []int64 {
+		var res []int64
+		for _, s := range p.Sample {
+			for _, l := range s.Label {
+				if p.StringTable[l.Key] == "bytes" {
+					require.Equal(t, "bytes", p.StringTable[l.NumUnit])
+					res = append(res, l.Num)
+				}
+			}
+		}
+		return res
+	}
+
+	all := bytesLabels(queryPprof(`memory:alloc_objects:count:space:bytes{job="default"}`))
+	require.NotEmpty(t, all)
+
+	large := bytesLabels(queryPprof(`memory:alloc_objects:count:space:bytes{job="default"} | bytes >= 1KiB`))
+	require.NotEmpty(t, large)
+	require.Less(t, len(large), len(all))
+	for _, v := range large {
+		require.GreaterOrEqual(t, v, int64(1024))
+	}
+
+	// Quantities without a unit compare the raw values.
+	require.Equal(t, large, bytesLabels(queryPprof(`memory:alloc_objects:count:space:bytes{job="default"} | bytes >= 1024`)))
+
+	for query, code := range map[string]codes.Code{
+		// Quantities of another dimension don't match.
+		`memory:alloc_objects:count:space:bytes{job="default"} | bytes > 1ms`: codes.NotFound,
+		`memory:alloc_objects:count:space:bytes{job="default"} | bytes ~ 1`:   codes.InvalidArgument,
+		`memory:alloc_objects:count:space:bytes{job="default"} bytes > 1`:     codes.InvalidArgument,
+	} {
+		_, err = api.Query(ctx, &pb.QueryRequest{
+			Options: &pb.QueryRequest_Single{
+				Single: &pb.SingleProfile{
+					Query: query,
+					Time:  ts,
+				},
+			},
+		})
+		require.Equal(t, code, status.Code(err), query)
+	}
+}
+
+func TestColumnQueryAPIQueryTrace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col, err := columnstore.New()
+	require.NoError(t, err)
+	colDB, err := col.DB(context.Background(), "parca")
+	require.NoError(t, err)
+
+	schema, err := parcacol.Schema()
+	require.NoError(t, err)
+
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(schema),
+	)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+
+	// The first two samples are recorded in spans of the same trace, the
+	// third one in another trace and the last one outside of any trace.
+	p := &pprofpb.Profile{
+		StringTable: []string{"", "samples", "count", "cpu", "nanoseconds", "a", "b", "c", "trace_id", "span_id", "0AF7651916CD43DD8448EB211C80319C", "b7ad6b7169203331", "00f067aa0ba902b7", "4bf92f3577b34da6a3ce929d0e0e4736", "53995c3f42cd8ad8"},
+		SampleType:  []*pprofpb.ValueType{{Type: 1, Unit: 2}},
+		PeriodType:  &pprofpb.ValueType{Type: 3, Unit: 4},
+		Period:      10000000,
+		TimeNanos:   time.Second.Nanoseconds(),
+		Function:    []*pprofpb.Function{{Id: 1, Name: 5}, {Id: 2, Name: 6}, {Id: 3, Name: 7}},
+		Location: []*pprofpb.Location{
+			{Id: 1, Line: []*pprofpb.Line{{FunctionId: 1}}},
+			{Id: 2, Line: []*pprofpb.Line{{FunctionId: 2}}},
+			{Id: 3, Line: []*pprofpb.Line{{FunctionId: 3}}},
+		},
+		Sample: []*pprofpb.Sample{{
+			LocationId: []uint64{1},
+			Value:      []int64{10},
+			Label:      []*pprofpb.Label{{Key: 8, Str: 10}, {Key: 9, Str: 11}},
+		}, {
+			LocationId: []uint64{2, 1},
+			Value:      []int64{20},
+			Label:      []*pprofpb.Label{{Key: 8, Str: 10}, {Key: 9, Str: 12}},
+		}, {
+			LocationId: []uint64{3},
+			Value:      []int64{5},
+			Label:      []*pprofpb.Label{{Key: 8, Str: 13}, {Key: 9, Str: 14}},
+		}, {
+			LocationId: []uint64{1},
+			Value:      []int64{7},
+		}},
+	}
+
+	metastore := metastore.NewInProcessClient(m)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
+	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
+
+	err = ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "process_cpu",
+	}, {
+		Name:  "job",
+		Value: "default",
+	}}, p, false)
+	require.NoError(t, err)
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		getShareServerConn(t),
+		parcacol.NewQuerier(
+			tracer,
+			query.NewEngine(
+				memory.DefaultAllocator,
+				colDB.TableProvider(),
+			),
+			"stacktraces",
+			metastore,
+		),
+	)
+
+	const query = `process_cpu:samples:count:cpu:nanoseconds{job="default"}`
+	start := timestamppb.New(timestamp.Time(0))
+	end := timestamppb.New(timestamp.Time(math.MaxInt64))
+
+	queryTrace := func(traceID, spanID string) *pprofpb.Profile {
+		res, err := api.Query(ctx, &pb.QueryRequest{
+			Mode:       pb.QueryRequest_MODE_TRACE,
+			ReportType: pb.QueryRequest_REPORT_TYPE_PPROF,
+			Options: &pb.QueryRequest_Trace{
+				Trace: &pb.TraceProfile{
+					Query:   query,
+					Start:   start,
+					End:     end,
+					TraceId: traceID,
+					SpanId:  spanID,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		p := &pprofpb.Profile{}
+		require.NoError(t, p.UnmarshalVT(MustDecompressGzip(t, res.Report.(*pb.QueryResponse_Pprof).Pprof)))
+		return p
+	}
+	total := func(p *pprofpb.Profile) int64 {
+		var res int64
+		for _, s := range p.Sample {
+			// The trace and span IDs are not returned as pprof labels.
+			require.Empty(t, s.Label)
+			res += s.Value[0]
+		}
+		return res
+	}
+
+	require.Equal(t, int64(30), total(queryTrace("0af7651916cd43dd8448eb211c80319c", "")))
+	require.Equal(t, int64(20), total(queryTrace("0AF7651916CD43DD8448EB211C80319C", "00f067aa0ba902b7")))
+	require.Equal(t, int64(5), total(queryTrace("", "53995c3f42cd8ad8")))
+
+	_, err = api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_TRACE,
+		Options: &pb.QueryRequest_Trace{
+			Trace: &pb.TraceProfile{
+				Query: query,
+				Start: start,
+				End:   end,
+			},
+		},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	res, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: query,
+		Start: start,
+		End:   end,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Series, 1)
+	require.Len(t, res.Series[0].Samples, 1)
+	require.Equal(t, int64(42), res.Series[0].Samples[0].Value)
+	require.Equal(t, []*pb.Exemplar{
+		{TraceId: "0af7651916cd43dd8448eb211c80319c", SpanId: "00f067aa0ba902b7", Value: 20},
+		{TraceId: "0af7651916cd43dd8448eb211c80319c", SpanId: "b7ad6b7169203331", Value: 10},
+		{TraceId: "4bf92f3577b34da6a3ce929d0e0e4736", SpanId: "53995c3f42cd8ad8", Value: 5},
+	}, res.Series[0].Samples[0].Exemplars)
+}
+
+func TestColumnQueryAPIQueryRollups(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col, err := columnstore.New()
+	require.NoError(t, err)
+	colDB, err := col.DB(context.Background(), "parca")
+	require.NoError(t, err)
+	emptyDB, err := col.DB(context.Background(), "empty")
+	require.NoError(t, err)
+
+	schema, err := parcacol.Schema()
+	require.NoError(t, err)
+
+	tables := parcacol.NewTables(colDB, schema, "stacktraces", nil)
+	rollupTables := parcacol.NewTables(colDB, schema, "rollups", nil)
+	table, err := tables.Table(tenant.Default)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+
+	dir := "./testdata/many/"
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	metastore := metastore.NewInProcessClient(m)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
+	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
+
+	for _, f := range files {
+		p := &pprofpb.Profile{}
+		err = p.UnmarshalVT(MustReadAllGzip(t, dir+f.Name()))
+		require.NoError(t, err)
+
+		err = ingester.Ingest(ctx, labels.Labels{{
+			Name:  "__name__",
+			Value: "memory",
+		}, {
+			Name:  "job",
+			Value: "default",
+		}}, p, false)
+		require.NoError(t, err)
+	}
+
+	engine := query.NewEngine(
+		memory.DefaultAllocator,
+		parcacol.MultiTableProvider{tables, rollupTables},
+	)
+	rollups := parcacol.NewRollups(logger, reg, engine, tables, rollupTables)
+	require.NoError(t, rollups.ApplyConfig(ctx, &config.Rollups{
+		After:      model.Duration(10 * time.Second),
+		Resolution: model.Duration(10 * time.Second),
+		Interval:   model.Duration(time.Minute),
+	}))
+
+	newAPI := func(engine parcacol.Engine, rollups *parcacol.Rollups) *ColumnQueryAPI {
+		return NewColumnQueryAPI(
+			logger,
+			tracer,
+			getShareServerConn(t),
+			parcacol.NewQuerier(
+				tracer,
+				engine,
+				"stacktraces",
+				metastore,
+			).WithRollups(rollups),
+		)
+	}
+
+	const q = `memory:alloc_objects:count:space:bytes{job="default"}`
+	// The profiles are written every three seconds, the range starts and
+	// ends in the middle of the buckets of the rollups.
+	start := timestamppb.New(timestamp.Time(1644509274000))
+	end := timestamppb.New(timestamp.Time(1644509296000))
+	queryMerge := func(api *ColumnQueryAPI, q string, start, end *timestamppb.Timestamp) map[string]int64 {
+		res, err := api.Query(ctx, &pb.QueryRequest{
+			Mode:       pb.QueryRequest_MODE_MERGE,
+			ReportType: pb.QueryRequest_REPORT_TYPE_PPROF,
+			Options: &pb.QueryRequest_Merge{
+				Merge: &pb.MergeProfile{
+					Query: q,
+					Start: start,
+					End:   end,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		p := &pprofpb.Profile{}
+		require.NoError(t, p.UnmarshalVT(MustDecompressGzip(t, res.Report.(*pb.QueryResponse_Pprof).Pprof)))
+		// Samples are keyed by their function names, as the IDs of
+		// locations differ between reports.
+		functions := map[uint64]string{}
+		for _, f := range p.Function {
+			functions[f.Id] = p.StringTable[f.Name]
+		}
+		locations := map[uint64]string{}
+		for _, l := range p.Location {
+			names := make([]string, 0, len(l.Line))
+			for _, line := range l.Line {
+				names = append(names, functions[line.FunctionId])
+			}
+			locations[l.Id] = fmt.Sprintf("%x:%s", l.Address, strings.Join(names, ","))
+		}
+		values := map[string]int64{}
+		for _, s := range p.Sample {
+			stack := make([]string, 0, len(s.LocationId))
+			for _, id := range s.LocationId {
+				stack = append(stack, locations[id])
+			}
+			values[strings.Join(stack, "/")] += s.Value[0]
+		}
+		return values
+	}
+	queryRange := func(api *ColumnQueryAPI, q string, start, end *timestamppb.Timestamp) []*pb.MetricsSeries {
+		res, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
+			Query: q,
+			Start: start,
+			End:   end,
+			Step:  durationpb.New(10 * time.Second),
+		})
+		require.NoError(t, err)
+		return res.Series
+	}
+
+	api := newAPI(engine, rollups)
+	allStart := timestamppb.New(timestamp.Time(0))
+	allEnd := timestamppb.New(timestamp.Time(math.MaxInt64))
+	expectedMerge := queryMerge(api, q, start, end)
+	expectedAllMerge := queryMerge(api, q, allStart, allEnd)
+	expectedRange := queryRange(api, q, start, end)
+	expectedAllRange := queryRange(api, q, allStart, allEnd)
+	require.NotEmpty(t, expectedMerge)
+	require.Len(t, expectedAllRange, 1)
+	require.Len(t, expectedAllRange[0].Samples, 3)
+
+	require.NoError(t, rollups.Compact(ctx))
+
+	// Reading the rollups in the middle of the range and the profiles at its
+	// edges returns the same values as reading only the profiles.
+	require.Equal(t, expectedMerge, queryMerge(api, q, start, end))
+	require.Equal(t, expectedAllMerge, queryMerge(api, q, allStart, allEnd))
+	requireSeriesEqual(t, expectedRange, queryRange(api, q, start, end))
+	requireSeriesEqual(t, expectedAllRange, queryRange(api, q, allStart, allEnd))
+
+	// All profiles can be read from the rollups alone.
+	rollupsOnly := newAPI(query.NewEngine(
+		memory.DefaultAllocator,
+		parcacol.MultiTableProvider{parcacol.NewTables(emptyDB, schema, "stacktraces", nil), rollupTables},
+	), rollups)
+	require.Equal(t, expectedAllMerge, queryMerge(rollupsOnly, q, allStart, allEnd))
+	requireSeriesEqual(t, expectedAllRange, queryRange(rollupsOnly, q, allStart, allEnd))
+
+	// The rollups keep the pprof labels, so queries filtering them read the
+	// rollups as well.
+	const filtered = q + " | bytes >= 1KiB"
+	expectedFiltered := queryMerge(api, filtered, allStart, allEnd)
+	require.NotEmpty(t, expectedFiltered)
+	require.NotEqual(t, expectedAllMerge, expectedFiltered)
+	require.Equal(t, expectedFiltered, queryMerge(rollupsOnly, filtered, allStart, allEnd))
+	requireSeriesEqual(t, queryRange(api, filtered, allStart, allEnd), queryRange(rollupsOnly, filtered, allStart, allEnd))
+
+	// Without a step every profile is returned, so no rollups are read.
+	_, err = rollupsOnly.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: q,
+		Start: allStart,
+		End:   allEnd,
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// The resolution the profiles were rolled up with is persisted, it can't
+	// be changed after a restart.
+	restarted := parcacol.NewRollups(logger, prometheus.NewRegistry(), engine, tables, rollupTables)
+	require.Error(t, restarted.ApplyConfig(ctx, &config.Rollups{
+		After:      model.Duration(10 * time.Second),
+		Resolution: model.Duration(20 * time.Second),
+		Interval:   model.Duration(time.Minute),
+	}))
+
+	// Profiles written to buckets that were rolled up before, here after a
+	// restart, are added to the rollups of their bucket.
+	require.NoError(t, restarted.ApplyConfig(ctx, &config.Rollups{
+		After:      model.Duration(10 * time.Second),
+		Resolution: model.Duration(10 * time.Second),
+		Interval:   model.Duration(time.Minute),
+	}))
+	p := &pprofpb.Profile{}
+	require.NoError(t, p.UnmarshalVT(MustReadAllGzip(t, dir+files[0].Name())))
+	require.NoError(t, parcacol.NewIngester(logger, normalizer, table, schema).WithRollups(restarted).Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "default",
+	}}, p, false))
+
+	raw := newAPI(engine, nil)
+	rollupsOnly = newAPI(query.NewEngine(
+		memory.DefaultAllocator,
+		parcacol.MultiTableProvider{parcacol.NewTables(emptyDB, schema, "stacktraces", nil), rollupTables},
+	), restarted)
+	require.NotEqual(t, expectedAllMerge, queryMerge(raw, q, allStart, allEnd))
+	require.Equal(t, queryMerge(raw, q, allStart, allEnd), queryMerge(rollupsOnly, q, allStart, allEnd))
+	requireSeriesEqual(t, queryRange(raw, q, allStart, allEnd), queryRange(rollupsOnly, q, allStart, allEnd))
+}
+
+func requireSeriesEqual(t *testing.T, expected, actual []*pb.MetricsSeries) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.True(t, proto.Equal(expected[i], actual[i]), "expected %v, got %v", expected[i], actual[i])
+	}
+}
+
+func TestColumnQueryAPIQueryStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col, err := columnstore.New()
+	require.NoError(t, err)
+	colDB, err := col.DB(context.Background(), "parca")
+	require.NoError(t, err)
+
+	schema, err := parcacol.Schema()
+	require.NoError(t, err)
+
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(schema),
+	)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+
+	fileContent := MustReadAllGzip(t, "testdata/alloc_objects.pb.gz")
+	p := &pprofpb.Profile{}
+	err = p.UnmarshalVT(fileContent)
+	require.NoError(t, err)
+
+	metastore := metastore.NewInProcessClient(m)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
+	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
+
+	err = ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "default",
+	}}, p, false)
+	require.NoError(t, err)
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		getShareServerConn(t),
+		parcacol.NewQuerier(
+			tracer,
+			query.NewEngine(
+				memory.DefaultAllocator,
+				parcacol.NewStatsTableProvider(colDB.TableProvider()),
+			),
+			"stacktraces",
+			metastore,
+		),
+	)
+	res, err := api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_MERGE,
+		Options: &pb.QueryRequest_Merge{
+			Merge: &pb.MergeProfile{
+				Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+				Start: timestamppb.New(timestamp.Time(0)),
+				End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	stats := res.Stats
+	require.NotNil(t, stats)
+	require.NotZero(t, stats.RowsScanned)
+	require.NotZero(t, stats.DistinctStacktraces)
+	require.LessOrEqual(t, stats.DistinctStacktraces, stats.RowsScanned)
+	require.Equal(t, uint64(4), stats.MetastoreLookups)
+	require.NotZero(t, stats.SelectDuration.AsDuration())
+	require.NotZero(t, stats.ResolveStacktracesDuration.AsDuration())
+	require.NotZero(t, stats.RenderReportDuration.AsDuration())
+
+	rangeRes, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, stats.RowsScanned, rangeRes.Stats.RowsScanned)
+	require.Zero(t, rangeRes.Stats.MetastoreLookups)
+}
+
 func TestColumnQueryAPIQueryFgprof(t *testing.T) {
 	t.Parallel()
 
@@ -331,7 +987,7 @@ func TestColumnQueryAPIQueryFgprof(t *testing.T) {
 	p.TimeNanos = time.Now().UnixNano()
 
 	metastore := metastore.NewInProcessClient(m)
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
 	err = ingester.Ingest(ctx, labels.Labels{{
 		Name:  "__name__",
@@ -459,7 +1115,7 @@ func TestColumnQueryAPIQueryDiff(t *testing.T) {
 	require.Equal(t, 1, len(sres.Stacktraces))
 	st2 := sres.Stacktraces[0]
 
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
 
 	err = ingester.IngestProfile(
@@ -620,6 +1276,286 @@ func TestColumnQueryAPIQueryDiff(t *testing.T) {
 	require.Equal(t, []int64{-1}, testProf.Sample[1].Value)
 }
 
+func TestColumnQueryAPIQueryLabelDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col, err := columnstore.New()
+	require.NoError(t, err)
+	colDB, err := col.DB(context.Background(), "parca")
+	require.NoError(t, err)
+
+	schema, err := parcacol.Schema()
+	require.NoError(t, err)
+
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(schema),
+	)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+	metastore := metastore.NewInProcessClient(m)
+
+	fres, err := m.GetOrCreateFunctions(ctx, &metastorepb.GetOrCreateFunctionsRequest{
+		Functions: []*metastorepb.Function{{
+			Name: "testFunc",
+		}},
+	})
+	require.NoError(t, err)
+
+	lres, err := m.GetOrCreateLocations(ctx, &metastorepb.GetOrCreateLocationsRequest{
+		Locations: []*metastorepb.Location{{
+			Address: 0x1,
+			Lines: []*metastorepb.Line{{
+				Line:       1,
+				FunctionId: fres.Functions[0].Id,
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	sres, err := m.GetOrCreateStacktraces(ctx, &metastorepb.GetOrCreateStacktracesRequest{
+		Stacktraces: []*metastorepb.Stacktrace{{
+			LocationIds: []string{lres.Locations[0].Id},
+		}},
+	})
+	require.NoError(t, err)
+	st := sres.Stacktraces[0]
+
+	normalizer := parcacol.NewNormalizer(metastore, nil)
+	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
+
+	for i, version := range []string{"v1", "v2"} {
+		err = ingester.IngestProfile(
+			ctx,
+			labels.Labels{{Name: "job", Value: "default"}, {Name: "version", Value: version}},
+			&profile.NormalizedProfile{
+				Meta: profile.Meta{
+					Name:       "memory",
+					PeriodType: profile.ValueType{Type: "space", Unit: "bytes"},
+					SampleType: profile.ValueType{Type: "alloc_objects", Unit: "count"},
+					Timestamp:  int64(i + 1),
+				},
+				Samples: []*profile.NormalizedSample{{
+					StacktraceID: st.Id,
+					Value:        int64(2*i + 1),
+				}, {
+					StacktraceID: st.Id,
+					Value:        int64(10 * (i + 1)),
+					NumLabel:     map[string]int64{"bytes": 2048},
+					NumUnit:      map[string]string{"bytes": "bytes"},
+				}},
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		getShareServerConn(t),
+		parcacol.NewQuerier(
+			tracer,
+			query.NewEngine(
+				memory.DefaultAllocator,
+				colDB.TableProvider(),
+			),
+			"stacktraces",
+			metastore,
+		),
+	)
+
+	res, err := api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_LABEL_DIFF,
+		Options: &pb.QueryRequest_LabelDiff{
+			LabelDiff: &pb.LabelDiffProfile{
+				Query:     `memory:alloc_objects:count:space:bytes{job="default"}`,
+				Start:     timestamppb.New(timestamp.Time(0)),
+				End:       timestamppb.New(timestamp.Time(3)),
+				LabelName: "version",
+				A:         "v1",
+				B:         "v2",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	fg := res.Report.(*pb.QueryResponse_Flamegraph).Flamegraph
+	require.Equal(t, 1, len(fg.Root.Children))
+	require.Equal(t, int64(23), fg.Root.Children[0].Cumulative)
+	require.Equal(t, int64(12), fg.Root.Children[0].Diff)
+
+	// Numeric label filters apply to both profiles.
+	res, err = api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_LABEL_DIFF,
+		Options: &pb.QueryRequest_LabelDiff{
+			LabelDiff: &pb.LabelDiffProfile{
+				Query:     `memory:alloc_objects:count:space:bytes{job="default"} | bytes >= 1KiB`,
+				Start:     timestamppb.New(timestamp.Time(0)),
+				End:       timestamppb.New(timestamp.Time(3)),
+				LabelName: "version",
+				A:         "v1",
+				B:         "v2",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	fg = res.Report.(*pb.QueryResponse_Flamegraph).Flamegraph
+	require.Equal(t, 1, len(fg.Root.Children))
+	require.Equal(t, int64(20), fg.Root.Children[0].Cumulative)
+	require.Equal(t, int64(10), fg.Root.Children[0].Diff)
+
+	_, err = api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_LABEL_DIFF,
+		Options: &pb.QueryRequest_LabelDiff{
+			LabelDiff: &pb.LabelDiffProfile{
+				Query:     `memory:alloc_objects:count:space:bytes{version="v1"}`,
+				Start:     timestamppb.New(timestamp.Time(0)),
+				End:       timestamppb.New(timestamp.Time(3)),
+				LabelName: "version",
+				A:         "v1",
+				B:         "v2",
+			},
+		},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRatioProfiles(t *testing.T) {
+	t.Parallel()
+
+	a := &profile.Location{ID: "a"}
+	b := &profile.Location{ID: "b"}
+	c := &profile.Location{ID: "c"}
+	d := &profile.Location{ID: "d"}
+
+	numerator := &profile.Profile{
+		Meta: profile.Meta{
+			Name:       "memory",
+			SampleType: profile.ValueType{Type: "alloc_space", Unit: "bytes"},
+		},
+		Samples: []*profile.SymbolizedSample{
+			{Locations: []*profile.Location{a, b}, Value: 300},
+			{Locations: []*profile.Location{b}, Value: 10},
+			// Only in the numerator.
+			{Locations: []*profile.Location{c}, Value: 5},
+			// Zero in the denominator.
+			{Locations: []*profile.Location{a}, Value: 7},
+		},
+	}
+	denominator := &profile.Profile{
+		Meta: profile.Meta{
+			Name:       "process_cpu",
+			SampleType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+		},
+		Samples: []*profile.SymbolizedSample{
+			{Locations: []*profile.Location{a, b}, Value: 2},
+			{Locations: []*profile.Location{b}, Value: 4},
+			{Locations: []*profile.Location{a}, Value: 0},
+			// Only in the denominator.
+			{Locations: []*profile.Location{d}, Value: 3},
+		},
+	}
+
+	values := func(p *profile.Profile) map[string]int64 {
+		res := map[string]int64{}
+		for _, s := range p.Samples {
+			res[stacktraceKey(s)] = s.Value
+		}
+		return res
+	}
+
+	ratio := ratioProfiles(numerator, denominator, 10)
+	require.Equal(t, "alloc_space/cpu", ratio.Meta.SampleType.Type)
+	require.Equal(t, "bytes/nanoseconds", ratio.Meta.SampleType.Unit)
+	// Stacktraces without a non-zero denominator are left out.
+	require.Equal(t, map[string]int64{"a/b": 1500, "b": 25}, values(ratio))
+
+	// Ratios are rounded after scaling.
+	require.Equal(t, map[string]int64{"a/b": 150, "b": 3}, values(ratioProfiles(numerator, denominator, 1)))
+	require.Equal(t, map[string]int64{"a/b": 150000000, "b": 2500000}, values(ratioProfiles(numerator, denominator, defaultRatioScale)))
+
+	// An empty denominator leaves no stacktraces.
+	require.Empty(t, ratioProfiles(numerator, &profile.Profile{}, 1).Samples)
+}
+
+// mergeQuerier returns the profiles of merge queries by their query.
+type mergeQuerier struct {
+	Querier
+
+	profiles map[string]*profile.Profile
+}
+
+func (q *mergeQuerier) QueryMerge(ctx context.Context, query string, start, end time.Time) (*profile.Profile, error) {
+	p, ok := q.profiles[query]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no profile for %s", query)
+	}
+	return p, nil
+}
+
+func TestColumnQueryAPIQueryRatio(t *testing.T) {
+	t.Parallel()
+
+	loc := &profile.Location{ID: "a"}
+	api := NewColumnQueryAPI(
+		log.NewNopLogger(),
+		trace.NewNoopTracerProvider().Tracer(""),
+		nil,
+		&mergeQuerier{profiles: map[string]*profile.Profile{
+			`{job="api",__name__="memory:alloc_space:bytes:space:bytes"}`: {
+				Samples: []*profile.SymbolizedSample{{Locations: []*profile.Location{loc}, Value: 3}},
+			},
+			`{job="api",__name__="process_cpu:cpu:nanoseconds:cpu:nanoseconds:delta"}`: {
+				Samples: []*profile.SymbolizedSample{{Locations: []*profile.Location{loc}, Value: 4}},
+			},
+		}},
+	)
+
+	query := func(numerator, selector string) (*pb.QueryResponse, error) {
+		return api.Query(context.Background(), &pb.QueryRequest{
+			Mode:       pb.QueryRequest_MODE_RATIO,
+			ReportType: pb.QueryRequest_REPORT_TYPE_TOP,
+			Options: &pb.QueryRequest_Ratio{
+				Ratio: &pb.RatioProfile{
+					Numerator:   numerator,
+					Denominator: "process_cpu:cpu:nanoseconds:cpu:nanoseconds:delta",
+					Selector:    selector,
+					Start:       timestamppb.New(timestamp.Time(0)),
+					End:         timestamppb.New(timestamp.Time(1)),
+				},
+			},
+		})
+	}
+
+	// Ratios are reported in millionths by default.
+	res, err := query("memory:alloc_space:bytes:space:bytes", ` { job="api" } `)
+	require.NoError(t, err)
+	top := res.Report.(*pb.QueryResponse_Top).Top
+	require.Len(t, top.List, 1)
+	require.Equal(t, int64(750000), top.List[0].Flat)
+
+	for _, c := range []struct{ numerator, selector string }{
+		{numerator: `memory:alloc_space:bytes:space:bytes{job="api"}`},
+		{numerator: "memory:alloc_space:bytes"},
+		{numerator: "memory:alloc_space:bytes:space:bytes", selector: `job="api"`},
+		{numerator: "memory:alloc_space:bytes:space:bytes", selector: `{job="api"} | bytes > 1`},
+		{numerator: "memory:alloc_space:bytes:space:bytes", selector: `{__name__="memory:alloc_space:bytes:space:bytes"}`},
+	} {
+		_, err := query(c.numerator, c.selector)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), c)
+	}
+}
+
 func TestColumnQueryAPITypes(t *testing.T) {
 	t.Parallel()
 
@@ -653,7 +1589,7 @@ func TestColumnQueryAPITypes(t *testing.T) {
 	require.NoError(t, err)
 
 	metastore := metastore.NewInProcessClient(m)
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
 
 	err = ingester.Ingest(ctx, labels.Labels{{
@@ -730,7 +1666,7 @@ func TestColumnQueryAPILabelNames(t *testing.T) {
 	require.NoError(t, err)
 
 	metastore := metastore.NewInProcessClient(m)
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
 	err = ingester.Ingest(ctx, labels.Labels{{
 		Name:  "__name__",
@@ -797,7 +1733,7 @@ func TestColumnQueryAPILabelValues(t *testing.T) {
 	require.NoError(t, err)
 
 	metastore := metastore.NewInProcessClient(m)
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	ingester := parcacol.NewIngester(logger, normalizer, table, schema)
 	err = ingester.Ingest(ctx, labels.Labels{{
 		Name:  "__name__",
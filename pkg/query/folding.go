@@ -0,0 +1,205 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"sync"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+const foldedIDPrefix = "folded:"
+
+// StackFolder folds the frames of queried profiles according to the
+// configured stack folding rules. It is safe for concurrent use and its rules
+// can be replaced at runtime.
+type StackFolder struct {
+	mtx   sync.RWMutex
+	rules []*config.StackFoldingRule
+}
+
+// NewStackFolder returns a StackFolder without any rules.
+func NewStackFolder() *StackFolder {
+	return &StackFolder{}
+}
+
+// ApplyConfig replaces the folding rules.
+func (f *StackFolder) ApplyConfig(rules []*config.StackFoldingRule) error {
+	for _, r := range rules {
+		if r == nil {
+			return fmt.Errorf("empty or null stack folding rule")
+		}
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.rules = rules
+	return nil
+}
+
+// Fold applies the folding rules to every sample of the profile. Samples are
+// modified in place.
+func (f *StackFolder) Fold(p *profile.Profile) *profile.Profile {
+	f.mtx.RLock()
+	rules := f.rules
+	f.mtx.RUnlock()
+
+	if len(rules) == 0 {
+		return p
+	}
+
+	fl := &folding{
+		rules:     rules,
+		synthetic: map[string]*profile.Location{},
+		locations: map[string][]foldedFrame{},
+	}
+	for _, s := range p.Samples {
+		s.Locations = fl.foldStack(s.Locations)
+	}
+
+	return p
+}
+
+// foldedFrame is either a (possibly split) original location, a synthetic
+// location that collapsed frames are replaced with, or a dropped frame
+// without a location.
+type foldedFrame struct {
+	location *profile.Location
+	rule     *config.StackFoldingRule
+}
+
+type folding struct {
+	rules     []*config.StackFoldingRule
+	synthetic map[string]*profile.Location
+	// locations caches the result of folding a single location, as folding a
+	// location only depends on its own lines.
+	locations map[string][]foldedFrame
+}
+
+// foldStack folds the locations of a stack, which are ordered from the leaf
+// to the root.
+func (f *folding) foldStack(locations []*profile.Location) []*profile.Location {
+	res := make([]*profile.Location, 0, len(locations))
+
+	var prev *config.StackFoldingRule
+	for _, l := range locations {
+		for _, frame := range f.foldLocation(l) {
+			if frame.location == nil {
+				// A dropped frame ends the run of collapsed frames.
+				prev = nil
+				continue
+			}
+			if frame.rule != nil && frame.rule == prev {
+				// Consecutive frames collapsed by the same rule become one.
+				continue
+			}
+			prev = frame.rule
+			res = append(res, frame.location)
+		}
+	}
+
+	return res
+}
+
+func (f *folding) foldLocation(l *profile.Location) []foldedFrame {
+	if frames, ok := f.locations[l.ID]; ok {
+		return frames
+	}
+
+	var (
+		frames  []foldedFrame
+		kept    []profile.LocationLine
+		changed bool
+	)
+	flush := func() {
+		if len(kept) == 0 {
+			return
+		}
+		frames = append(frames, foldedFrame{location: &profile.Location{
+			ID:       fmt.Sprintf("%s/%d", l.ID, len(frames)),
+			Address:  l.Address,
+			IsFolded: l.IsFolded,
+			Mapping:  l.Mapping,
+			Lines:    kept,
+		}})
+		kept = nil
+	}
+
+	// Lines are ordered from the inner-most to the outer-most inlined
+	// function, the same way locations are.
+	for _, line := range l.Lines {
+		rule := f.match(line)
+		if rule == nil {
+			kept = append(kept, line)
+			continue
+		}
+
+		changed = true
+		flush()
+		if rule.Action == config.StackFoldingCollapse {
+			frames = append(frames, foldedFrame{
+				location: f.syntheticLocation(rule.Replacement),
+				rule:     rule,
+			})
+			continue
+		}
+		frames = append(frames, foldedFrame{rule: rule})
+	}
+
+	if !changed {
+		frames = []foldedFrame{{location: l}}
+	} else {
+		flush()
+	}
+
+	f.locations[l.ID] = frames
+	return frames
+}
+
+func (f *folding) match(line profile.LocationLine) *config.StackFoldingRule {
+	if line.Function == nil || line.Function.Name == "" {
+		return nil
+	}
+
+	for _, r := range f.rules {
+		if r.Match.MatchString(line.Function.Name) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func (f *folding) syntheticLocation(name string) *profile.Location {
+	if l, ok := f.synthetic[name]; ok {
+		return l
+	}
+
+	id := foldedIDPrefix + name
+	l := &profile.Location{
+		ID: id,
+		Lines: []profile.LocationLine{{
+			Function: &pb.Function{
+				Id:   id,
+				Name: name,
+			},
+		}},
+	}
+	f.synthetic[name] = l
+	return l
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func testLocation(id string, functions ...string) *profile.Location {
+	l := &profile.Location{ID: id}
+	for _, f := range functions {
+		l.Lines = append(l.Lines, profile.LocationLine{
+			Function: &pb.Function{Id: f, Name: f},
+		})
+	}
+	return l
+}
+
+func stackFunctions(locations []*profile.Location) []string {
+	res := []string{}
+	for _, l := range locations {
+		for _, line := range l.Lines {
+			res = append(res, line.Function.Name)
+		}
+	}
+	return res
+}
+
+func TestStackFolderFold(t *testing.T) {
+	t.Parallel()
+
+	f := NewStackFolder()
+	require.NoError(t, f.ApplyConfig([]*config.StackFoldingRule{{
+		Match:  relabel.MustNewRegexp("runtime\\.goexit"),
+		Action: config.StackFoldingDrop,
+	}, {
+		Match:       relabel.MustNewRegexp("google\\.golang\\.org/grpc\\..*"),
+		Action:      config.StackFoldingCollapse,
+		Replacement: "[grpc]",
+	}}))
+
+	p := &profile.Profile{
+		Samples: []*profile.SymbolizedSample{{
+			Value: 1,
+			Locations: []*profile.Location{
+				testLocation("1", "main.handler"),
+				testLocation("2", "google.golang.org/grpc.chainUnary", "google.golang.org/grpc.interceptor"),
+				testLocation("3", "google.golang.org/grpc.(*Server).handleStream"),
+				testLocation("4", "main.serve", "google.golang.org/grpc.(*Server).serveStreams"),
+				testLocation("5", "runtime.goexit"),
+			},
+		}, {
+			Value: 2,
+			Locations: []*profile.Location{
+				testLocation("1", "main.handler"),
+				testLocation("6", "main.main"),
+			},
+		}},
+	}
+
+	f.Fold(p)
+
+	require.Equal(t, []string{
+		"main.handler",
+		"[grpc]",
+		"main.serve",
+		"[grpc]",
+	}, stackFunctions(p.Samples[0].Locations))
+	require.Equal(t, []string{
+		"main.handler",
+		"main.main",
+	}, stackFunctions(p.Samples[1].Locations))
+
+	// Unchanged locations are kept as is and synthetic locations are shared.
+	require.Same(t, p.Samples[0].Locations[0], p.Samples[1].Locations[0])
+	require.Same(t, p.Samples[0].Locations[1], p.Samples[0].Locations[3])
+	require.Equal(t, "folded:[grpc]", p.Samples[0].Locations[1].ID)
+	require.Equal(t, "4/0", p.Samples[0].Locations[2].ID)
+}
+
+func TestStackFolderDropEndsCollapse(t *testing.T) {
+	t.Parallel()
+
+	f := NewStackFolder()
+	require.NoError(t, f.ApplyConfig([]*config.StackFoldingRule{{
+		Match:  relabel.MustNewRegexp("main\\.middleware"),
+		Action: config.StackFoldingDrop,
+	}, {
+		Match:       relabel.MustNewRegexp("net/http\\..*"),
+		Action:      config.StackFoldingCollapse,
+		Replacement: "[http]",
+	}}))
+
+	p := &profile.Profile{
+		Samples: []*profile.SymbolizedSample{{
+			Value: 1,
+			Locations: []*profile.Location{
+				testLocation("1", "net/http.HandlerFunc.ServeHTTP"),
+				testLocation("2", "main.middleware"),
+				testLocation("3", "net/http.(*conn).serve"),
+				testLocation("4", "net/http.(*Server).Serve"),
+			},
+		}},
+	}
+
+	f.Fold(p)
+
+	require.Equal(t, []string{
+		"[http]",
+		"[http]",
+	}, stackFunctions(p.Samples[0].Locations))
+}
+
+func TestStackFolderNoRules(t *testing.T) {
+	t.Parallel()
+
+	locations := []*profile.Location{
+		testLocation("1", "runtime.goexit"),
+	}
+	p := &profile.Profile{
+		Samples: []*profile.SymbolizedSample{{
+			Value:     1,
+			Locations: locations,
+		}},
+	}
+
+	NewStackFolder().Fold(p)
+	require.Equal(t, locations, p.Samples[0].Locations)
+}
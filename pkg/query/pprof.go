@@ -155,6 +155,9 @@ func GenerateFlatPprof(ctx context.Context, ip *parcaprofile.Profile) (*profile.
 		p.Sample = append(p.Sample, &profile.Sample{
 			Value:    []int64{s.Value},
 			Location: locations,
+			Label:    pprofLabels(s.Label),
+			NumLabel: pprofNumLabels(s.NumLabel),
+			NumUnit:  pprofNumUnits(s.NumUnit),
 		})
 	}
 
@@ -195,3 +198,36 @@ func GenerateFlatPprof(ctx context.Context, ip *parcaprofile.Profile) (*profile.
 
 	return p, nil
 }
+
+func pprofLabels(labels map[string]string) map[string][]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	res := make(map[string][]string, len(labels))
+	for k, v := range labels {
+		res[k] = []string{v}
+	}
+	return res
+}
+
+func pprofNumLabels(labels map[string]int64) map[string][]int64 {
+	if len(labels) == 0 {
+		return nil
+	}
+	res := make(map[string][]int64, len(labels))
+	for k, v := range labels {
+		res[k] = []int64{v}
+	}
+	return res
+}
+
+func pprofNumUnits(units map[string]string) map[string][]string {
+	if len(units) == 0 {
+		return nil
+	}
+	res := make(map[string][]string, len(units))
+	for k, v := range units {
+		res[k] = []string{v}
+	}
+	return res
+}
@@ -74,7 +74,7 @@ func Benchmark_Query_Merge(b *testing.B) {
 				s.Label = nil
 			}
 
-			normalizer := parcacol.NewNormalizer(m)
+			normalizer := parcacol.NewNormalizer(m, nil)
 			ingester := parcacol.NewIngester(logger, normalizer, table, schema)
 
 			profiles, err := normalizer.NormalizePprof(ctx, "memory", map[string]struct{}{}, p, false)
@@ -46,7 +46,7 @@ func TestGenerateTopTable(t *testing.T) {
 		trace.NewNoopTracerProvider().Tracer(""),
 	)
 	metastore := metastore.NewInProcessClient(l)
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	profiles, err := normalizer.NormalizePprof(ctx, "memory", map[string]struct{}{}, p, false)
 	require.NoError(t, err)
 
@@ -186,7 +186,7 @@ func TestGenerateDiffTopTable(t *testing.T) {
 		trace.NewNoopTracerProvider().Tracer(""),
 	)
 	metastore := metastore.NewInProcessClient(l)
-	normalizer := parcacol.NewNormalizer(metastore)
+	normalizer := parcacol.NewNormalizer(metastore, nil)
 	profiles, err := normalizer.NormalizePprof(ctx, "memory", map[string]struct{}{}, p1, false)
 	require.NoError(t, err)
 
@@ -50,6 +50,7 @@ import (
 
 	"github.com/parca-dev/parca/pkg/debuginfo"
 	"github.com/parca-dev/parca/pkg/prober"
+	"github.com/parca-dev/parca/pkg/tenant"
 	"github.com/parca-dev/parca/ui"
 )
 
@@ -121,19 +122,23 @@ func (s *Server) ListenAndServe(ctx context.Context, logger log.Logger, port str
 				otelgrpc.StreamServerInterceptor(),
 				met.StreamServerInterceptor(),
 				grpc_logging.StreamServerInterceptor(kit.InterceptorLogger(logger), logOpts...),
+				tenant.StreamServerInterceptor(),
 			)),
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
 				otelgrpc.UnaryServerInterceptor(),
 				met.UnaryServerInterceptor(),
 				grpc_logging.UnaryServerInterceptor(kit.InterceptorLogger(logger), logOpts...),
+				tenant.UnaryServerInterceptor(),
 			),
 		),
 	)
 
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	grpcWebMux := runtime.NewServeMux()
+	grpcWebMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(tenant.IncomingHeaderMatcher),
+	)
 	for _, r := range registerables {
 		if err := r.Register(ctx, srv, grpcWebMux, port, opts); err != nil {
 			return err
@@ -142,11 +147,13 @@ func (s *Server) ListenAndServe(ctx context.Context, logger log.Logger, port str
 	reflection.Register(srv)
 	grpc_health.RegisterHealthServer(srv, s.grpcProbe.HealthServer())
 
+	apiHandler := tenant.HTTPMiddleware(grpcWebMux)
+
 	internalMux := chi.NewRouter()
 	if pathPrefix != "" {
-		internalMux.Mount(pathPrefix+"/api", grpcWebMux)
+		internalMux.Mount(pathPrefix+"/api", apiHandler)
 	}
-	internalMux.Mount("/api", grpcWebMux)
+	internalMux.Mount("/api", apiHandler)
 
 	internalMux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
 		promhttp.HandlerFor(s.reg, promhttp.HandlerOpts{}).ServeHTTP(w, r)
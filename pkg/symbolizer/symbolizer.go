@@ -31,6 +31,7 @@ import (
 	"github.com/parca-dev/parca/pkg/profile"
 	"github.com/parca-dev/parca/pkg/runutil"
 	"github.com/parca-dev/parca/pkg/symbol"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 type Symbolizer struct {
@@ -60,6 +61,10 @@ type Symbolizer struct {
 	debuginfoCacheDir  string
 
 	batchSize uint32
+
+	// tenants returns the tenants whose locations are symbolized, if nil
+	// only the locations of the default tenant are.
+	tenants func(ctx context.Context) ([]string, error)
 }
 
 type DebugInfoFetcher interface {
@@ -77,6 +82,7 @@ func New(
 	debuginfodCacheDir string,
 	debuginfoCacheDir string,
 	batchSize uint32,
+	tenants func(ctx context.Context) ([]string, error),
 ) *Symbolizer {
 	attemptsTotal := promauto.With(reg).NewCounter(
 		prometheus.CounterOpts{
@@ -118,6 +124,7 @@ func New(
 		debuginfodCacheDir: debuginfodCacheDir,
 		debuginfoCacheDir:  debuginfoCacheDir,
 		batchSize:          batchSize,
+		tenants:            tenants,
 	}
 	return &s
 }
@@ -125,7 +132,18 @@ func New(
 func (s *Symbolizer) Run(ctx context.Context, interval time.Duration) error {
 	return runutil.Repeat(interval, ctx.Done(), func() error {
 		level.Debug(s.logger).Log("msg", "start symbolization cycle")
-		s.runSymbolizationCycle(ctx)
+		tenants := []string{tenant.Default}
+		if s.tenants != nil {
+			var err error
+			tenants, err = s.tenants(ctx)
+			if err != nil {
+				level.Error(s.logger).Log("msg", "failed to list tenants", "err", err)
+				return nil
+			}
+		}
+		for _, id := range tenants {
+			s.runSymbolizationCycle(tenant.NewContext(ctx, id))
+		}
 		level.Debug(s.logger).Log("msg", "symbolization loop completed")
 		return nil
 	})
@@ -421,11 +421,7 @@ func setup(t *testing.T) (*grpc.ClientConn, pb.MetastoreServiceClient, *Symboliz
 	schema, err := parcacol.Schema()
 	require.NoError(t, err)
 
-	table, err := colDB.Table(
-		"stacktraces",
-		frostdb.NewTableConfig(schema),
-	)
-	require.NoError(t, err)
+	tables := parcacol.NewTables(colDB, schema, "stacktraces", nil)
 
 	debugInfoCacheDir, err := os.MkdirTemp("", "parca-debuginfo-test-cache-*")
 	require.NoError(t, err)
@@ -475,11 +471,11 @@ func setup(t *testing.T) (*grpc.ClientConn, pb.MetastoreServiceClient, *Symboliz
 
 	pStr := profilestore.NewProfileColumnStore(
 		logger,
+		prometheus.NewRegistry(),
 		tracer,
 		metastore,
-		table,
+		tables,
 		schema,
-		false,
 	)
 
 	lis, err := net.Listen("tcp", "127.0.0.1:0")
@@ -516,5 +512,6 @@ func setup(t *testing.T) (*grpc.ClientConn, pb.MetastoreServiceClient, *Symboliz
 		symbolizerCacheDir,
 		symbolizerCacheDir,
 		0,
+		nil,
 	)
 }
@@ -0,0 +1,189 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant identifies the tenant a request is made on behalf of.
+// Profiles and their metadata of different tenants are stored in separate
+// tables and under separate metastore key prefixes, and requests can only
+// ever see the data of their own tenant.
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Header is the HTTP header carrying the tenant ID.
+	Header = "X-Scope-OrgID"
+	// MetadataKey is the gRPC metadata key carrying the tenant ID.
+	MetadataKey = "x-scope-orgid"
+
+	// Default is the tenant of requests that don't specify one. Its data is
+	// stored the same way it was before tenants were introduced.
+	Default = "default"
+)
+
+var validID = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// Validate returns an error if id can't be used as a tenant ID. IDs are used
+// in table names and storage paths, so only alphanumeric characters, dashes
+// and underscores are allowed.
+func Validate(id string) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("invalid tenant ID %q: must be 1 to 64 alphanumeric characters, dashes or underscores", id)
+	}
+	return nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the tenant ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant ID of the context, or the default tenant if
+// it doesn't carry one.
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id
+	}
+	return Default
+}
+
+// TableName returns the name of the table with the given base name that
+// holds the data of the tenant.
+func TableName(base, id string) string {
+	if id == Default || id == "" {
+		return base
+	}
+	return base + "_" + id
+}
+
+// KeyPrefix returns the prefix of the metastore keys of the tenant.
+func KeyPrefix(id string) string {
+	if id == Default || id == "" {
+		return ""
+	}
+	return "tenants/" + id + "/"
+}
+
+// NewOutgoingContext returns a context whose outgoing gRPC metadata carries
+// the tenant ID of ctx, so requests forwarded to another Parca instance stay
+// scoped to the same tenant.
+func NewOutgoingContext(ctx context.Context) context.Context {
+	id := FromContext(ctx)
+	if id == Default {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+}
+
+// fromIncomingContext returns the tenant ID of the incoming gRPC metadata of
+// the context, or an empty string if there is none.
+func fromIncomingContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	ids := md.Get(MetadataKey)
+	switch len(ids) {
+	case 0:
+		return "", nil
+	case 1:
+		return ids[0], Validate(ids[0])
+	default:
+		return "", fmt.Errorf("multiple tenant IDs provided")
+	}
+}
+
+func grpcContext(ctx context.Context) (context.Context, error) {
+	id, err := fromIncomingContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if id == "" {
+		// The tenant may have already been set by the HTTP middleware.
+		return ctx, nil
+	}
+	return NewContext(ctx, id), nil
+}
+
+// UnaryServerInterceptor returns an interceptor that scopes unary calls to
+// the tenant of their metadata.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := grpcContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that scopes streaming calls
+// to the tenant of their metadata.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := grpcContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// HTTPMiddleware scopes HTTP requests to the tenant of their header.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if id == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := Validate(id); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// IncomingHeaderMatcher forwards the tenant header of requests to the
+// grpc-gateway as gRPC metadata, and all other headers the way the gateway
+// does by default.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(Header) {
+		return MetadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
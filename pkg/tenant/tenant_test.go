@@ -0,0 +1,100 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Validate("team-a"))
+	require.NoError(t, Validate("Team_1"))
+	require.Error(t, Validate(""))
+	require.Error(t, Validate("../etc"))
+	require.Error(t, Validate("team a"))
+	require.Error(t, Validate("a/b"))
+}
+
+func TestStorageNames(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "stacktraces", TableName("stacktraces", Default))
+	require.Equal(t, "stacktraces_team-a", TableName("stacktraces", "team-a"))
+	require.Equal(t, "", KeyPrefix(Default))
+	require.Equal(t, "tenants/team-a/", KeyPrefix("team-a"))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		got = FromContext(ctx)
+		return nil, nil
+	}
+	interceptor := UnaryServerInterceptor()
+
+	_, err := interceptor(context.Background(), nil, nil, handler)
+	require.NoError(t, err)
+	require.Equal(t, Default, got)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "team-a"))
+	_, err = interceptor(ctx, nil, nil, handler)
+	require.NoError(t, err)
+	require.Equal(t, "team-a", got)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "team/a"))
+	_, err = interceptor(ctx, nil, nil, handler)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "team-a", MetadataKey, "team-b"))
+	_, err = interceptor(ctx, nil, nil, handler)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	h := HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(Header, "team-a")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "team-a", got)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(Header, "team a")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	key, ok := IncomingHeaderMatcher("x-scope-orgid")
+	require.True(t, ok)
+	require.Equal(t, MetadataKey, key)
+}
@@ -0,0 +1,367 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valuelog reads and replays the debug value log, to which the
+// profile store writes every raw profile when --storage-debug-value-log is
+// enabled. The profiles of a series are stored in the directory of its tenant
+// and the URL safe base64 encoding of its labels as they were received, before
+// relabeling. The files are named after the time in milliseconds since the
+// epoch they were written at and the time in nanoseconds since the epoch the
+// profile was stored with, which differs from the time recorded in the profile
+// if the write overrode it:
+//
+//	tmp/<tenant>/<base64 labels>/<timestamp>-<profile time>.pb.gz
+//
+// Logs written by earlier versions don't have the tenant level and the
+// profile time, their profiles belong to the default tenant and keep the time
+// recorded in them:
+//
+//	tmp/<base64 labels>/<timestamp>.pb.gz
+package valuelog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// Dir is the directory the profile store writes the value log to.
+const Dir = "tmp"
+
+const fileSuffix = ".pb.gz"
+
+// Path returns the path of the gzip compressed profile of the tenant's series
+// written at the time in milliseconds since the epoch and stored with the
+// time in nanoseconds since the epoch to the value log in dir.
+func Path(dir, tenantID string, ls labels.Labels, ts, profileTime int64) string {
+	return filepath.Join(
+		dir,
+		tenantID,
+		base64.URLEncoding.EncodeToString([]byte(ls.String())),
+		strconv.FormatInt(ts, 10)+"-"+strconv.FormatInt(profileTime, 10)+fileSuffix,
+	)
+}
+
+// Entry is a profile of the value log.
+type Entry struct {
+	// Tenant is the tenant the profile was written for.
+	Tenant string
+	// Labels are the labels of the series of the profile as they were
+	// received, before relabeling.
+	Labels labels.Labels
+	// Timestamp is the time in milliseconds since the epoch the profile was
+	// written at.
+	Timestamp int64
+	// ProfileTime is the time in nanoseconds since the epoch the profile was
+	// stored with. It is zero for profiles of the legacy layout, which were
+	// stored with the time recorded in them.
+	ProfileTime int64
+	// Path is the path of the gzip compressed profile.
+	Path string
+}
+
+// Read returns the entries of the value log in dir, sorted by the time they
+// were written at and then by their tenant and labels. Directories holding
+// profiles directly are series of the legacy layout.
+func Read(dir string) ([]Entry, error) {
+	dirs, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+
+		legacy, err := hasProfiles(filepath.Join(dir, d.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if legacy {
+			seriesEntries, err := readSeries(dir, d.Name(), tenant.Default)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, seriesEntries...)
+			continue
+		}
+
+		if err := tenant.Validate(d.Name()); err != nil {
+			return nil, fmt.Errorf("tenant of directory %q: %w", d.Name(), err)
+		}
+		tenantEntries, err := readTenant(dir, d.Name())
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, tenantEntries...)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Timestamp != entries[j].Timestamp {
+			return entries[i].Timestamp < entries[j].Timestamp
+		}
+		if entries[i].Tenant != entries[j].Tenant {
+			return entries[i].Tenant < entries[j].Tenant
+		}
+		return labels.Compare(entries[i].Labels, entries[j].Labels) < 0
+	})
+	return entries, nil
+}
+
+// hasProfiles returns whether the directory holds profiles.
+func hasProfiles(dir string) (bool, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), fileSuffix) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// readTenant returns the entries of the tenant in the value log in dir.
+func readTenant(dir, tenantID string) ([]Entry, error) {
+	series, err := os.ReadDir(filepath.Join(dir, tenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, s := range series {
+		if !s.IsDir() {
+			continue
+		}
+		seriesEntries, err := readSeries(dir, filepath.Join(tenantID, s.Name()), tenantID)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, seriesEntries...)
+	}
+	return entries, nil
+}
+
+// readSeries returns the entries of the series directory, given relative to
+// the value log in dir.
+func readSeries(dir, seriesDir, tenantID string) ([]Entry, error) {
+	name := filepath.Base(seriesDir)
+	decoded, err := base64.URLEncoding.DecodeString(name)
+	if err != nil {
+		return nil, fmt.Errorf("decode labels of directory %q: %w", seriesDir, err)
+	}
+	ls, err := parser.ParseMetric(string(decoded))
+	if err != nil {
+		return nil, fmt.Errorf("parse labels of directory %q: %w", seriesDir, err)
+	}
+
+	files, err := os.ReadDir(filepath.Join(dir, seriesDir))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), fileSuffix) {
+			continue
+		}
+		ts, profileTime, err := parseFileName(strings.TrimSuffix(f.Name(), fileSuffix))
+		if err != nil {
+			return nil, fmt.Errorf("parse name of %q: %w", filepath.Join(seriesDir, f.Name()), err)
+		}
+
+		entries = append(entries, Entry{
+			Tenant:      tenantID,
+			Labels:      ls,
+			Timestamp:   ts,
+			ProfileTime: profileTime,
+			Path:        filepath.Join(dir, seriesDir, f.Name()),
+		})
+	}
+	return entries, nil
+}
+
+// parseFileName returns the write and profile time of the name of a profile
+// without its suffix. The profile time is zero for names of the legacy
+// layout, which only consist of the write time.
+func parseFileName(name string) (int64, int64, error) {
+	written, stored, ok := strings.Cut(name, "-")
+	ts, err := strconv.ParseInt(written, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !ok {
+		return ts, 0, nil
+	}
+	profileTime, err := strconv.ParseInt(stored, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return ts, profileTime, nil
+}
+
+// ReplayOptions configure a replay.
+type ReplayOptions struct {
+	// Speed is the factor by which the replay is faster than the original
+	// writes. The time between the writes of two profiles is divided by it.
+	// Zero replays the profiles without waiting.
+	Speed float64
+	// Labels are set on all replayed profiles, replacing labels of the same
+	// name. Labels with an empty value are removed.
+	Labels map[string]string
+	// Tenant replaces the tenant of all replayed profiles if it is set.
+	Tenant string
+}
+
+// Replay writes the profiles of the entries to the store in order, for the
+// tenant they were written for. Profiles keep the time they were stored with.
+// Profiles that fail to be written don't stop the replay, the returned error
+// counts them and describes the first failure. It returns the number of
+// profiles written.
+func Replay(ctx context.Context, store profilestorepb.ProfileStoreServiceClient, entries []Entry, opts ReplayOptions) (int, error) {
+	var (
+		written  int
+		failed   int
+		firstErr error
+	)
+	for i, e := range entries {
+		if i > 0 && opts.Speed > 0 {
+			wait := time.Duration(float64(time.Duration(e.Timestamp-entries[i-1].Timestamp)*time.Millisecond) / opts.Speed)
+			select {
+			case <-ctx.Done():
+				return written, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+
+		if err := replay(ctx, store, e, opts); err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("replay %q: %w", e.Path, err)
+			}
+			continue
+		}
+		written++
+	}
+
+	if failed > 0 {
+		return written, fmt.Errorf("failed to replay %d of %d profiles, first failure: %w", failed, len(entries), firstErr)
+	}
+	return written, nil
+}
+
+func replay(ctx context.Context, store profilestorepb.ProfileStoreServiceClient, e Entry, opts ReplayOptions) error {
+	raw, err := os.ReadFile(e.Path)
+	if err != nil {
+		return err
+	}
+	raw, err = withProfileTime(raw, e.ProfileTime)
+	if err != nil {
+		return err
+	}
+
+	b := labels.NewBuilder(e.Labels)
+	for name, value := range opts.Labels {
+		if value == "" {
+			b.Del(name)
+			continue
+		}
+		b.Set(name, value)
+	}
+	ls := b.Labels()
+
+	pbLabels := make([]*profilestorepb.Label, 0, len(ls))
+	for _, l := range ls {
+		pbLabels = append(pbLabels, &profilestorepb.Label{Name: l.Name, Value: l.Value})
+	}
+
+	tenantID := e.Tenant
+	if opts.Tenant != "" {
+		tenantID = opts.Tenant
+	}
+	ctx = tenant.NewOutgoingContext(tenant.NewContext(ctx, tenantID))
+
+	_, err = store.WriteRaw(ctx, &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels: &profilestorepb.LabelSet{Labels: pbLabels},
+			Samples: []*profilestorepb.RawSample{{
+				RawProfile: raw,
+			}},
+		}},
+	})
+	return err
+}
+
+// withProfileTime returns the gzip compressed profile with its time set to
+// the time in nanoseconds since the epoch. The profile is only encoded again
+// if the write it was logged for overrode its time. A zero time keeps the
+// time recorded in the profile.
+func withProfileTime(raw []byte, profileTime int64) ([]byte, error) {
+	if profileTime == 0 {
+		return raw, nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("decompress profile: %w", err)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decompress profile: %w", err)
+	}
+
+	p := &pprofpb.Profile{}
+	if err := p.UnmarshalVT(content); err != nil {
+		return nil, fmt.Errorf("parse profile: %w", err)
+	}
+	if p.TimeNanos == profileTime {
+		return raw, nil
+	}
+	p.TimeNanos = profileTime
+
+	content, err = p.MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(content); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
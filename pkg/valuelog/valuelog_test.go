@@ -0,0 +1,147 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valuelog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// recordingClient records the written profiles, the tenants and the times
+// they were written at.
+type recordingClient struct {
+	profilestorepb.ProfileStoreServiceClient
+
+	requests []*profilestorepb.WriteRawRequest
+	tenants  []string
+	times    []time.Time
+}
+
+func (c *recordingClient) WriteRaw(ctx context.Context, in *profilestorepb.WriteRawRequest, opts ...grpc.CallOption) (*profilestorepb.WriteRawResponse, error) {
+	c.requests = append(c.requests, in)
+	c.tenants = append(c.tenants, tenant.FromContext(ctx))
+	c.times = append(c.times, time.Now())
+	return &profilestorepb.WriteRawResponse{}, nil
+}
+
+// writeEntry logs a profile recording the time in nanoseconds since the epoch
+// that is stored with the profile time.
+func writeEntry(t *testing.T, dir, tenantID string, ls labels.Labels, ts, recorded, profileTime int64) {
+	t.Helper()
+
+	writeProfile(t, Path(dir, tenantID, ls, ts, profileTime), recorded)
+}
+
+// writeProfile writes a profile recording the time in nanoseconds since the
+// epoch to the path.
+func writeProfile(t *testing.T, path string, recorded int64) {
+	t.Helper()
+
+	content, err := (&pprofpb.Profile{TimeNanos: recorded}).MarshalVT()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err = w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+}
+
+// profileTime returns the time recorded in the gzip compressed profile.
+func profileTime(t *testing.T, raw []byte) int64 {
+	t.Helper()
+
+	r, err := gzip.NewReader(bytes.NewReader(raw))
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	p := &pprofpb.Profile{}
+	require.NoError(t, p.UnmarshalVT(content))
+	return p.TimeNanos
+}
+
+func TestReadAndReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	api := labels.FromStrings("__name__", "memory", "job", "api", "instance", "a\"b")
+	db := labels.FromStrings("__name__", "memory", "job", "db")
+	writeEntry(t, dir, tenant.Default, api, 1300, 3, 3)
+	writeEntry(t, dir, "team-a", db, 1000, 1, 1)
+	// The write of the second profile overrode its time.
+	writeEntry(t, dir, tenant.Default, api, 1000, 0, 2)
+	// Profiles of the legacy layout keep the time recorded in them.
+	legacy := labels.FromStrings("__name__", "memory", "job", "legacy")
+	legacyPath := filepath.Join(dir, base64.URLEncoding.EncodeToString([]byte(legacy.String())), "1400.pb.gz")
+	writeProfile(t, legacyPath, 4)
+
+	entries, err := Read(dir)
+	require.NoError(t, err)
+	require.Equal(t, []Entry{
+		{Tenant: tenant.Default, Labels: api, Timestamp: 1000, ProfileTime: 2, Path: Path(dir, tenant.Default, api, 1000, 2)},
+		{Tenant: "team-a", Labels: db, Timestamp: 1000, ProfileTime: 1, Path: Path(dir, "team-a", db, 1000, 1)},
+		{Tenant: tenant.Default, Labels: api, Timestamp: 1300, ProfileTime: 3, Path: Path(dir, tenant.Default, api, 1300, 3)},
+		{Tenant: tenant.Default, Labels: legacy, Timestamp: 1400, Path: legacyPath},
+	}, entries)
+
+	client := &recordingClient{}
+	written, err := Replay(context.Background(), client, entries, ReplayOptions{
+		// The 300ms between the second and third profile take 100ms.
+		Speed:  3,
+		Labels: map[string]string{"job": "replay", "instance": ""},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, written)
+
+	times := make([]int64, 0, len(client.requests))
+	for _, req := range client.requests {
+		require.Len(t, req.Series, 1)
+		require.Equal(t, []*profilestorepb.Label{
+			{Name: "__name__", Value: "memory"},
+			{Name: "job", Value: "replay"},
+		}, req.Series[0].Labels.Labels)
+		times = append(times, profileTime(t, req.Series[0].Samples[0].RawProfile))
+	}
+	require.Equal(t, []int64{2, 1, 3, 4}, times)
+	require.Equal(t, []string{tenant.Default, "team-a", tenant.Default, tenant.Default}, client.tenants)
+	require.GreaterOrEqual(t, client.times[2].Sub(client.times[1]), 100*time.Millisecond)
+
+	// The tenant of all profiles can be replaced.
+	client = &recordingClient{}
+	_, err = Replay(context.Background(), client, entries, ReplayOptions{Tenant: "team-b"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-b", "team-b", "team-b", "team-b"}, client.tenants)
+
+	// Directories not following the layout are reported.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "team-a", "not-base64!"), 0o755))
+	_, err = Read(dir)
+	require.Error(t, err)
+}
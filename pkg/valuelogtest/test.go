@@ -0,0 +1,125 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valuelogtest replays debug value logs into fresh in-memory stores,
+// so situations recorded with --storage-debug-value-log can be reproduced in
+// tests.
+package valuelogtest
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	metastorepb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/metastoretest"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profilestore"
+	"github.com/parca-dev/parca/pkg/valuelog"
+)
+
+type Testing interface {
+	require.TestingT
+	Helper()
+	Name() string
+}
+
+// Store is a fresh in-memory profile store.
+type Store struct {
+	*profilestore.ProfileColumnStore
+
+	Metastore metastorepb.MetastoreServiceClient
+	Tables    *parcacol.Tables
+	Querier   *parcacol.Querier
+}
+
+// NewStore returns an empty in-memory profile store.
+func NewStore(
+	t Testing,
+	logger log.Logger,
+	reg prometheus.Registerer,
+	tracer trace.Tracer,
+) *Store {
+	t.Helper()
+
+	m := metastore.NewInProcessClient(metastoretest.NewTestMetastore(t, logger, reg, tracer))
+
+	col, err := frostdb.New()
+	require.NoError(t, err)
+	colDB, err := col.DB(context.Background(), "parca")
+	require.NoError(t, err)
+
+	schema, err := parcacol.Schema()
+	require.NoError(t, err)
+	tables := parcacol.NewTables(colDB, schema, "stacktraces", nil)
+
+	return &Store{
+		ProfileColumnStore: profilestore.NewProfileColumnStore(
+			logger,
+			reg,
+			tracer,
+			m,
+			tables,
+			schema,
+		),
+		Metastore: m,
+		Tables:    tables,
+		Querier: parcacol.NewQuerier(
+			tracer,
+			query.NewEngine(memory.DefaultAllocator, tables),
+			"stacktraces",
+			m,
+		),
+	}
+}
+
+// Client returns a client writing to the store in process.
+func (s *Store) Client() profilestorepb.ProfileStoreServiceClient {
+	return &inProcessClient{s: s.ProfileColumnStore}
+}
+
+// inProcessClient implements the writes of raw profiles of the
+// ProfileStoreServiceClient by calling the store directly.
+type inProcessClient struct {
+	profilestorepb.ProfileStoreServiceClient
+
+	s profilestorepb.ProfileStoreServiceServer
+}
+
+func (c *inProcessClient) WriteRaw(ctx context.Context, in *profilestorepb.WriteRawRequest, _ ...grpc.CallOption) (*profilestorepb.WriteRawResponse, error) {
+	return c.s.WriteRaw(ctx, in)
+}
+
+// Replay replays the value log in dir into a fresh store, without waiting
+// between the profiles.
+func Replay(t Testing, dir string, labels map[string]string) *Store {
+	t.Helper()
+
+	s := NewStore(t, log.NewNopLogger(), prometheus.NewRegistry(), trace.NewNoopTracerProvider().Tracer(""))
+
+	entries, err := valuelog.Read(dir)
+	require.NoError(t, err)
+	_, err = valuelog.Replay(context.Background(), s.Client(), entries, valuelog.ReplayOptions{Labels: labels})
+	require.NoError(t, err)
+
+	return s
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valuelogtest
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/stretchr/testify/require"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	"github.com/parca-dev/parca/pkg/profilestore"
+	"github.com/parca-dev/parca/pkg/tenant"
+	"github.com/parca-dev/parca/pkg/valuelog"
+)
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	many, err := os.ReadDir("../query/testdata/many")
+	require.NoError(t, err)
+	for i, f := range many {
+		raw, err := os.ReadFile(filepath.Join("../query/testdata/many", f.Name()))
+		require.NoError(t, err)
+
+		content, _, err := profilestore.Decompress(raw, 0)
+		require.NoError(t, err)
+		p := &pprofpb.Profile{}
+		require.NoError(t, p.UnmarshalVT(content))
+
+		path := valuelog.Path(dir, tenant.Default, labels.FromStrings("__name__", "memory", "job", "recorded"), int64(i), p.TimeNanos)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, raw, 0o644))
+	}
+
+	s := Replay(t, dir, map[string]string{"job": "replayed"})
+
+	series, err := s.Querier.QueryRange(
+		context.Background(),
+		`memory:alloc_objects:count:space:bytes{job="replayed"}`,
+		timestamp.Time(0),
+		timestamp.Time(math.MaxInt64),
+		0,
+		0,
+	)
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	require.Len(t, series[0].Samples, len(many))
+}
@@ -4,6 +4,13 @@
 import type { RpcTransport } from "@protobuf-ts/runtime-rpc";
 import type { ServiceInfo } from "@protobuf-ts/runtime-rpc";
 import { ProfileStoreService } from "./profilestore";
+import type { WriteRawStreamResponse } from "./profilestore";
+import type { WriteRawStreamRequest } from "./profilestore";
+import type { DuplexStreamingCall } from "@protobuf-ts/runtime-rpc";
+import type { WriteJFRResponse } from "./profilestore";
+import type { WriteJFRRequest } from "./profilestore";
+import type { WriteFoldedResponse } from "./profilestore";
+import type { WriteFoldedRequest } from "./profilestore";
 import { stackIntercept } from "@protobuf-ts/runtime-rpc";
 import type { WriteRawResponse } from "./profilestore";
 import type { WriteRawRequest } from "./profilestore";
@@ -21,6 +28,24 @@ export interface IProfileStoreServiceClient {
      * @generated from protobuf rpc: WriteRaw(parca.profilestore.v1alpha1.WriteRawRequest) returns (parca.profilestore.v1alpha1.WriteRawResponse);
      */
     writeRaw(input: WriteRawRequest, options?: RpcOptions): UnaryCall<WriteRawRequest, WriteRawResponse>;
+    /**
+     * WriteFolded accepts a profile in the collapsed/folded stack text format
+     *
+     * @generated from protobuf rpc: WriteFolded(parca.profilestore.v1alpha1.WriteFoldedRequest) returns (parca.profilestore.v1alpha1.WriteFoldedResponse);
+     */
+    writeFolded(input: WriteFoldedRequest, options?: RpcOptions): UnaryCall<WriteFoldedRequest, WriteFoldedResponse>;
+    /**
+     * WriteJFR accepts a Java Flight Recorder recording
+     *
+     * @generated from protobuf rpc: WriteJFR(parca.profilestore.v1alpha1.WriteJFRRequest) returns (parca.profilestore.v1alpha1.WriteJFRResponse);
+     */
+    writeJFR(input: WriteJFRRequest, options?: RpcOptions): UnaryCall<WriteJFRRequest, WriteJFRResponse>;
+    /**
+     * WriteRawStream accepts a stream of windows of raw pprof profiles, every window is acknowledged with the result of each of its series
+     *
+     * @generated from protobuf rpc: WriteRawStream(stream parca.profilestore.v1alpha1.WriteRawStreamRequest) returns (stream parca.profilestore.v1alpha1.WriteRawStreamResponse);
+     */
+    writeRawStream(options?: RpcOptions): DuplexStreamingCall<WriteRawStreamRequest, WriteRawStreamResponse>;
 }
 /**
  * ProfileStoreService is the service the accepts pprof writes
@@ -42,4 +67,31 @@ export class ProfileStoreServiceClient implements IProfileStoreServiceClient, Se
         const method = this.methods[0], opt = this._transport.mergeOptions(options);
         return stackIntercept<WriteRawRequest, WriteRawResponse>("unary", this._transport, method, opt, input);
     }
+    /**
+     * WriteFolded accepts a profile in the collapsed/folded stack text format
+     *
+     * @generated from protobuf rpc: WriteFolded(parca.profilestore.v1alpha1.WriteFoldedRequest) returns (parca.profilestore.v1alpha1.WriteFoldedResponse);
+     */
+    writeFolded(input: WriteFoldedRequest, options?: RpcOptions): UnaryCall<WriteFoldedRequest, WriteFoldedResponse> {
+        const method = this.methods[1], opt = this._transport.mergeOptions(options);
+        return stackIntercept<WriteFoldedRequest, WriteFoldedResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * WriteJFR accepts a Java Flight Recorder recording
+     *
+     * @generated from protobuf rpc: WriteJFR(parca.profilestore.v1alpha1.WriteJFRRequest) returns (parca.profilestore.v1alpha1.WriteJFRResponse);
+     */
+    writeJFR(input: WriteJFRRequest, options?: RpcOptions): UnaryCall<WriteJFRRequest, WriteJFRResponse> {
+        const method = this.methods[2], opt = this._transport.mergeOptions(options);
+        return stackIntercept<WriteJFRRequest, WriteJFRResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * WriteRawStream accepts a stream of windows of raw pprof profiles, every window is acknowledged with the result of each of its series
+     *
+     * @generated from protobuf rpc: WriteRawStream(stream parca.profilestore.v1alpha1.WriteRawStreamRequest) returns (stream parca.profilestore.v1alpha1.WriteRawStreamResponse);
+     */
+    writeRawStream(options?: RpcOptions): DuplexStreamingCall<WriteRawStreamRequest, WriteRawStreamResponse> {
+        const method = this.methods[3], opt = this._transport.mergeOptions(options);
+        return stackIntercept<WriteRawStreamRequest, WriteRawStreamResponse>("duplex", this._transport, method, opt);
+    }
 }
@@ -12,6 +12,8 @@ import type { PartialMessage } from "@protobuf-ts/runtime";
 import { reflectionMergePartial } from "@protobuf-ts/runtime";
 import { MESSAGE_TYPE } from "@protobuf-ts/runtime";
 import { MessageType } from "@protobuf-ts/runtime";
+import { Duration } from "../../../google/protobuf/duration";
+import { Timestamp } from "../../../google/protobuf/timestamp";
 /**
  * WriteRawRequest writes a pprof profile for a given tenant
  *
@@ -37,13 +39,232 @@ export interface WriteRawRequest {
      * @generated from protobuf field: bool normalized = 3;
      */
     normalized: boolean;
+    /**
+     * partial enables partial acceptance, invalid series and samples are skipped and reported in the response
+     * while the valid ones are stored, instead of failing the whole request
+     *
+     * @generated from protobuf field: bool partial = 4;
+     */
+    partial: boolean;
 }
 /**
- * WriteRawResponse is the empty response
+ * WriteRawResponse is the response to a WriteRawRequest
  *
  * @generated from protobuf message parca.profilestore.v1alpha1.WriteRawResponse
  */
 export interface WriteRawResponse {
+    /**
+     * rejections are the series and samples that were skipped, they are only reported for partial requests
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.WriteRawRejection rejections = 1;
+     */
+    rejections: WriteRawRejection[];
+}
+/**
+ * WriteRawRejection describes a series, raw sample or pprof sample that was skipped
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteRawRejection
+ */
+export interface WriteRawRejection {
+    /**
+     * series_index is the index of the series within the request
+     *
+     * @generated from protobuf field: uint64 series_index = 1;
+     */
+    seriesIndex: string;
+    /**
+     * sample_index is the index of the raw sample within its series, it is -1 if the whole series was rejected
+     *
+     * @generated from protobuf field: int64 sample_index = 2;
+     */
+    sampleIndex: string;
+    /**
+     * pprof_sample_index is the index of the sample within the pprof profile, it is -1 if the whole raw sample was rejected
+     *
+     * @generated from protobuf field: int64 pprof_sample_index = 3;
+     */
+    pprofSampleIndex: string;
+    /**
+     * code is the gRPC status code of the rejection
+     *
+     * @generated from protobuf field: int32 code = 4;
+     */
+    code: number;
+    /**
+     * reason identifies the kind of rejection, for example invalid_location_id
+     *
+     * @generated from protobuf field: string reason = 5;
+     */
+    reason: string;
+    /**
+     * message describes the rejection
+     *
+     * @generated from protobuf field: string message = 6;
+     */
+    message: string;
+}
+/**
+ * WriteRawStreamRequest is a window of raw pprof profiles written on a stream
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteRawStreamRequest
+ */
+export interface WriteRawStreamRequest {
+    /**
+     * series is a set raw pprof profiles and accompanying labels
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.RawProfileSeries series = 1;
+     */
+    series: RawProfileSeries[];
+    /**
+     * normalized is a flag indicating if the addresses in the profile is normalized for position independent code
+     *
+     * @generated from protobuf field: bool normalized = 2;
+     */
+    normalized: boolean;
+}
+/**
+ * WriteRawStreamResponse acknowledges a window of a stream
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteRawStreamResponse
+ */
+export interface WriteRawStreamResponse {
+    /**
+     * window is the zero based index of the acknowledged request on the stream
+     *
+     * @generated from protobuf field: uint64 window = 1;
+     */
+    window: string;
+    /**
+     * accepted is the number of series of the window that were stored successfully
+     *
+     * @generated from protobuf field: uint64 accepted = 2;
+     */
+    accepted: string;
+    /**
+     * errors are the failures of the series of the window that were rejected
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.SeriesError errors = 3;
+     */
+    errors: SeriesError[];
+}
+/**
+ * SeriesError is the failure to store a single series
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.SeriesError
+ */
+export interface SeriesError {
+    /**
+     * index is the index of the series within its window
+     *
+     * @generated from protobuf field: uint64 index = 1;
+     */
+    index: string;
+    /**
+     * code is the gRPC status code of the failure
+     *
+     * @generated from protobuf field: int32 code = 2;
+     */
+    code: number;
+    /**
+     * message describes the failure
+     *
+     * @generated from protobuf field: string message = 3;
+     */
+    message: string;
+}
+/**
+ * WriteFoldedRequest writes a profile in the collapsed/folded stack text format
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteFoldedRequest
+ */
+export interface WriteFoldedRequest {
+    /**
+     * labels are the key value pairs to identify the profile, they must contain the __name__ label
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labels = 1;
+     */
+    labels?: LabelSet;
+    /**
+     * sample_type is the type of the sample values, defaults to samples
+     *
+     * @generated from protobuf field: string sample_type = 2;
+     */
+    sampleType: string;
+    /**
+     * sample_unit is the unit of the sample values, defaults to count
+     *
+     * @generated from protobuf field: string sample_unit = 3;
+     */
+    sampleUnit: string;
+    /**
+     * period_type is the kind of events between sampled occurrences
+     *
+     * @generated from protobuf field: string period_type = 4;
+     */
+    periodType: string;
+    /**
+     * period_unit is the unit of the period
+     *
+     * @generated from protobuf field: string period_unit = 5;
+     */
+    periodUnit: string;
+    /**
+     * period is the number of events between sampled occurrences
+     *
+     * @generated from protobuf field: int64 period = 6;
+     */
+    period: string;
+    /**
+     * timestamp is the time the profile was taken at, defaults to the time the request is received
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp timestamp = 7;
+     */
+    timestamp?: Timestamp;
+    /**
+     * duration is the time span the profile covers, a non-zero duration marks the profile as a delta profile
+     *
+     * @generated from protobuf field: google.protobuf.Duration duration = 8;
+     */
+    duration?: Duration;
+    /**
+     * folded is the folded stack text, one stack per line with frames separated by semicolons from the root to the leaf, followed by a space and the sample value
+     *
+     * @generated from protobuf field: string folded = 9;
+     */
+    folded: string;
+}
+/**
+ * WriteFoldedResponse is the empty response
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteFoldedResponse
+ */
+export interface WriteFoldedResponse {
+}
+/**
+ * WriteJFRRequest writes the profiles contained in a Java Flight Recorder recording
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteJFRRequest
+ */
+export interface WriteJFRRequest {
+    /**
+     * labels are the key value pairs to identify the profiles, the __name__ label defaults to jfr and is suffixed with the kind of event (cpu, alloc or lock) of each profile
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labels = 1;
+     */
+    labels?: LabelSet;
+    /**
+     * jfr is the JFR recording, it may be gzip or zstd compressed
+     *
+     * @generated from protobuf field: bytes jfr = 2;
+     */
+    jfr: Uint8Array;
+}
+/**
+ * WriteJFRResponse is the empty response
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteJFRResponse
+ */
+export interface WriteJFRResponse {
 }
 /**
  * RawProfileSeries represents the pprof profile and its associated labels
@@ -115,11 +336,12 @@ class WriteRawRequest$Type extends MessageType<WriteRawRequest> {
         super("parca.profilestore.v1alpha1.WriteRawRequest", [
             { no: 1, name: "tenant", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => RawProfileSeries },
-            { no: 3, name: "normalized", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+            { no: 3, name: "normalized", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 4, name: "partial", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
     create(value?: PartialMessage<WriteRawRequest>): WriteRawRequest {
-        const message = { tenant: "", series: [], normalized: false };
+        const message = { tenant: "", series: [], normalized: false, partial: false };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<WriteRawRequest>(this, message, value);
@@ -139,6 +361,9 @@ class WriteRawRequest$Type extends MessageType<WriteRawRequest> {
                 case /* bool normalized */ 3:
                     message.normalized = reader.bool();
                     break;
+                case /* bool partial */ 4:
+                    message.partial = reader.bool();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -160,6 +385,9 @@ class WriteRawRequest$Type extends MessageType<WriteRawRequest> {
         /* bool normalized = 3; */
         if (message.normalized !== false)
             writer.tag(3, WireType.Varint).bool(message.normalized);
+        /* bool partial = 4; */
+        if (message.partial !== false)
+            writer.tag(4, WireType.Varint).bool(message.partial);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -173,19 +401,40 @@ export const WriteRawRequest = new WriteRawRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class WriteRawResponse$Type extends MessageType<WriteRawResponse> {
     constructor() {
-        super("parca.profilestore.v1alpha1.WriteRawResponse", []);
+        super("parca.profilestore.v1alpha1.WriteRawResponse", [
+            { no: 1, name: "rejections", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => WriteRawRejection }
+        ]);
     }
     create(value?: PartialMessage<WriteRawResponse>): WriteRawResponse {
-        const message = {};
+        const message = { rejections: [] };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<WriteRawResponse>(this, message, value);
         return message;
     }
     internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteRawResponse): WriteRawResponse {
-        return target ?? this.create();
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.profilestore.v1alpha1.WriteRawRejection rejections */ 1:
+                    message.rejections.push(WriteRawRejection.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
     }
     internalBinaryWrite(message: WriteRawResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.profilestore.v1alpha1.WriteRawRejection rejections = 1; */
+        for (let i = 0; i < message.rejections.length; i++)
+            WriteRawRejection.internalBinaryWrite(message.rejections[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -197,6 +446,473 @@ class WriteRawResponse$Type extends MessageType<WriteRawResponse> {
  */
 export const WriteRawResponse = new WriteRawResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class WriteRawRejection$Type extends MessageType<WriteRawRejection> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteRawRejection", [
+            { no: 1, name: "series_index", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "sample_index", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "pprof_sample_index", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "code", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 5, name: "reason", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteRawRejection>): WriteRawRejection {
+        const message = { seriesIndex: "0", sampleIndex: "0", pprofSampleIndex: "0", code: 0, reason: "", message: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteRawRejection>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteRawRejection): WriteRawRejection {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 series_index */ 1:
+                    message.seriesIndex = reader.uint64().toString();
+                    break;
+                case /* int64 sample_index */ 2:
+                    message.sampleIndex = reader.int64().toString();
+                    break;
+                case /* int64 pprof_sample_index */ 3:
+                    message.pprofSampleIndex = reader.int64().toString();
+                    break;
+                case /* int32 code */ 4:
+                    message.code = reader.int32();
+                    break;
+                case /* string reason */ 5:
+                    message.reason = reader.string();
+                    break;
+                case /* string message */ 6:
+                    message.message = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteRawRejection, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 series_index = 1; */
+        if (message.seriesIndex !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.seriesIndex);
+        /* int64 sample_index = 2; */
+        if (message.sampleIndex !== "0")
+            writer.tag(2, WireType.Varint).int64(message.sampleIndex);
+        /* int64 pprof_sample_index = 3; */
+        if (message.pprofSampleIndex !== "0")
+            writer.tag(3, WireType.Varint).int64(message.pprofSampleIndex);
+        /* int32 code = 4; */
+        if (message.code !== 0)
+            writer.tag(4, WireType.Varint).int32(message.code);
+        /* string reason = 5; */
+        if (message.reason !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.reason);
+        /* string message = 6; */
+        if (message.message !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.message);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteRawRejection
+ */
+export const WriteRawRejection = new WriteRawRejection$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteRawStreamRequest$Type extends MessageType<WriteRawStreamRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteRawStreamRequest", [
+            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => RawProfileSeries },
+            { no: 2, name: "normalized", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteRawStreamRequest>): WriteRawStreamRequest {
+        const message = { series: [], normalized: false };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteRawStreamRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteRawStreamRequest): WriteRawStreamRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.profilestore.v1alpha1.RawProfileSeries series */ 1:
+                    message.series.push(RawProfileSeries.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* bool normalized */ 2:
+                    message.normalized = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteRawStreamRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.profilestore.v1alpha1.RawProfileSeries series = 1; */
+        for (let i = 0; i < message.series.length; i++)
+            RawProfileSeries.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* bool normalized = 2; */
+        if (message.normalized !== false)
+            writer.tag(2, WireType.Varint).bool(message.normalized);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteRawStreamRequest
+ */
+export const WriteRawStreamRequest = new WriteRawStreamRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteRawStreamResponse$Type extends MessageType<WriteRawStreamResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteRawStreamResponse", [
+            { no: 1, name: "window", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "accepted", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 3, name: "errors", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => SeriesError }
+        ]);
+    }
+    create(value?: PartialMessage<WriteRawStreamResponse>): WriteRawStreamResponse {
+        const message = { window: "0", accepted: "0", errors: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteRawStreamResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteRawStreamResponse): WriteRawStreamResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 window */ 1:
+                    message.window = reader.uint64().toString();
+                    break;
+                case /* uint64 accepted */ 2:
+                    message.accepted = reader.uint64().toString();
+                    break;
+                case /* repeated parca.profilestore.v1alpha1.SeriesError errors */ 3:
+                    message.errors.push(SeriesError.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteRawStreamResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 window = 1; */
+        if (message.window !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.window);
+        /* uint64 accepted = 2; */
+        if (message.accepted !== "0")
+            writer.tag(2, WireType.Varint).uint64(message.accepted);
+        /* repeated parca.profilestore.v1alpha1.SeriesError errors = 3; */
+        for (let i = 0; i < message.errors.length; i++)
+            SeriesError.internalBinaryWrite(message.errors[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteRawStreamResponse
+ */
+export const WriteRawStreamResponse = new WriteRawStreamResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class SeriesError$Type extends MessageType<SeriesError> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.SeriesError", [
+            { no: 1, name: "index", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "code", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 3, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<SeriesError>): SeriesError {
+        const message = { index: "0", code: 0, message: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<SeriesError>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SeriesError): SeriesError {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 index */ 1:
+                    message.index = reader.uint64().toString();
+                    break;
+                case /* int32 code */ 2:
+                    message.code = reader.int32();
+                    break;
+                case /* string message */ 3:
+                    message.message = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: SeriesError, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 index = 1; */
+        if (message.index !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.index);
+        /* int32 code = 2; */
+        if (message.code !== 0)
+            writer.tag(2, WireType.Varint).int32(message.code);
+        /* string message = 3; */
+        if (message.message !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.message);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.SeriesError
+ */
+export const SeriesError = new SeriesError$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteFoldedRequest$Type extends MessageType<WriteFoldedRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteFoldedRequest", [
+            { no: 1, name: "labels", kind: "message", T: () => LabelSet },
+            { no: 2, name: "sample_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "sample_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "period_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "period_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "period", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 7, name: "timestamp", kind: "message", T: () => Timestamp },
+            { no: 8, name: "duration", kind: "message", T: () => Duration },
+            { no: 9, name: "folded", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteFoldedRequest>): WriteFoldedRequest {
+        const message = { sampleType: "", sampleUnit: "", periodType: "", periodUnit: "", period: "0", folded: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteFoldedRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteFoldedRequest): WriteFoldedRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labels */ 1:
+                    message.labels = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labels);
+                    break;
+                case /* string sample_type */ 2:
+                    message.sampleType = reader.string();
+                    break;
+                case /* string sample_unit */ 3:
+                    message.sampleUnit = reader.string();
+                    break;
+                case /* string period_type */ 4:
+                    message.periodType = reader.string();
+                    break;
+                case /* string period_unit */ 5:
+                    message.periodUnit = reader.string();
+                    break;
+                case /* int64 period */ 6:
+                    message.period = reader.int64().toString();
+                    break;
+                case /* google.protobuf.Timestamp timestamp */ 7:
+                    message.timestamp = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.timestamp);
+                    break;
+                case /* google.protobuf.Duration duration */ 8:
+                    message.duration = Duration.internalBinaryRead(reader, reader.uint32(), options, message.duration);
+                    break;
+                case /* string folded */ 9:
+                    message.folded = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteFoldedRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labels = 1; */
+        if (message.labels)
+            LabelSet.internalBinaryWrite(message.labels, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* string sample_type = 2; */
+        if (message.sampleType !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.sampleType);
+        /* string sample_unit = 3; */
+        if (message.sampleUnit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.sampleUnit);
+        /* string period_type = 4; */
+        if (message.periodType !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.periodType);
+        /* string period_unit = 5; */
+        if (message.periodUnit !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.periodUnit);
+        /* int64 period = 6; */
+        if (message.period !== "0")
+            writer.tag(6, WireType.Varint).int64(message.period);
+        /* google.protobuf.Timestamp timestamp = 7; */
+        if (message.timestamp)
+            Timestamp.internalBinaryWrite(message.timestamp, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Duration duration = 8; */
+        if (message.duration)
+            Duration.internalBinaryWrite(message.duration, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* string folded = 9; */
+        if (message.folded !== "")
+            writer.tag(9, WireType.LengthDelimited).string(message.folded);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteFoldedRequest
+ */
+export const WriteFoldedRequest = new WriteFoldedRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteFoldedResponse$Type extends MessageType<WriteFoldedResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteFoldedResponse", []);
+    }
+    create(value?: PartialMessage<WriteFoldedResponse>): WriteFoldedResponse {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteFoldedResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteFoldedResponse): WriteFoldedResponse {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: WriteFoldedResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteFoldedResponse
+ */
+export const WriteFoldedResponse = new WriteFoldedResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteJFRRequest$Type extends MessageType<WriteJFRRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteJFRRequest", [
+            { no: 1, name: "labels", kind: "message", T: () => LabelSet },
+            { no: 2, name: "jfr", kind: "scalar", T: 12 /*ScalarType.BYTES*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteJFRRequest>): WriteJFRRequest {
+        const message = { jfr: new Uint8Array(0) };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteJFRRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteJFRRequest): WriteJFRRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labels */ 1:
+                    message.labels = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labels);
+                    break;
+                case /* bytes jfr */ 2:
+                    message.jfr = reader.bytes();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteJFRRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labels = 1; */
+        if (message.labels)
+            LabelSet.internalBinaryWrite(message.labels, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* bytes jfr = 2; */
+        if (message.jfr.length)
+            writer.tag(2, WireType.LengthDelimited).bytes(message.jfr);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteJFRRequest
+ */
+export const WriteJFRRequest = new WriteJFRRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteJFRResponse$Type extends MessageType<WriteJFRResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteJFRResponse", []);
+    }
+    create(value?: PartialMessage<WriteJFRResponse>): WriteJFRResponse {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteJFRResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteJFRResponse): WriteJFRResponse {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: WriteJFRResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteJFRResponse
+ */
+export const WriteJFRResponse = new WriteJFRResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class RawProfileSeries$Type extends MessageType<RawProfileSeries> {
     constructor() {
         super("parca.profilestore.v1alpha1.RawProfileSeries", [
@@ -402,5 +1118,8 @@ export const RawSample = new RawSample$Type();
  * @generated ServiceType for protobuf service parca.profilestore.v1alpha1.ProfileStoreService
  */
 export const ProfileStoreService = new ServiceType("parca.profilestore.v1alpha1.ProfileStoreService", [
-    { name: "WriteRaw", options: { "google.api.http": { post: "/profiles/writeraw", body: "*" } }, I: WriteRawRequest, O: WriteRawResponse }
+    { name: "WriteRaw", options: { "google.api.http": { post: "/profiles/writeraw", body: "*" } }, I: WriteRawRequest, O: WriteRawResponse },
+    { name: "WriteFolded", options: { "google.api.http": { post: "/profiles/writefolded", body: "*" } }, I: WriteFoldedRequest, O: WriteFoldedResponse },
+    { name: "WriteJFR", options: { "google.api.http": { post: "/profiles/writejfr", body: "*" } }, I: WriteJFRRequest, O: WriteJFRResponse },
+    { name: "WriteRawStream", serverStreaming: true, clientStreaming: true, options: {}, I: WriteRawStreamRequest, O: WriteRawStreamResponse }
 ]);
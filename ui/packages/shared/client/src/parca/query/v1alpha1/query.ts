@@ -17,6 +17,7 @@ import { Function } from "../../metastore/v1alpha1/metastore";
 import { Mapping } from "../../metastore/v1alpha1/metastore";
 import { Location } from "../../metastore/v1alpha1/metastore";
 import { LabelSet } from "../../profilestore/v1alpha1/profilestore";
+import { Duration } from "../../../google/protobuf/duration";
 import { Timestamp } from "../../../google/protobuf/timestamp";
 /**
  * ProfileTypesRequest is the request to retrieve the list of available profile types.
@@ -111,6 +112,13 @@ export interface QueryRangeRequest {
      * @generated from protobuf field: uint32 limit = 4;
      */
     limit: number;
+    /**
+     * step is the width of the time buckets the values of each series are summed in,
+     * if unset every profile is returned as a sample
+     *
+     * @generated from protobuf field: google.protobuf.Duration step = 5;
+     */
+    step?: Duration;
 }
 /**
  * QueryRangeResponse is the set of matching profile values
@@ -124,6 +132,12 @@ export interface QueryRangeResponse {
      * @generated from protobuf field: repeated parca.query.v1alpha1.MetricsSeries series = 1;
      */
     series: MetricsSeries[];
+    /**
+     * stats contains statistics about the execution of the query
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.QueryStats stats = 2;
+     */
+    stats?: QueryStats;
 }
 /**
  * MetricsSeries is a set of labels and corresponding sample values
@@ -174,6 +188,37 @@ export interface MetricsSample {
      * @generated from protobuf field: int64 value = 2;
      */
     value: string;
+    /**
+     * exemplars are the spans in which the largest parts of the value were recorded
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.Exemplar exemplars = 3;
+     */
+    exemplars: Exemplar[];
+}
+/**
+ * Exemplar is a span in which part of the value of a sample was recorded
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Exemplar
+ */
+export interface Exemplar {
+    /**
+     * trace_id is the hex encoded ID of the trace of the span
+     *
+     * @generated from protobuf field: string trace_id = 1;
+     */
+    traceId: string;
+    /**
+     * span_id is the hex encoded ID of the span
+     *
+     * @generated from protobuf field: string span_id = 2;
+     */
+    spanId: string;
+    /**
+     * value is the part of the sample value recorded in the span
+     *
+     * @generated from protobuf field: int64 value = 3;
+     */
+    value: string;
 }
 /**
  * MergeProfile contains parameters for a merge request
@@ -200,6 +245,43 @@ export interface MergeProfile {
      */
     end?: Timestamp;
 }
+/**
+ * TraceProfile contains parameters for a request merging the samples recorded in a trace or span
+ *
+ * @generated from protobuf message parca.query.v1alpha1.TraceProfile
+ */
+export interface TraceProfile {
+    /**
+     * query is the query string to match profiles for merge
+     *
+     * @generated from protobuf field: string query = 1;
+     */
+    query: string;
+    /**
+     * start is the beginning of the evaluation time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the evaluation time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     */
+    end?: Timestamp;
+    /**
+     * trace_id is the hex encoded ID of the trace whose samples are merged, may be empty if span_id is set
+     *
+     * @generated from protobuf field: string trace_id = 4;
+     */
+    traceId: string;
+    /**
+     * span_id is the hex encoded ID of the span whose samples are merged, may be empty if trace_id is set
+     *
+     * @generated from protobuf field: string span_id = 5;
+     */
+    spanId: string;
+}
 /**
  * SingleProfile contains parameters for a single profile query request
  *
@@ -238,6 +320,94 @@ export interface DiffProfile {
      */
     b?: ProfileDiffSelection;
 }
+/**
+ * LabelDiffProfile contains parameters for a diff of the merged profiles of two values of a label
+ *
+ * @generated from protobuf message parca.query.v1alpha1.LabelDiffProfile
+ */
+export interface LabelDiffProfile {
+    /**
+     * query is the query string to match profiles for both merges, it must not select the label
+     *
+     * @generated from protobuf field: string query = 1;
+     */
+    query: string;
+    /**
+     * start is the beginning of the evaluation time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the evaluation time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     */
+    end?: Timestamp;
+    /**
+     * label_name is the name of the label whose values are compared
+     *
+     * @generated from protobuf field: string label_name = 4;
+     */
+    labelName: string;
+    /**
+     * a is the label value of the base profile
+     *
+     * @generated from protobuf field: string a = 5;
+     */
+    a: string;
+    /**
+     * b is the label value of the compared profile
+     *
+     * @generated from protobuf field: string b = 6;
+     */
+    b: string;
+}
+/**
+ * RatioProfile contains parameters for a query of the per stacktrace ratio of two profile types.
+ * Reports that aggregate multiple stacktraces, like the flamegraph, sum their ratios.
+ *
+ * @generated from protobuf message parca.query.v1alpha1.RatioProfile
+ */
+export interface RatioProfile {
+    /**
+     * numerator is the profile type of the numerator, for example memory:alloc_space:bytes:space:bytes
+     *
+     * @generated from protobuf field: string numerator = 1;
+     */
+    numerator: string;
+    /**
+     * denominator is the profile type of the denominator, for example process_cpu:cpu:nanoseconds:cpu:nanoseconds:delta
+     *
+     * @generated from protobuf field: string denominator = 2;
+     */
+    denominator: string;
+    /**
+     * selector is the label selector applied to both profile types, for example {job="parca"}
+     *
+     * @generated from protobuf field: string selector = 3;
+     */
+    selector: string;
+    /**
+     * start is the beginning of the evaluation time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 4;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the evaluation time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 5;
+     */
+    end?: Timestamp;
+    /**
+     * scale is multiplied with each ratio before it is rounded to an integer, as ratios are reported as integers.
+     * It defaults to 1000000, so ratios are reported in millionths. Ratios far below 1 / scale are reported as 0.
+     *
+     * @generated from protobuf field: double scale = 6;
+     */
+    scale: number;
+}
 /**
  * ProfileDiffSelection contains the parameters of a diff selection
  *
@@ -331,6 +501,30 @@ export interface QueryRequest {
          * @generated from protobuf field: parca.query.v1alpha1.SingleProfile single = 4;
          */
         single: SingleProfile;
+    } | {
+        oneofKind: "labelDiff";
+        /**
+         * label_diff contains the label diff query options
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.LabelDiffProfile label_diff = 6;
+         */
+        labelDiff: LabelDiffProfile;
+    } | {
+        oneofKind: "ratio";
+        /**
+         * ratio contains the ratio query options
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.RatioProfile ratio = 7;
+         */
+        ratio: RatioProfile;
+    } | {
+        oneofKind: "trace";
+        /**
+         * trace contains the trace query options
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.TraceProfile trace = 8;
+         */
+        trace: TraceProfile;
     } | {
         oneofKind: undefined;
     };
@@ -364,7 +558,25 @@ export enum QueryRequest_Mode {
      *
      * @generated from protobuf enum value: MODE_MERGE = 2;
      */
-    MERGE = 2
+    MERGE = 2,
+    /**
+     * MODE_LABEL_DIFF is a diff query of two values of a label
+     *
+     * @generated from protobuf enum value: MODE_LABEL_DIFF = 3;
+     */
+    LABEL_DIFF = 3,
+    /**
+     * MODE_RATIO is a query of the per stacktrace ratio of two profile types
+     *
+     * @generated from protobuf enum value: MODE_RATIO = 4;
+     */
+    RATIO = 4,
+    /**
+     * MODE_TRACE is a merge query of the samples recorded in a trace or span
+     *
+     * @generated from protobuf enum value: MODE_TRACE = 5;
+     */
+    TRACE = 5
 }
 /**
  * ReportType is the type of report to return
@@ -770,6 +982,55 @@ export interface QueryResponse {
     } | {
         oneofKind: undefined;
     };
+    /**
+     * stats contains statistics about the execution of the query
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.QueryStats stats = 9;
+     */
+    stats?: QueryStats;
+}
+/**
+ * QueryStats contains statistics about the execution of a query
+ *
+ * @generated from protobuf message parca.query.v1alpha1.QueryStats
+ */
+export interface QueryStats {
+    /**
+     * rows_scanned is the number of rows read from storage, before they are filtered
+     *
+     * @generated from protobuf field: uint64 rows_scanned = 1;
+     */
+    rowsScanned: string;
+    /**
+     * distinct_stacktraces is the number of distinct stacktraces resolved
+     *
+     * @generated from protobuf field: uint64 distinct_stacktraces = 2;
+     */
+    distinctStacktraces: string;
+    /**
+     * metastore_lookups is the number of requests made to the metastore
+     *
+     * @generated from protobuf field: uint64 metastore_lookups = 3;
+     */
+    metastoreLookups: string;
+    /**
+     * select_duration is the time spent selecting the data from storage
+     *
+     * @generated from protobuf field: google.protobuf.Duration select_duration = 4;
+     */
+    selectDuration?: Duration;
+    /**
+     * resolve_stacktraces_duration is the time spent resolving stacktraces through the metastore
+     *
+     * @generated from protobuf field: google.protobuf.Duration resolve_stacktraces_duration = 5;
+     */
+    resolveStacktracesDuration?: Duration;
+    /**
+     * render_report_duration is the time spent rendering the requested report
+     *
+     * @generated from protobuf field: google.protobuf.Duration render_report_duration = 6;
+     */
+    renderReportDuration?: Duration;
 }
 /**
  * SeriesRequest is unimplemented
@@ -1110,7 +1371,8 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
             { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "start", kind: "message", T: () => Timestamp },
             { no: 3, name: "end", kind: "message", T: () => Timestamp },
-            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "step", kind: "message", T: () => Duration }
         ]);
     }
     create(value?: PartialMessage<QueryRangeRequest>): QueryRangeRequest {
@@ -1137,6 +1399,9 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
                 case /* uint32 limit */ 4:
                     message.limit = reader.uint32();
                     break;
+                case /* google.protobuf.Duration step */ 5:
+                    message.step = Duration.internalBinaryRead(reader, reader.uint32(), options, message.step);
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1161,6 +1426,9 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
         /* uint32 limit = 4; */
         if (message.limit !== 0)
             writer.tag(4, WireType.Varint).uint32(message.limit);
+        /* google.protobuf.Duration step = 5; */
+        if (message.step)
+            Duration.internalBinaryWrite(message.step, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1175,7 +1443,8 @@ export const QueryRangeRequest = new QueryRangeRequest$Type();
 class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
     constructor() {
         super("parca.query.v1alpha1.QueryRangeResponse", [
-            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSeries }
+            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSeries },
+            { no: 2, name: "stats", kind: "message", T: () => QueryStats }
         ]);
     }
     create(value?: PartialMessage<QueryRangeResponse>): QueryRangeResponse {
@@ -1193,6 +1462,9 @@ class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
                 case /* repeated parca.query.v1alpha1.MetricsSeries series */ 1:
                     message.series.push(MetricsSeries.internalBinaryRead(reader, reader.uint32(), options));
                     break;
+                case /* parca.query.v1alpha1.QueryStats stats */ 2:
+                    message.stats = QueryStats.internalBinaryRead(reader, reader.uint32(), options, message.stats);
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1208,6 +1480,9 @@ class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
         /* repeated parca.query.v1alpha1.MetricsSeries series = 1; */
         for (let i = 0; i < message.series.length; i++)
             MetricsSeries.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.QueryStats stats = 2; */
+        if (message.stats)
+            QueryStats.internalBinaryWrite(message.stats, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1291,11 +1566,12 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
     constructor() {
         super("parca.query.v1alpha1.MetricsSample", [
             { no: 1, name: "timestamp", kind: "message", T: () => Timestamp },
-            { no: 2, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+            { no: 2, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "exemplars", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => Exemplar }
         ]);
     }
     create(value?: PartialMessage<MetricsSample>): MetricsSample {
-        const message = { value: "0" };
+        const message = { value: "0", exemplars: [] };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<MetricsSample>(this, message, value);
@@ -1312,6 +1588,9 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
                 case /* int64 value */ 2:
                     message.value = reader.int64().toString();
                     break;
+                case /* repeated parca.query.v1alpha1.Exemplar exemplars */ 3:
+                    message.exemplars.push(Exemplar.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1330,6 +1609,9 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
         /* int64 value = 2; */
         if (message.value !== "0")
             writer.tag(2, WireType.Varint).int64(message.value);
+        /* repeated parca.query.v1alpha1.Exemplar exemplars = 3; */
+        for (let i = 0; i < message.exemplars.length; i++)
+            Exemplar.internalBinaryWrite(message.exemplars[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1341,6 +1623,67 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
  */
 export const MetricsSample = new MetricsSample$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class Exemplar$Type extends MessageType<Exemplar> {
+    constructor() {
+        super("parca.query.v1alpha1.Exemplar", [
+            { no: 1, name: "trace_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "span_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        ]);
+    }
+    create(value?: PartialMessage<Exemplar>): Exemplar {
+        const message = { traceId: "", spanId: "", value: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<Exemplar>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Exemplar): Exemplar {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string trace_id */ 1:
+                    message.traceId = reader.string();
+                    break;
+                case /* string span_id */ 2:
+                    message.spanId = reader.string();
+                    break;
+                case /* int64 value */ 3:
+                    message.value = reader.int64().toString();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: Exemplar, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string trace_id = 1; */
+        if (message.traceId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.traceId);
+        /* string span_id = 2; */
+        if (message.spanId !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.spanId);
+        /* int64 value = 3; */
+        if (message.value !== "0")
+            writer.tag(3, WireType.Varint).int64(message.value);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Exemplar
+ */
+export const Exemplar = new Exemplar$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class MergeProfile$Type extends MessageType<MergeProfile> {
     constructor() {
         super("parca.query.v1alpha1.MergeProfile", [
@@ -1402,6 +1745,81 @@ class MergeProfile$Type extends MessageType<MergeProfile> {
  */
 export const MergeProfile = new MergeProfile$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class TraceProfile$Type extends MessageType<TraceProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.TraceProfile", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "trace_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "span_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TraceProfile>): TraceProfile {
+        const message = { query: "", traceId: "", spanId: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<TraceProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TraceProfile): TraceProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* string trace_id */ 4:
+                    message.traceId = reader.string();
+                    break;
+                case /* string span_id */ 5:
+                    message.spanId = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TraceProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* string trace_id = 4; */
+        if (message.traceId !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.traceId);
+        /* string span_id = 5; */
+        if (message.spanId !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.spanId);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TraceProfile
+ */
+export const TraceProfile = new TraceProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class SingleProfile$Type extends MessageType<SingleProfile> {
     constructor() {
         super("parca.query.v1alpha1.SingleProfile", [
@@ -1510,6 +1928,170 @@ class DiffProfile$Type extends MessageType<DiffProfile> {
  */
 export const DiffProfile = new DiffProfile$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class LabelDiffProfile$Type extends MessageType<LabelDiffProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.LabelDiffProfile", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "label_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "a", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "b", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<LabelDiffProfile>): LabelDiffProfile {
+        const message = { query: "", labelName: "", a: "", b: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<LabelDiffProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: LabelDiffProfile): LabelDiffProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* string label_name */ 4:
+                    message.labelName = reader.string();
+                    break;
+                case /* string a */ 5:
+                    message.a = reader.string();
+                    break;
+                case /* string b */ 6:
+                    message.b = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: LabelDiffProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* string label_name = 4; */
+        if (message.labelName !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.labelName);
+        /* string a = 5; */
+        if (message.a !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.a);
+        /* string b = 6; */
+        if (message.b !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.b);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.LabelDiffProfile
+ */
+export const LabelDiffProfile = new LabelDiffProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class RatioProfile$Type extends MessageType<RatioProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.RatioProfile", [
+            { no: 1, name: "numerator", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "denominator", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "selector", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "start", kind: "message", T: () => Timestamp },
+            { no: 5, name: "end", kind: "message", T: () => Timestamp },
+            { no: 6, name: "scale", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ }
+        ]);
+    }
+    create(value?: PartialMessage<RatioProfile>): RatioProfile {
+        const message = { numerator: "", denominator: "", selector: "", scale: 0 };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<RatioProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RatioProfile): RatioProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string numerator */ 1:
+                    message.numerator = reader.string();
+                    break;
+                case /* string denominator */ 2:
+                    message.denominator = reader.string();
+                    break;
+                case /* string selector */ 3:
+                    message.selector = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 4:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 5:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* double scale */ 6:
+                    message.scale = reader.double();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: RatioProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string numerator = 1; */
+        if (message.numerator !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.numerator);
+        /* string denominator = 2; */
+        if (message.denominator !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.denominator);
+        /* string selector = 3; */
+        if (message.selector !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.selector);
+        /* google.protobuf.Timestamp start = 4; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 5; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* double scale = 6; */
+        if (message.scale !== 0)
+            writer.tag(6, WireType.Bit64).double(message.scale);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.RatioProfile
+ */
+export const RatioProfile = new RatioProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
     constructor() {
         super("parca.query.v1alpha1.ProfileDiffSelection", [
@@ -1584,6 +2166,9 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
             { no: 2, name: "diff", kind: "message", oneof: "options", T: () => DiffProfile },
             { no: 3, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
             { no: 4, name: "single", kind: "message", oneof: "options", T: () => SingleProfile },
+            { no: 6, name: "label_diff", kind: "message", oneof: "options", T: () => LabelDiffProfile },
+            { no: 7, name: "ratio", kind: "message", oneof: "options", T: () => RatioProfile },
+            { no: 8, name: "trace", kind: "message", oneof: "options", T: () => TraceProfile },
             { no: 5, name: "report_type", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.ReportType", QueryRequest_ReportType, "REPORT_TYPE_"] }
         ]);
     }
@@ -1620,6 +2205,24 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
                         single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
                     };
                     break;
+                case /* parca.query.v1alpha1.LabelDiffProfile label_diff */ 6:
+                    message.options = {
+                        oneofKind: "labelDiff",
+                        labelDiff: LabelDiffProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).labelDiff)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.RatioProfile ratio */ 7:
+                    message.options = {
+                        oneofKind: "ratio",
+                        ratio: RatioProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).ratio)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.TraceProfile trace */ 8:
+                    message.options = {
+                        oneofKind: "trace",
+                        trace: TraceProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).trace)
+                    };
+                    break;
                 case /* parca.query.v1alpha1.QueryRequest.ReportType report_type */ 5:
                     message.reportType = reader.int32();
                     break;
@@ -1647,6 +2250,15 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
         /* parca.query.v1alpha1.SingleProfile single = 4; */
         if (message.options.oneofKind === "single")
             SingleProfile.internalBinaryWrite(message.options.single, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.LabelDiffProfile label_diff = 6; */
+        if (message.options.oneofKind === "labelDiff")
+            LabelDiffProfile.internalBinaryWrite(message.options.labelDiff, writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.RatioProfile ratio = 7; */
+        if (message.options.oneofKind === "ratio")
+            RatioProfile.internalBinaryWrite(message.options.ratio, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.TraceProfile trace = 8; */
+        if (message.options.oneofKind === "trace")
+            TraceProfile.internalBinaryWrite(message.options.trace, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
         /* parca.query.v1alpha1.QueryRequest.ReportType report_type = 5; */
         if (message.reportType !== 0)
             writer.tag(5, WireType.Varint).int32(message.reportType);
@@ -2401,7 +3013,8 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
             { no: 5, name: "flamegraph", kind: "message", oneof: "report", T: () => Flamegraph },
             { no: 6, name: "pprof", kind: "scalar", oneof: "report", T: 12 /*ScalarType.BYTES*/ },
             { no: 7, name: "top", kind: "message", oneof: "report", T: () => Top },
-            { no: 8, name: "callgraph", kind: "message", oneof: "report", T: () => Callgraph }
+            { no: 8, name: "callgraph", kind: "message", oneof: "report", T: () => Callgraph },
+            { no: 9, name: "stats", kind: "message", T: () => QueryStats }
         ]);
     }
     create(value?: PartialMessage<QueryResponse>): QueryResponse {
@@ -2440,6 +3053,9 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
                         callgraph: Callgraph.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).callgraph)
                     };
                     break;
+                case /* parca.query.v1alpha1.QueryStats stats */ 9:
+                    message.stats = QueryStats.internalBinaryRead(reader, reader.uint32(), options, message.stats);
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2464,6 +3080,9 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
         /* parca.query.v1alpha1.Callgraph callgraph = 8; */
         if (message.report.oneofKind === "callgraph")
             Callgraph.internalBinaryWrite(message.report.callgraph, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.QueryStats stats = 9; */
+        if (message.stats)
+            QueryStats.internalBinaryWrite(message.stats, writer.tag(9, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2475,6 +3094,88 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
  */
 export const QueryResponse = new QueryResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class QueryStats$Type extends MessageType<QueryStats> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryStats", [
+            { no: 1, name: "rows_scanned", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "distinct_stacktraces", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 3, name: "metastore_lookups", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 4, name: "select_duration", kind: "message", T: () => Duration },
+            { no: 5, name: "resolve_stacktraces_duration", kind: "message", T: () => Duration },
+            { no: 6, name: "render_report_duration", kind: "message", T: () => Duration }
+        ]);
+    }
+    create(value?: PartialMessage<QueryStats>): QueryStats {
+        const message = { rowsScanned: "0", distinctStacktraces: "0", metastoreLookups: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<QueryStats>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryStats): QueryStats {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 rows_scanned */ 1:
+                    message.rowsScanned = reader.uint64().toString();
+                    break;
+                case /* uint64 distinct_stacktraces */ 2:
+                    message.distinctStacktraces = reader.uint64().toString();
+                    break;
+                case /* uint64 metastore_lookups */ 3:
+                    message.metastoreLookups = reader.uint64().toString();
+                    break;
+                case /* google.protobuf.Duration select_duration */ 4:
+                    message.selectDuration = Duration.internalBinaryRead(reader, reader.uint32(), options, message.selectDuration);
+                    break;
+                case /* google.protobuf.Duration resolve_stacktraces_duration */ 5:
+                    message.resolveStacktracesDuration = Duration.internalBinaryRead(reader, reader.uint32(), options, message.resolveStacktracesDuration);
+                    break;
+                case /* google.protobuf.Duration render_report_duration */ 6:
+                    message.renderReportDuration = Duration.internalBinaryRead(reader, reader.uint32(), options, message.renderReportDuration);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryStats, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 rows_scanned = 1; */
+        if (message.rowsScanned !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.rowsScanned);
+        /* uint64 distinct_stacktraces = 2; */
+        if (message.distinctStacktraces !== "0")
+            writer.tag(2, WireType.Varint).uint64(message.distinctStacktraces);
+        /* uint64 metastore_lookups = 3; */
+        if (message.metastoreLookups !== "0")
+            writer.tag(3, WireType.Varint).uint64(message.metastoreLookups);
+        /* google.protobuf.Duration select_duration = 4; */
+        if (message.selectDuration)
+            Duration.internalBinaryWrite(message.selectDuration, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Duration resolve_stacktraces_duration = 5; */
+        if (message.resolveStacktracesDuration)
+            Duration.internalBinaryWrite(message.resolveStacktracesDuration, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Duration render_report_duration = 6; */
+        if (message.renderReportDuration)
+            Duration.internalBinaryWrite(message.renderReportDuration, writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryStats
+ */
+export const QueryStats = new QueryStats$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class SeriesRequest$Type extends MessageType<SeriesRequest> {
     constructor() {
         super("parca.query.v1alpha1.SeriesRequest", [